@@ -9,6 +9,7 @@ import (
 	"os"
 	"runtime"
 	"runtime/debug"
+	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -18,6 +19,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/dlvhdr/gh-dash/v4/config"
+	"github.com/dlvhdr/gh-dash/v4/data"
 	"github.com/dlvhdr/gh-dash/v4/ui"
 	"github.com/dlvhdr/gh-dash/v4/ui/markdown"
 )
@@ -37,6 +39,10 @@ var (
 		Short:   "A gh extension that shows a configurable dashboard of pull requests and issues.",
 		Version: "",
 		Args:    cobra.MaximumNArgs(1),
+		Example: `  gh dash
+  gh dash https://github.com/dlvhdr/gh-dash/pull/123
+  gh dash dlvhdr/gh-dash#123
+  gh dash '#123'`,
 	}
 )
 
@@ -47,7 +53,7 @@ func Execute() {
 	}
 }
 
-func createModel(repoPath *string, configPath string, debug bool) (ui.Model, *os.File) {
+func createModel(repoPath *string, configPath string, itemRef *string, debug bool) (ui.Model, *os.File) {
 	var loggerFile *os.File
 
 	if debug {
@@ -68,7 +74,7 @@ func createModel(repoPath *string, configPath string, debug bool) (ui.Model, *os
 		log.SetLevel(log.FatalLevel)
 	}
 
-	return ui.NewModel(repoPath, configPath), loggerFile
+	return ui.NewModel(repoPath, configPath, itemRef), loggerFile
 }
 
 func buildVersion(version, commit, date, builtBy string) string {
@@ -119,10 +125,14 @@ func init() {
 	)
 
 	rootCmd.Run = func(_ *cobra.Command, args []string) {
-		var repo *string
+		var repo, itemRef *string
 		repos := config.IsFeatureEnabled(config.FF_REPO_VIEW)
-		if repos && len(args) > 0 {
-			repo = &args[0]
+		if len(args) > 0 {
+			if _, err := data.ParseItemRef(args[0], ""); err == nil || strings.HasPrefix(args[0], "#") {
+				itemRef = &args[0]
+			} else if repos {
+				repo = &args[0]
+			}
 		}
 		debug, err := rootCmd.Flags().GetBool("debug")
 		if err != nil {
@@ -133,16 +143,19 @@ func init() {
 		lipgloss.SetHasDarkBackground(termenv.HasDarkBackground())
 		markdown.InitializeMarkdownStyle(termenv.HasDarkBackground())
 
-		model, logger := createModel(repo, cfgFile, debug)
+		model, logger := createModel(repo, cfgFile, itemRef, debug)
 		if logger != nil {
 			defer logger.Close()
 		}
 
-		p := tea.NewProgram(
-			model,
-			tea.WithAltScreen(),
-			tea.WithReportFocus(),
-		)
+		opts := []tea.ProgramOption{tea.WithAltScreen(), tea.WithReportFocus()}
+		// The dashboard shows config errors once it starts, so the mouse is
+		// captured like by default when the config can't be parsed.
+		if cfg, err := config.ParseConfig(cfgFile); err != nil || cfg.Mouse {
+			opts = append(opts, tea.WithMouseCellMotion())
+		}
+
+		p := tea.NewProgram(model, opts...)
 		if _, err := p.Run(); err != nil {
 			log.Fatal("Failed starting the TUI", err)
 		}
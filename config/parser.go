@@ -41,11 +41,20 @@ type SectionConfig struct {
 	Type    *ViewType
 }
 
+type DraftsDisplay string
+
+const (
+	DraftsShow DraftsDisplay = "show"
+	DraftsHide DraftsDisplay = "hide"
+	DraftsDim  DraftsDisplay = "dim"
+)
+
 type PrsSectionConfig struct {
 	Title   string
 	Filters string
 	Limit   *int            `yaml:"limit,omitempty"`
 	Layout  PrsLayoutConfig `yaml:"layout,omitempty"`
+	Drafts  DraftsDisplay   `yaml:"drafts,omitempty" validate:"omitempty,oneof=show hide dim"`
 	Type    *ViewType
 }
 
@@ -56,9 +65,19 @@ type IssuesSectionConfig struct {
 	Layout  IssuesLayoutConfig `yaml:"layout,omitempty"`
 }
 
+type PreviewPosition string
+
+const (
+	PreviewRight  PreviewPosition = "right"
+	PreviewBottom PreviewPosition = "bottom"
+	PreviewAuto   PreviewPosition = "auto"
+)
+
 type PreviewConfig struct {
-	Open  bool
-	Width int
+	Open     bool
+	Width    int
+	Height   int             `yaml:"height"   validate:"gt=0"`
+	Position PreviewPosition `yaml:"position" validate:"omitempty,oneof=right bottom auto"`
 }
 
 type ColumnConfig struct {
@@ -76,7 +95,9 @@ type PrsLayoutConfig struct {
 	ReviewStatus ColumnConfig `yaml:"reviewStatus,omitempty"`
 	State        ColumnConfig `yaml:"state,omitempty"`
 	Ci           ColumnConfig `yaml:"ci,omitempty"`
+	Viewed       ColumnConfig `yaml:"viewed,omitempty"`
 	Lines        ColumnConfig `yaml:"lines,omitempty"`
+	Notes        ColumnConfig `yaml:"notes,omitempty"`
 }
 
 type IssuesLayoutConfig struct {
@@ -88,6 +109,7 @@ type IssuesLayoutConfig struct {
 	Assignees ColumnConfig `yaml:"assignees,omitempty"`
 	Comments  ColumnConfig `yaml:"comments,omitempty"`
 	Reactions ColumnConfig `yaml:"reactions,omitempty"`
+	Notes     ColumnConfig `yaml:"notes,omitempty"`
 }
 
 type LayoutConfig struct {
@@ -103,6 +125,9 @@ type Defaults struct {
 	Layout                 LayoutConfig  `yaml:"layout,omitempty"`
 	RefetchIntervalMinutes int           `yaml:"refetchIntervalMinutes,omitempty"`
 	DateFormat             string        `yaml:"dateFormat,omitempty"`
+	// MutedAuthors are the users whose PRs and issues are hidden from the
+	// sections, like bots.
+	MutedAuthors []string `yaml:"mutedAuthors,omitempty"`
 }
 
 type RepoConfig struct {
@@ -128,7 +153,14 @@ func (kb Keybinding) NewBinding(previous *key.Binding) key.Binding {
 	)
 }
 
+// DefaultLeader is the key <leader> stands for in keybindings when no leader
+// is set.
+const DefaultLeader = `\`
+
 type Keybindings struct {
+	// Leader is the key that <leader> stands for in the keys of keybindings,
+	// like "<leader>m s".
+	Leader    string       `yaml:"leader,omitempty"`
 	Universal []Keybinding `yaml:"universal"`
 	Issues    []Keybinding `yaml:"issues"`
 	Prs       []Keybinding `yaml:"prs"`
@@ -187,7 +219,7 @@ type ThemeConfig struct {
 }
 
 type Config struct {
-	PRSections     []PrsSectionConfig    `yaml:"prSections"`
+	PRSections     []PrsSectionConfig    `yaml:"prSections"     validate:"dive"`
 	IssuesSections []IssuesSectionConfig `yaml:"issuesSections"`
 	Repo           RepoConfig            `yaml:"repo"`
 	Defaults       Defaults              `yaml:"defaults"`
@@ -196,6 +228,7 @@ type Config struct {
 	Theme          *ThemeConfig          `yaml:"theme,omitempty" validate:"omitempty"`
 	Pager          Pager                 `yaml:"pager"`
 	ConfirmQuit    bool                  `yaml:"confirmQuit"`
+	Mouse          bool                  `yaml:"mouse"`
 }
 
 type configError struct {
@@ -210,8 +243,10 @@ func (parser ConfigParser) getDefaultConfig() Config {
 	return Config{
 		Defaults: Defaults{
 			Preview: PreviewConfig{
-				Open:  true,
-				Width: 50,
+				Open:     true,
+				Width:    50,
+				Height:   20,
+				Position: PreviewRight,
 			},
 			PrsLimit:               20,
 			IssuesLimit:            20,
@@ -236,9 +271,15 @@ func (parser ConfigParser) getDefaultConfig() Config {
 						Width:  utils.IntPtr(15),
 						Hidden: utils.BoolPtr(true),
 					},
+					Viewed: ColumnConfig{
+						Width: utils.IntPtr(lipgloss.Width(" 100/100 ")),
+					},
 					Lines: ColumnConfig{
 						Width: utils.IntPtr(lipgloss.Width(" +31.4k -31.6k ")),
 					},
+					Notes: ColumnConfig{
+						Width: utils.IntPtr(3),
+					},
 				},
 				Issues: IssuesLayoutConfig{
 					UpdatedAt: ColumnConfig{
@@ -254,6 +295,9 @@ func (parser ConfigParser) getDefaultConfig() Config {
 						Width:  utils.IntPtr(20),
 						Hidden: utils.BoolPtr(true),
 					},
+					Notes: ColumnConfig{
+						Width: utils.IntPtr(3),
+					},
 				},
 			},
 		},
@@ -290,6 +334,7 @@ func (parser ConfigParser) getDefaultConfig() Config {
 			},
 		},
 		Keybindings: Keybindings{
+			Leader:    DefaultLeader,
 			Universal: []Keybinding{},
 			Issues:    []Keybinding{},
 			Prs:       []Keybinding{},
@@ -305,6 +350,7 @@ func (parser ConfigParser) getDefaultConfig() Config {
 			},
 		},
 		ConfirmQuit: false,
+		Mouse:       true,
 	}
 }
 
@@ -423,6 +469,7 @@ func (parser ConfigParser) readConfigFile(path string) (Config, error) {
 	if err != nil {
 		return config, err
 	}
+	config.Keybindings.normalizeKeys()
 	repoFF := IsFeatureEnabled(FF_REPO_VIEW)
 	if config.Defaults.View == RepoView && !repoFF {
 		config.Defaults.View = PRsView
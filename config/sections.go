@@ -0,0 +1,186 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"strings"
+
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// savedSection is a section as it's written to the configuration file.
+type savedSection struct {
+	Title   string `yaml:"title"`
+	Filters string `yaml:"filters"`
+	Limit   *int   `yaml:"limit,omitempty"`
+}
+
+// AddPrsSection adds section to the end of the prSections of the
+// configuration file at path, or the default configuration file if path is
+// empty. The rest of the file, including its comments, is kept as it is.
+func AddPrsSection(path string, section SectionConfig) error {
+	return addSection(path, "prSections", section)
+}
+
+// AddIssuesSection adds section to the end of the issuesSections of the
+// configuration file at path, or the default configuration file if path is
+// empty. The rest of the file, including its comments, is kept as it is.
+func AddIssuesSection(path string, section SectionConfig) error {
+	return addSection(path, "issuesSections", section)
+}
+
+func addSection(path string, key string, section SectionConfig) error {
+	if path == "" {
+		var err error
+		path, err = ConfigParser{}.getDefaultConfigFileOrCreateIfMissing()
+		if err != nil {
+			return err
+		}
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	updated, err := insertSection(contents, key, savedSection{
+		Title:   section.Title,
+		Filters: section.Filters,
+		Limit:   section.Limit,
+	})
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, updated, info.Mode())
+}
+
+// insertSection adds section to the sequence under key. When the sequence
+// is written in block style, the section is inserted as text after its last
+// item so the formatting of the file doesn't change.
+func insertSection(contents []byte, key string, section savedSection) ([]byte, error) {
+	var doc yamlv3.Node
+	if err := yamlv3.Unmarshal(contents, &doc); err != nil {
+		return nil, err
+	}
+
+	text := string(contents)
+	if text != "" && !strings.HasSuffix(text, "\n") {
+		text += "\n"
+	}
+	if len(doc.Content) == 0 {
+		item, err := renderSectionItem(section, "  ", 2)
+		if err != nil {
+			return nil, err
+		}
+		return []byte(text + key + ":\n" + item), nil
+	}
+
+	root := doc.Content[0]
+	if root.Kind != yamlv3.MappingNode {
+		return nil, errors.New("the configuration file isn't a YAML mapping")
+	}
+
+	i := 0
+	for i < len(root.Content) && root.Content[i].Value != key {
+		i += 2
+	}
+	if i >= len(root.Content) {
+		item, err := renderSectionItem(section, "  ", 2)
+		if err != nil {
+			return nil, err
+		}
+		return []byte(text + "\n" + key + ":\n" + item), nil
+	}
+
+	value := root.Content[i+1]
+	if value.Kind != yamlv3.SequenceNode || value.Style&yamlv3.FlowStyle != 0 || len(value.Content) == 0 {
+		return replaceSequence(&doc, root.Content[i], value, section)
+	}
+
+	lines := strings.SplitAfter(text, "\n")
+	first := value.Content[0]
+	firstLine := lines[first.Line-1]
+	dash := strings.LastIndex(firstLine[:first.Column-1], "-")
+	if dash == -1 {
+		return replaceSequence(&doc, root.Content[i], value, section)
+	}
+
+	end := len(lines)
+	if i+2 < len(root.Content) {
+		end = root.Content[i+2].Line - 1
+	}
+	// Comments and blank lines right before the next key belong to it
+	for end > 0 {
+		line := strings.TrimRight(lines[end-1], "\r\n")
+		if strings.TrimSpace(line) != "" && !strings.HasPrefix(line, "#") {
+			break
+		}
+		end--
+	}
+
+	item, err := renderSectionItem(section, strings.Repeat(" ", dash), first.Column-1-dash)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(strings.Join(lines[:end], "") + item + strings.Join(lines[end:], "")), nil
+}
+
+// renderSectionItem renders section as an item of a block sequence whose
+// dashes are preceded by indent and followed by offset-1 spaces.
+func renderSectionItem(section savedSection, indent string, offset int) (string, error) {
+	var b bytes.Buffer
+	enc := yamlv3.NewEncoder(&b)
+	enc.SetIndent(2)
+	if err := enc.Encode(section); err != nil {
+		return "", err
+	}
+
+	var item strings.Builder
+	for j, line := range strings.Split(strings.TrimSuffix(b.String(), "\n"), "\n") {
+		item.WriteString(indent)
+		if j == 0 {
+			item.WriteString("-" + strings.Repeat(" ", offset-1))
+		} else {
+			item.WriteString(strings.Repeat(" ", offset))
+		}
+		item.WriteString(line + "\n")
+	}
+	return item.String(), nil
+}
+
+// replaceSequence replaces the value of key, which is empty or written in
+// flow style, with a block sequence of its items and section, and re-encodes
+// the file.
+func replaceSequence(doc *yamlv3.Node, key *yamlv3.Node, value *yamlv3.Node, section savedSection) ([]byte, error) {
+	var item yamlv3.Node
+	if err := item.Encode(section); err != nil {
+		return nil, err
+	}
+
+	var items []*yamlv3.Node
+	if value.Kind == yamlv3.SequenceNode {
+		items = value.Content
+	}
+	// Comments after the values of keys are only kept for scalars
+	if key.LineComment == "" {
+		key.LineComment = value.LineComment
+	}
+	*value = yamlv3.Node{
+		Kind:    yamlv3.SequenceNode,
+		Tag:     "!!seq",
+		Content: append(items, &item),
+	}
+
+	var b bytes.Buffer
+	enc := yamlv3.NewEncoder(&b)
+	enc.SetIndent(2)
+	if err := enc.Encode(doc); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
@@ -0,0 +1,95 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dlvhdr/gh-dash/v4/config"
+	"github.com/dlvhdr/gh-dash/v4/utils"
+)
+
+func TestAddPrsSection(t *testing.T) {
+	testCases := map[string]struct {
+		contents string
+		section  config.SectionConfig
+		want     string
+	}{
+		"keeps comments and formatting": {
+			contents: `# My dashboard
+prSections:
+  - title: Mine # authored by me
+    filters: is:open author:@me
+  # reviews
+  - title: Review
+    filters: is:open review-requested:@me
+
+# issues
+issuesSections:
+- title: Issues
+  filters: is:open
+`,
+			section: config.SectionConfig{Title: "Bugs", Filters: "is:open label:bug", Limit: utils.IntPtr(10)},
+			want: `# My dashboard
+prSections:
+  - title: Mine # authored by me
+    filters: is:open author:@me
+  # reviews
+  - title: Review
+    filters: is:open review-requested:@me
+  - title: Bugs
+    filters: is:open label:bug
+    limit: 10
+
+# issues
+issuesSections:
+- title: Issues
+  filters: is:open
+`,
+		},
+		"last key without trailing newline": {
+			contents: "prSections:\n    -   title: Mine\n        filters: is:open",
+			section:  config.SectionConfig{Title: "Mine: drafts", Filters: "is:open draft:true"},
+			want: `prSections:
+    -   title: Mine
+        filters: is:open
+    -   title: 'Mine: drafts'
+        filters: is:open draft:true
+`,
+		},
+		"missing key": {
+			contents: "repoPaths:\n  dlvhdr/gh-dash: ~/code/gh-dash\n",
+			section:  config.SectionConfig{Title: "Mine", Filters: "author:@me"},
+			want: `repoPaths:
+  dlvhdr/gh-dash: ~/code/gh-dash
+
+prSections:
+  - title: Mine
+    filters: author:@me
+`,
+		},
+		"empty sequence": {
+			contents: "prSections: [] # none yet\n",
+			section:  config.SectionConfig{Title: "Mine", Filters: "author:@me"},
+			want: `prSections: # none yet
+  - title: Mine
+    filters: author:@me
+`,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.yml")
+			require.NoError(t, os.WriteFile(path, []byte(tc.contents), 0o600))
+
+			require.NoError(t, config.AddPrsSection(path, tc.section))
+
+			contents, err := os.ReadFile(path)
+			require.NoError(t, err)
+			require.Equal(t, tc.want, string(contents))
+		})
+	}
+}
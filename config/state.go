@@ -0,0 +1,61 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v2"
+)
+
+const DEFAULT_XDG_STATE_DIRNAME = ".local/state"
+
+// StateFilePath returns the path of the file called name in the directory
+// gh-dash keeps its state in, which is $XDG_STATE_HOME/gh-dash or
+// ~/.local/state/gh-dash. The directory is created if it's missing.
+func StateFilePath(name string) (string, error) {
+	stateDir := os.Getenv("XDG_STATE_HOME")
+	if stateDir == "" {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		stateDir = filepath.Join(homeDir, DEFAULT_XDG_STATE_DIRNAME)
+	}
+
+	dashStateDir := filepath.Join(stateDir, DashDir)
+	if err := os.MkdirAll(dashStateDir, os.ModePerm); err != nil {
+		return "", err
+	}
+	return filepath.Join(dashStateDir, name), nil
+}
+
+// ReadState decodes the YAML state file called name into out. out is left
+// as it is when the file doesn't exist yet.
+func ReadState(name string, out any) error {
+	path, err := StateFilePath(name)
+	if err != nil {
+		return err
+	}
+	contents, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return yaml.Unmarshal(contents, out)
+}
+
+// WriteState encodes in as YAML to the state file called name.
+func WriteState(name string, in any) error {
+	path, err := StateFilePath(name)
+	if err != nil {
+		return err
+	}
+	contents, err := yaml.Marshal(in)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, contents, 0o600)
+}
@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"os"
+	"strings"
 )
 
 func (cfg Config) GetFullScreenDiffPagerEnv() []string {
@@ -54,3 +55,32 @@ func MergeColumnConfigs(defaultCfg, sectionCfg ColumnConfig) ColumnConfig {
 	}
 	return colCfg
 }
+
+// normalizeKeys replaces <leader> with the leader key in the keys of every
+// keybinding, and separates the keys of sequences like "g  p" with a single
+// space, the way they're matched.
+func (kbs *Keybindings) normalizeKeys() {
+	leader := kbs.Leader
+	switch {
+	case leader == "":
+		leader = DefaultLeader
+	case leader == " ":
+		leader = "space"
+	}
+
+	for _, bindings := range [][]Keybinding{kbs.Universal, kbs.Issues, kbs.Prs, kbs.Branches} {
+		for i := range bindings {
+			bindings[i].Key = NormalizeKey(bindings[i].Key, leader)
+		}
+	}
+}
+
+// NormalizeKey returns the key of a keybinding with <leader> replaced by the
+// leader key and the keys of a sequence separated by a single space.
+func NormalizeKey(k, leader string) string {
+	if !strings.Contains(k, "<leader>") && (k == " " || !strings.Contains(k, " ")) {
+		return k
+	}
+	k = strings.ReplaceAll(k, "<leader>", " "+leader+" ")
+	return strings.Join(strings.Fields(k), " ")
+}
@@ -2,14 +2,17 @@ package data
 
 import (
 	"fmt"
+	"net/url"
 	"time"
 
 	"github.com/charmbracelet/log"
 	gh "github.com/cli/go-gh/v2/pkg/api"
 	graphql "github.com/cli/shurcooL-graphql"
+	"github.com/shurcooL/githubv4"
 )
 
 type IssueData struct {
+	Id     string
 	Number int
 	Title  string
 	Body   string
@@ -23,7 +26,10 @@ type IssueData struct {
 	Assignees  Assignees      `graphql:"assignees(first: 3)"`
 	Comments   IssueComments  `graphql:"comments(first: 15)"`
 	Reactions  IssueReactions `graphql:"reactions(first: 1)"`
-	Labels     IssueLabels    `graphql:"labels(first: 3)"`
+	Labels     IssueLabels    `graphql:"labels(first: 100)"`
+	// ClosingPrs are the PRs that close the issue when they're merged,
+	// including closed and merged ones.
+	ClosingPrs LinkedItems `graphql:"closedByPullRequestsReferences(first: 5, includeClosedPrs: true)"`
 }
 
 type IssueComments struct {
@@ -123,8 +129,232 @@ func FetchIssues(query string, limit int, pageInfo *PageInfo) (IssuesResponse, e
 	}, nil
 }
 
+func FetchIssue(issueUrl string) (IssueData, error) {
+	var err error
+	client, err := gh.DefaultGraphQLClient()
+	if err != nil {
+		return IssueData{}, err
+	}
+
+	var queryResult struct {
+		Resource struct {
+			Issue IssueData `graphql:"... on Issue"`
+		} `graphql:"resource(url: $url)"`
+	}
+	parsedUrl, err := url.Parse(issueUrl)
+	if err != nil {
+		return IssueData{}, err
+	}
+	variables := map[string]interface{}{
+		"url": githubv4.URI{URL: parsedUrl},
+	}
+	log.Debug("Fetching issue", "url", issueUrl)
+	err = client.Query("FetchIssue", &queryResult, variables)
+	if err != nil {
+		return IssueData{}, err
+	}
+	log.Debug("Successfully fetched issue", "url", issueUrl)
+
+	return queryResult.Resource.Issue, nil
+}
+
+// FetchIssuesByIds fetches the issues with the given GraphQL node IDs, in the
+// same order. IDs of deleted issues are skipped.
+func FetchIssuesByIds(ids []string) ([]IssueData, error) {
+	client, err := gh.DefaultGraphQLClient()
+	if err != nil {
+		return nil, err
+	}
+
+	var queryResult struct {
+		Nodes []struct {
+			Issue IssueData `graphql:"... on Issue"`
+		} `graphql:"nodes(ids: $ids)"`
+	}
+	variables := map[string]interface{}{
+		"ids": toGraphQLIds(ids),
+	}
+	log.Debug("Fetching issues by ids", "count", len(ids))
+	err = client.Query("FetchIssuesByIds", &queryResult, variables)
+	if err != nil {
+		return nil, err
+	}
+	log.Debug("Successfully fetched issues by ids", "count", len(queryResult.Nodes))
+
+	issues := make([]IssueData, 0, len(queryResult.Nodes))
+	for _, node := range queryResult.Nodes {
+		if node.Issue.Id != "" {
+			issues = append(issues, node.Issue)
+		}
+	}
+	return issues, nil
+}
+
 type IssuesResponse struct {
 	Issues     []IssueData
 	TotalCount int
 	PageInfo   PageInfo
 }
+
+// UpdateIssue sets the title and/or body of an issue. Nil values are left
+// unchanged.
+func UpdateIssue(issueId string, title *string, body *string) (IssueData, error) {
+	var mutation struct {
+		UpdateIssue struct {
+			Issue IssueData
+		} `graphql:"updateIssue(input: $input)"`
+	}
+	err := mutate("UpdateIssue", &mutation, githubv4.UpdateIssueInput{
+		ID:    githubv4.ID(issueId),
+		Title: (*githubv4.String)(title),
+		Body:  (*githubv4.String)(body),
+	})
+	return mutation.UpdateIssue.Issue, err
+}
+
+func CloseIssue(issueId string) (IssueData, error) {
+	var mutation struct {
+		CloseIssue struct {
+			Issue IssueData
+		} `graphql:"closeIssue(input: $input)"`
+	}
+	err := mutate("CloseIssue", &mutation, githubv4.CloseIssueInput{
+		IssueID: githubv4.ID(issueId),
+	})
+	return mutation.CloseIssue.Issue, err
+}
+
+func ReopenIssue(issueId string) (IssueData, error) {
+	var mutation struct {
+		ReopenIssue struct {
+			Issue IssueData
+		} `graphql:"reopenIssue(input: $input)"`
+	}
+	err := mutate("ReopenIssue", &mutation, githubv4.ReopenIssueInput{
+		IssueID: githubv4.ID(issueId),
+	})
+	return mutation.ReopenIssue.Issue, err
+}
+
+func AddIssueComment(issueId string, body string) (IssueData, error) {
+	var mutation struct {
+		AddComment struct {
+			CommentEdge struct {
+				Node IssueComment
+			}
+			Subject struct {
+				Issue IssueData `graphql:"... on Issue"`
+			}
+		} `graphql:"addComment(input: $input)"`
+	}
+	err := mutate("AddIssueComment", &mutation, githubv4.AddCommentInput{
+		SubjectID: githubv4.ID(issueId),
+		Body:      githubv4.String(body),
+	})
+	issue := mutation.AddComment.Subject.Issue
+	if len(issue.Comments.Nodes) < issue.Comments.TotalCount {
+		// Only the first comments are fetched, so they don't include the new one.
+		issue.Comments.Nodes = append(issue.Comments.Nodes, mutation.AddComment.CommentEdge.Node)
+	}
+	return issue, err
+}
+
+// UpdateIssueAssignees adds and removes assignees, given by login.
+func UpdateIssueAssignees(issueId string, added []string, removed []string) (IssueData, error) {
+	var issue IssueData
+	if len(removed) > 0 {
+		ids, err := fetchUserIds(removed)
+		if err != nil {
+			return issue, err
+		}
+		var mutation struct {
+			RemoveAssigneesFromAssignable struct {
+				Assignable struct {
+					Issue IssueData `graphql:"... on Issue"`
+				}
+			} `graphql:"removeAssigneesFromAssignable(input: $input)"`
+		}
+		err = mutate("RemoveIssueAssignees", &mutation, githubv4.RemoveAssigneesFromAssignableInput{
+			AssignableID: githubv4.ID(issueId),
+			AssigneeIDs:  ids,
+		})
+		if err != nil {
+			return issue, err
+		}
+		issue = mutation.RemoveAssigneesFromAssignable.Assignable.Issue
+	}
+
+	if len(added) > 0 {
+		ids, err := fetchUserIds(added)
+		if err != nil {
+			return issue, err
+		}
+		var mutation struct {
+			AddAssigneesToAssignable struct {
+				Assignable struct {
+					Issue IssueData `graphql:"... on Issue"`
+				}
+			} `graphql:"addAssigneesToAssignable(input: $input)"`
+		}
+		err = mutate("AddIssueAssignees", &mutation, githubv4.AddAssigneesToAssignableInput{
+			AssignableID: githubv4.ID(issueId),
+			AssigneeIDs:  ids,
+		})
+		if err != nil {
+			return issue, err
+		}
+		issue = mutation.AddAssigneesToAssignable.Assignable.Issue
+	}
+
+	return issue, nil
+}
+
+// UpdateIssueLabels adds and removes labels, given by name.
+func UpdateIssueLabels(issueId string, nameWithOwner string, added []string, removed []string) (IssueData, error) {
+	var issue IssueData
+	if len(removed) > 0 {
+		ids, err := fetchLabelIds(nameWithOwner, removed)
+		if err != nil {
+			return issue, err
+		}
+		var mutation struct {
+			RemoveLabelsFromLabelable struct {
+				Labelable struct {
+					Issue IssueData `graphql:"... on Issue"`
+				}
+			} `graphql:"removeLabelsFromLabelable(input: $input)"`
+		}
+		err = mutate("RemoveIssueLabels", &mutation, githubv4.RemoveLabelsFromLabelableInput{
+			LabelableID: githubv4.ID(issueId),
+			LabelIDs:    ids,
+		})
+		if err != nil {
+			return issue, err
+		}
+		issue = mutation.RemoveLabelsFromLabelable.Labelable.Issue
+	}
+
+	if len(added) > 0 {
+		ids, err := fetchLabelIds(nameWithOwner, added)
+		if err != nil {
+			return issue, err
+		}
+		var mutation struct {
+			AddLabelsToLabelable struct {
+				Labelable struct {
+					Issue IssueData `graphql:"... on Issue"`
+				}
+			} `graphql:"addLabelsToLabelable(input: $input)"`
+		}
+		err = mutate("AddIssueLabels", &mutation, githubv4.AddLabelsToLabelableInput{
+			LabelableID: githubv4.ID(issueId),
+			LabelIDs:    ids,
+		})
+		if err != nil {
+			return issue, err
+		}
+		issue = mutation.AddLabelsToLabelable.Labelable.Issue
+	}
+
+	return issue, nil
+}
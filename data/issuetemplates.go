@@ -0,0 +1,280 @@
+package data
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/charmbracelet/log"
+	gh "github.com/cli/go-gh/v2/pkg/api"
+	"gopkg.in/yaml.v2"
+)
+
+const issueTemplatesDir = ".github/ISSUE_TEMPLATE"
+
+// IssueTemplate is either a markdown issue template or an issue form. Issue
+// forms are converted to the markdown body GitHub would create from them.
+type IssueTemplate struct {
+	Name      string
+	About     string
+	Title     string
+	Body      string
+	Labels    []string
+	Assignees []string
+	IsForm    bool
+}
+
+// stringList accepts both a YAML list and a comma-separated string, as
+// GitHub does for template labels and assignees.
+type stringList []string
+
+func (l *stringList) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var list []string
+	if err := unmarshal(&list); err == nil {
+		*l = list
+		return nil
+	}
+
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+	*l = nil
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			*l = append(*l, item)
+		}
+	}
+	return nil
+}
+
+type templateHeader struct {
+	Name        string     `yaml:"name"`
+	About       string     `yaml:"about"`
+	Description string     `yaml:"description"`
+	Title       string     `yaml:"title"`
+	Labels      stringList `yaml:"labels"`
+	Assignees   stringList `yaml:"assignees"`
+}
+
+type issueFormElement struct {
+	Type       string `yaml:"type"`
+	Attributes struct {
+		Label   string        `yaml:"label"`
+		Value   string        `yaml:"value"`
+		Options []interface{} `yaml:"options"`
+	} `yaml:"attributes"`
+}
+
+type issueForm struct {
+	templateHeader `yaml:",inline"`
+	Body           []issueFormElement `yaml:"body"`
+}
+
+// ParseIssueTemplate parses the contents of a file in the repository's
+// ISSUE_TEMPLATE directory. Files that aren't templates, like config.yml,
+// return a nil template.
+func ParseIssueTemplate(filename string, content string) (*IssueTemplate, error) {
+	switch strings.ToLower(path.Ext(filename)) {
+	case ".md":
+		return parseMarkdownTemplate(content)
+	case ".yml", ".yaml":
+		if strings.TrimSuffix(path.Base(filename), path.Ext(filename)) == "config" {
+			return nil, nil
+		}
+		return parseIssueForm(content)
+	}
+	return nil, nil
+}
+
+func parseMarkdownTemplate(content string) (*IssueTemplate, error) {
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+	if !strings.HasPrefix(content, "---\n") {
+		return nil, errors.New("missing template front matter")
+	}
+	frontMatter, body, found := strings.Cut(strings.TrimPrefix(content, "---\n"), "\n---")
+	if !found {
+		return nil, errors.New("unterminated template front matter")
+	}
+
+	var header templateHeader
+	if err := yaml.Unmarshal([]byte(frontMatter), &header); err != nil {
+		return nil, err
+	}
+
+	return &IssueTemplate{
+		Name:      header.Name,
+		About:     header.About,
+		Title:     header.Title,
+		Body:      strings.TrimLeft(strings.TrimPrefix(body, "\n"), "\n"),
+		Labels:    header.Labels,
+		Assignees: header.Assignees,
+	}, nil
+}
+
+func parseIssueForm(content string) (*IssueTemplate, error) {
+	var form issueForm
+	if err := yaml.Unmarshal([]byte(content), &form); err != nil {
+		return nil, err
+	}
+
+	sections := []string{}
+	for _, el := range form.Body {
+		attrs := el.Attributes
+		switch el.Type {
+		case "textarea", "input":
+			sections = append(sections, fmt.Sprintf("### %s\n\n%s", attrs.Label, attrs.Value))
+		case "dropdown":
+			options := make([]string, 0, len(attrs.Options))
+			for _, o := range attrs.Options {
+				options = append(options, fmt.Sprint(o))
+			}
+			sections = append(sections, fmt.Sprintf(
+				"### %s\n\n<!-- One of: %s -->",
+				attrs.Label,
+				strings.Join(options, ", "),
+			))
+		case "checkboxes":
+			options := make([]string, 0, len(attrs.Options))
+			for _, o := range attrs.Options {
+				label := fmt.Sprint(o)
+				if m, ok := o.(map[interface{}]interface{}); ok {
+					label = fmt.Sprint(m["label"])
+				}
+				options = append(options, "- [ ] "+label)
+			}
+			sections = append(sections, fmt.Sprintf("### %s\n\n%s", attrs.Label, strings.Join(options, "\n")))
+		}
+	}
+
+	return &IssueTemplate{
+		Name:      form.Name,
+		About:     form.Description,
+		Title:     form.Title,
+		Body:      strings.Join(sections, "\n\n"),
+		Labels:    form.Labels,
+		Assignees: form.Assignees,
+		IsForm:    true,
+	}, nil
+}
+
+type repoContent struct {
+	Name     string
+	Path     string
+	Type     string
+	Content  string
+	Encoding string
+}
+
+// singleIssueTemplatePaths are where GitHub looks for a repository's single
+// issue template when it doesn't have an ISSUE_TEMPLATE directory.
+var singleIssueTemplatePaths = []string{
+	".github/ISSUE_TEMPLATE.md",
+	"ISSUE_TEMPLATE.md",
+	"docs/ISSUE_TEMPLATE.md",
+}
+
+// ParseSingleIssueTemplate parses a repository's single issue template, like
+// .github/ISSUE_TEMPLATE.md. Unlike the templates of the ISSUE_TEMPLATE
+// directory, it doesn't need front matter, in which case all of it is the
+// body.
+func ParseSingleIssueTemplate(content string) *IssueTemplate {
+	if template, err := parseMarkdownTemplate(content); err == nil {
+		return template
+	}
+	return &IssueTemplate{Body: strings.ReplaceAll(content, "\r\n", "\n")}
+}
+
+func isNotFound(err error) bool {
+	var httpErr *gh.HTTPError
+	return errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusNotFound
+}
+
+// fetchRepoFile returns the content of the file at filePath in the
+// repository.
+func fetchRepoFile(client *gh.RESTClient, nameWithOwner string, filePath string) (string, error) {
+	var file repoContent
+	err := client.Get(fmt.Sprintf("repos/%s/contents/%s", nameWithOwner, filePath), &file)
+	if err != nil {
+		return "", err
+	}
+	if file.Encoding != "base64" {
+		return file.Content, nil
+	}
+	decoded, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(file.Content, "\n", ""))
+	if err != nil {
+		return "", err
+	}
+	return string(decoded), nil
+}
+
+// FetchIssueTemplates returns the templates of the repository's
+// ISSUE_TEMPLATE directory or, if it doesn't have one, its single issue
+// template.
+func FetchIssueTemplates(nameWithOwner string) ([]IssueTemplate, error) {
+	client, err := gh.DefaultRESTClient()
+	if err != nil {
+		return nil, err
+	}
+
+	log.Debug("Fetching issue templates", "repo", nameWithOwner)
+	var files []repoContent
+	err = client.Get(fmt.Sprintf("repos/%s/contents/%s", nameWithOwner, issueTemplatesDir), &files)
+	if isNotFound(err) {
+		return fetchSingleIssueTemplate(client, nameWithOwner)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	templates := []IssueTemplate{}
+	for _, f := range files {
+		if f.Type != "file" {
+			continue
+		}
+
+		content, err := fetchRepoFile(client, nameWithOwner, f.Path)
+		if err != nil {
+			return nil, err
+		}
+
+		template, err := ParseIssueTemplate(f.Name, content)
+		if err != nil {
+			log.Error("Failed parsing issue template", "repo", nameWithOwner, "path", f.Path, "err", err)
+			continue
+		}
+		if template == nil {
+			continue
+		}
+		if template.Name == "" {
+			template.Name = f.Name
+		}
+		templates = append(templates, *template)
+	}
+	log.Debug("Successfully fetched issue templates", "repo", nameWithOwner, "count", len(templates))
+
+	return templates, nil
+}
+
+func fetchSingleIssueTemplate(client *gh.RESTClient, nameWithOwner string) ([]IssueTemplate, error) {
+	for _, filePath := range singleIssueTemplatePaths {
+		content, err := fetchRepoFile(client, nameWithOwner, filePath)
+		if isNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		template := ParseSingleIssueTemplate(content)
+		if template.Name == "" {
+			template.Name = path.Base(filePath)
+		}
+		log.Debug("Successfully fetched issue template", "repo", nameWithOwner, "path", filePath)
+		return []IssueTemplate{*template}, nil
+	}
+	return []IssueTemplate{}, nil
+}
@@ -0,0 +1,107 @@
+package data_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dlvhdr/gh-dash/v4/data"
+)
+
+func TestParseIssueTemplate(t *testing.T) {
+	testCases := map[string]struct {
+		filename string
+		content  string
+		want     *data.IssueTemplate
+	}{
+		"markdown template": {
+			filename: "bug_report.md",
+			content: `---
+name: Bug report
+about: Report a bug
+title: "[BUG] "
+labels: bug, triage
+assignees: ''
+---
+
+**Describe the bug**
+`,
+			want: &data.IssueTemplate{
+				Name:   "Bug report",
+				About:  "Report a bug",
+				Title:  "[BUG] ",
+				Body:   "**Describe the bug**\n",
+				Labels: []string{"bug", "triage"},
+			},
+		},
+		"issue form": {
+			filename: "feature.yml",
+			content: `name: Feature request
+description: Suggest an idea
+labels: [enhancement]
+body:
+  - type: markdown
+    attributes:
+      value: Thanks!
+  - type: textarea
+    attributes:
+      label: What do you want?
+  - type: input
+    attributes:
+      label: Version
+      value: latest
+  - type: checkboxes
+    attributes:
+      label: Checks
+      options:
+        - label: I searched existing issues
+`,
+			want: &data.IssueTemplate{
+				Name:   "Feature request",
+				About:  "Suggest an idea",
+				Body:   "### What do you want?\n\n\n\n### Version\n\nlatest\n\n### Checks\n\n- [ ] I searched existing issues",
+				Labels: []string{"enhancement"},
+				IsForm: true,
+			},
+		},
+		"template chooser config": {
+			filename: "config.yml",
+			content:  "blank_issues_enabled: false\n",
+			want:     nil,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := data.ParseIssueTemplate(tc.filename, tc.content)
+			require.NoError(t, err)
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestParseSingleIssueTemplate(t *testing.T) {
+	testCases := map[string]struct {
+		content string
+		want    *data.IssueTemplate
+	}{
+		"without front matter": {
+			content: "**Describe the bug**\r\n\r\n**Version**\r\n",
+			want:    &data.IssueTemplate{Body: "**Describe the bug**\n\n**Version**\n"},
+		},
+		"with front matter": {
+			content: "---\nname: Bug report\nlabels: bug\n---\n\n**Describe the bug**\n",
+			want: &data.IssueTemplate{
+				Name:   "Bug report",
+				Body:   "**Describe the bug**\n",
+				Labels: []string{"bug"},
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.want, data.ParseSingleIssueTemplate(tc.content))
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package data
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/log"
+	gh "github.com/cli/go-gh/v2/pkg/api"
+	graphql "github.com/cli/shurcooL-graphql"
+)
+
+var (
+	itemUrlRegex = regexp.MustCompile(`^https?://[^/]+/([^/]+)/([^/]+)/(pull|issues)/(\d+)(?:[/?#].*)?$`)
+	itemRefRegex = regexp.MustCompile(`^(?:([\w.-]+)/([\w.-]+))?#(\d+)$`)
+)
+
+// ItemRef points to a PR or an issue.
+type ItemRef struct {
+	Owner  string
+	Name   string
+	Number int
+	// Url is only set when the reference was a URL, which is the only kind of
+	// reference that tells whether it's a PR or an issue.
+	Url string
+}
+
+func (ref ItemRef) String() string {
+	return fmt.Sprintf("%s/%s#%d", ref.Owner, ref.Name, ref.Number)
+}
+
+// IsPullRequestUrl reports whether url is the URL of a PR rather than an
+// issue.
+func IsPullRequestUrl(url string) bool {
+	match := itemUrlRegex.FindStringSubmatch(url)
+	return match != nil && match[3] == "pull"
+}
+
+// ParseItemRef parses a PR or issue URL, an owner/repo#123 reference or a
+// #123 reference to an item of currRepo, which is an owner/repo name.
+func ParseItemRef(ref string, currRepo string) (ItemRef, error) {
+	ref = strings.TrimSpace(ref)
+
+	if match := itemUrlRegex.FindStringSubmatch(ref); match != nil {
+		number, _ := strconv.Atoi(match[4])
+		parsed, err := url.Parse(ref)
+		if err != nil {
+			return ItemRef{}, err
+		}
+		parsed.RawQuery, parsed.Fragment = "", ""
+		parsed.Path = fmt.Sprintf("/%s/%s/%s/%d", match[1], match[2], match[3], number)
+		return ItemRef{Owner: match[1], Name: match[2], Number: number, Url: parsed.String()}, nil
+	}
+
+	match := itemRefRegex.FindStringSubmatch(ref)
+	if match == nil {
+		return ItemRef{}, fmt.Errorf("%q isn't a URL, owner/repo#123 or #123", ref)
+	}
+	number, _ := strconv.Atoi(match[3])
+	owner, name := match[1], match[2]
+	if owner == "" {
+		var ok bool
+		owner, name, ok = strings.Cut(currRepo, "/")
+		if !ok {
+			return ItemRef{}, fmt.Errorf("can't tell the repository of %q", ref)
+		}
+	}
+	return ItemRef{Owner: owner, Name: name, Number: number}, nil
+}
+
+// FetchItemUrl returns the URL of the PR or issue ref points to, from which
+// IsPullRequestUrl tells which one it is.
+func FetchItemUrl(ref ItemRef) (string, error) {
+	if ref.Url != "" {
+		return ref.Url, nil
+	}
+
+	client, err := gh.DefaultGraphQLClient()
+	if err != nil {
+		return "", err
+	}
+
+	var queryResult struct {
+		Repository struct {
+			IssueOrPullRequest struct {
+				Issue struct {
+					Url string
+				} `graphql:"... on Issue"`
+				PullRequest struct {
+					Url string
+				} `graphql:"... on PullRequest"`
+			} `graphql:"issueOrPullRequest(number: $number)"`
+		} `graphql:"repository(owner: $owner, name: $name)"`
+	}
+	variables := map[string]interface{}{
+		"owner":  graphql.String(ref.Owner),
+		"name":   graphql.String(ref.Name),
+		"number": graphql.Int(ref.Number),
+	}
+	log.Debug("Fetching item URL", "ref", ref)
+	err = client.Query("FetchItemUrl", &queryResult, variables)
+	if err != nil {
+		return "", err
+	}
+
+	// Both fragments select the same field, so both are set to the URL of
+	// whichever the item is.
+	item := queryResult.Repository.IssueOrPullRequest
+	if item.Issue.Url == "" && item.PullRequest.Url == "" {
+		return "", fmt.Errorf("%s doesn't exist", ref)
+	}
+	if item.PullRequest.Url != "" {
+		return item.PullRequest.Url, nil
+	}
+	return item.Issue.Url, nil
+}
@@ -0,0 +1,66 @@
+package data_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dlvhdr/gh-dash/v4/data"
+)
+
+func TestParseItemRef(t *testing.T) {
+	testCases := map[string]struct {
+		ref      string
+		currRepo string
+		want     data.ItemRef
+		wantErr  bool
+	}{
+		"PR URL": {
+			ref: "https://github.com/dlvhdr/gh-dash/pull/123/files?w=1",
+			want: data.ItemRef{
+				Owner:  "dlvhdr",
+				Name:   "gh-dash",
+				Number: 123,
+				Url:    "https://github.com/dlvhdr/gh-dash/pull/123",
+			},
+		},
+		"issue URL": {
+			ref: "https://github.com/dlvhdr/gh-dash/issues/45#issuecomment-1",
+			want: data.ItemRef{
+				Owner:  "dlvhdr",
+				Name:   "gh-dash",
+				Number: 45,
+				Url:    "https://github.com/dlvhdr/gh-dash/issues/45",
+			},
+		},
+		"repo reference": {
+			ref:  "cli/cli#9",
+			want: data.ItemRef{Owner: "cli", Name: "cli", Number: 9},
+		},
+		"number in the current repo": {
+			ref:      " #7 ",
+			currRepo: "dlvhdr/gh-dash",
+			want:     data.ItemRef{Owner: "dlvhdr", Name: "gh-dash", Number: 7},
+		},
+		"number without a current repo": {
+			ref:     "#7",
+			wantErr: true,
+		},
+		"not a reference": {
+			ref:     "gh-dash",
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ref, err := data.ParseItemRef(tc.ref, tc.currRepo)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.want, ref)
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package data
+
+// LinkedItem is an issue or PR linked to another one, like an issue a PR
+// closes or a PR that closes an issue.
+type LinkedItem struct {
+	Number     int
+	Title      string
+	State      string
+	Url        string
+	Repository struct {
+		NameWithOwner string
+	}
+}
+
+type LinkedItems struct {
+	Nodes      []LinkedItem
+	TotalCount int
+}
+
+// Preferred returns the first open item, or the first item if none of them
+// are open. ok is false when there are no items.
+func (items LinkedItems) Preferred() (item LinkedItem, ok bool) {
+	for _, item := range items.Nodes {
+		if item.State == "OPEN" {
+			return item, true
+		}
+	}
+	if len(items.Nodes) == 0 {
+		return LinkedItem{}, false
+	}
+	return items.Nodes[0], true
+}
@@ -0,0 +1,44 @@
+package data_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dlvhdr/gh-dash/v4/data"
+)
+
+func TestLinkedItemsPreferred(t *testing.T) {
+	testCases := map[string]struct {
+		states     []string
+		wantNumber int
+		wantOk     bool
+	}{
+		"no items": {
+			wantOk: false,
+		},
+		"first open item": {
+			states:     []string{"MERGED", "OPEN", "OPEN"},
+			wantNumber: 2,
+			wantOk:     true,
+		},
+		"no open items": {
+			states:     []string{"CLOSED", "MERGED"},
+			wantNumber: 1,
+			wantOk:     true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var items data.LinkedItems
+			for i, state := range tc.states {
+				items.Nodes = append(items.Nodes, data.LinkedItem{Number: i + 1, State: state})
+			}
+
+			item, ok := items.Preferred()
+			require.Equal(t, tc.wantOk, ok)
+			require.Equal(t, tc.wantNumber, item.Number)
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package data
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/log"
+	gh "github.com/cli/go-gh/v2/pkg/api"
+	graphql "github.com/cli/shurcooL-graphql"
+	"github.com/shurcooL/githubv4"
+)
+
+// mutate runs the mutation with the given input. The mutation's selection
+// usually includes the updated PR or issue, so callers can show the state the
+// server ended up with.
+func mutate(name string, mutation any, input any) error {
+	client, err := gh.DefaultGraphQLClient()
+	if err != nil {
+		return err
+	}
+
+	log.Debug("Running mutation", "name", name)
+	err = client.Mutate(name, mutation, map[string]interface{}{"input": input})
+	if err != nil {
+		return err
+	}
+	log.Debug("Successfully ran mutation", "name", name)
+
+	return nil
+}
+
+// fetchUserIds returns the node IDs of the users with the given logins.
+// @me is the current user.
+func fetchUserIds(logins []string) ([]githubv4.ID, error) {
+	client, err := gh.DefaultGraphQLClient()
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]githubv4.ID, 0, len(logins))
+	for _, login := range logins {
+		login = strings.TrimPrefix(login, "@")
+		if login == "me" {
+			var query struct {
+				Viewer struct {
+					Id string
+				}
+			}
+			if err := client.Query("ViewerId", &query, nil); err != nil {
+				return nil, err
+			}
+			ids = append(ids, githubv4.ID(query.Viewer.Id))
+			continue
+		}
+
+		var query struct {
+			User *struct {
+				Id string
+			} `graphql:"user(login: $login)"`
+		}
+		err := client.Query("UserId", &query, map[string]interface{}{"login": graphql.String(login)})
+		if err != nil {
+			return nil, err
+		}
+		if query.User == nil {
+			return nil, fmt.Errorf("user %q not found", login)
+		}
+		ids = append(ids, githubv4.ID(query.User.Id))
+	}
+
+	return ids, nil
+}
+
+// fetchTeamId returns the node ID of a team given as org/team.
+func fetchTeamId(team string) (githubv4.ID, error) {
+	org, slug, ok := strings.Cut(team, "/")
+	if !ok {
+		return nil, fmt.Errorf("invalid team %q, expected org/team", team)
+	}
+
+	client, err := gh.DefaultGraphQLClient()
+	if err != nil {
+		return nil, err
+	}
+
+	var query struct {
+		Organization *struct {
+			Team *struct {
+				Id string
+			} `graphql:"team(slug: $slug)"`
+		} `graphql:"organization(login: $org)"`
+	}
+	variables := map[string]interface{}{
+		"org":  graphql.String(org),
+		"slug": graphql.String(slug),
+	}
+	if err := client.Query("TeamId", &query, variables); err != nil {
+		return nil, err
+	}
+	if query.Organization == nil || query.Organization.Team == nil {
+		return nil, fmt.Errorf("team %q not found", team)
+	}
+
+	return githubv4.ID(query.Organization.Team.Id), nil
+}
+
+// fetchLabelIds returns the node IDs of the labels with the given names in
+// the repository.
+func fetchLabelIds(nameWithOwner string, names []string) ([]githubv4.ID, error) {
+	owner, name, err := splitRepoNameWithOwner(nameWithOwner)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := gh.DefaultGraphQLClient()
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]githubv4.ID, 0, len(names))
+	for _, label := range names {
+		var query struct {
+			Repository struct {
+				Label *struct {
+					Id string
+				} `graphql:"label(name: $label)"`
+			} `graphql:"repository(owner: $owner, name: $name)"`
+		}
+		variables := map[string]interface{}{
+			"owner": graphql.String(owner),
+			"name":  graphql.String(name),
+			"label": graphql.String(label),
+		}
+		if err := client.Query("LabelId", &query, variables); err != nil {
+			return nil, err
+		}
+		if query.Repository.Label == nil {
+			return nil, fmt.Errorf("label %q not found in %s", label, nameWithOwner)
+		}
+		ids = append(ids, githubv4.ID(query.Repository.Label.Id))
+	}
+
+	return ids, nil
+}
@@ -2,7 +2,10 @@ package data
 
 import (
 	"fmt"
+	"io"
+	"net/http"
 	"net/url"
+	"strings"
 	"time"
 
 	"github.com/charmbracelet/log"
@@ -12,6 +15,7 @@ import (
 )
 
 type PullRequestData struct {
+	Id     string
 	Number int
 	Title  string
 	Body   string
@@ -25,12 +29,17 @@ type PullRequestData struct {
 	ReviewDecision string
 	Additions      int
 	Deletions      int
+	ChangedFiles   int
 	HeadRefName    string
 	BaseRefName    string
 	HeadRepository struct {
 		Name string
 	}
+	HeadRepositoryOwner struct {
+		Login string
+	}
 	HeadRef struct {
+		Id   string
 		Name string
 	}
 	Repository       Repository
@@ -40,8 +49,19 @@ type PullRequestData struct {
 	ReviewThreads    ReviewThreads `graphql:"reviewThreads(last: 20)"`
 	IsDraft          bool
 	Commits          Commits          `graphql:"commits(last: 1)"`
-	Labels           PRLabels         `graphql:"labels(first: 3)"`
+	Labels           PRLabels         `graphql:"labels(first: 100)"`
 	MergeStateStatus MergeStateStatus `graphql:"mergeStateStatus"`
+	IsInMergeQueue   bool
+	AutoMergeRequest *AutoMergeRequest
+	// ClosingIssues are the issues the PR closes when it's merged.
+	ClosingIssues LinkedItems `graphql:"closingIssuesReferences(first: 5)"`
+	// FileStates are the viewed states of the PR's first files.
+	FileStates PullRequestFileStates `graphql:"files(first: 100)"`
+}
+
+type AutoMergeRequest struct {
+	MergeMethod string
+	EnabledAt   time.Time
 }
 
 type CheckRun struct {
@@ -68,6 +88,13 @@ type StatusContext struct {
 	}
 }
 
+// CheckContext is either a check run or a commit status.
+type CheckContext struct {
+	Typename      graphql.String `graphql:"__typename"`
+	CheckRun      CheckRun       `graphql:"... on CheckRun"`
+	StatusContext StatusContext  `graphql:"... on StatusContext"`
+}
+
 type Commits struct {
 	Nodes []struct {
 		Commit struct {
@@ -78,19 +105,25 @@ type Commits struct {
 				}
 			} `graphql:"deployments(last: 10)"`
 			StatusCheckRollup struct {
+				State    graphql.String
 				Contexts struct {
 					TotalCount graphql.Int
-					Nodes      []struct {
-						Typename      graphql.String `graphql:"__typename"`
-						CheckRun      CheckRun       `graphql:"... on CheckRun"`
-						StatusContext StatusContext  `graphql:"... on StatusContext"`
-					}
+					Nodes      []CheckContext
 				} `graphql:"contexts(last: 20)"`
 			}
 		}
 	}
 }
 
+// ChecksState returns the combined state of the checks of the PR's last
+// commit, or "" if it has none.
+func (commits Commits) ChecksState() string {
+	if len(commits.Nodes) == 0 {
+		return ""
+	}
+	return string(commits.Nodes[0].Commit.StatusCheckRollup.State)
+}
+
 type Comment struct {
 	Author struct {
 		Login string
@@ -132,16 +165,98 @@ type Reviews struct {
 	Nodes []Review
 }
 
+type ReviewThread struct {
+	Id           string
+	IsOutdated   bool
+	OriginalLine int
+	StartLine    int
+	Line         int
+	Path         string
+	DiffSide     string
+	IsResolved   bool
+	Comments     ReviewComments `graphql:"comments(first: 10)"`
+}
+
 type ReviewThreads struct {
-	Nodes []struct {
-		Id           string
-		IsOutdated   bool
-		OriginalLine int
-		StartLine    int
-		Line         int
-		Path         string
-		Comments     ReviewComments `graphql:"comments(first: 10)"`
+	Nodes []ReviewThread
+}
+
+type PullRequestFile struct {
+	Path              string
+	Additions         int
+	Deletions         int
+	ViewerViewedState string
+}
+
+func (file PullRequestFile) IsViewed() bool {
+	return file.ViewerViewedState == "VIEWED"
+}
+
+type PullRequestFiles struct {
+	Nodes      []PullRequestFile
+	TotalCount int
+}
+
+// IsPartial reports whether only some of the TotalCount files were fetched.
+func (files PullRequestFiles) IsPartial() bool {
+	return len(files.Nodes) < files.TotalCount
+}
+
+// ViewedCount returns the number of files the viewer marked as viewed. Files
+// that changed since they were viewed aren't counted.
+func (files PullRequestFiles) ViewedCount() int {
+	n := 0
+	for _, file := range files.Nodes {
+		if file.IsViewed() {
+			n++
+		}
 	}
+	return n
+}
+
+// ViewedProgress is how far the viewer got reviewing a PR's files.
+type ViewedProgress int
+
+const (
+	NoneViewed ViewedProgress = iota
+	SomeViewed
+	AllViewed
+)
+
+// ViewedProgress returns how far the viewer got reviewing the files. They're
+// only all viewed once every one of the TotalCount files was fetched and
+// viewed.
+func (files PullRequestFiles) ViewedProgress() ViewedProgress {
+	switch viewed := files.ViewedCount(); {
+	case viewed == 0:
+		return NoneViewed
+	case viewed >= files.TotalCount:
+		return AllViewed
+	default:
+		return SomeViewed
+	}
+}
+
+// PullRequestFileStates are the viewed states of a page of a PR's files,
+// which is enough to show how many were viewed in lists without fetching
+// every file.
+type PullRequestFileStates struct {
+	Nodes      []PullRequestFileState
+	TotalCount int
+}
+
+type PullRequestFileState struct {
+	ViewerViewedState string
+}
+
+// Files returns the files the states are of, with only their viewed state
+// set.
+func (states PullRequestFileStates) Files() PullRequestFiles {
+	files := PullRequestFiles{TotalCount: states.TotalCount}
+	for _, node := range states.Nodes {
+		files.Nodes = append(files.Nodes, PullRequestFile{ViewerViewedState: node.ViewerViewedState})
+	}
+	return files
 }
 
 type PRLabel struct {
@@ -268,3 +383,467 @@ func FetchPullRequest(prUrl string) (PullRequestData, error) {
 
 	return queryResult.Resource.PullRequest, nil
 }
+
+// FetchPullRequestsByIds fetches the PRs with the given GraphQL node IDs, in
+// the same order. IDs of deleted PRs are skipped.
+func FetchPullRequestsByIds(ids []string) ([]PullRequestData, error) {
+	client, err := gh.DefaultGraphQLClient()
+	if err != nil {
+		return nil, err
+	}
+
+	var queryResult struct {
+		Nodes []struct {
+			PullRequest PullRequestData `graphql:"... on PullRequest"`
+		} `graphql:"nodes(ids: $ids)"`
+	}
+	variables := map[string]interface{}{
+		"ids": toGraphQLIds(ids),
+	}
+	log.Debug("Fetching PRs by ids", "count", len(ids))
+	err = client.Query("FetchPullRequestsByIds", &queryResult, variables)
+	if err != nil {
+		return nil, err
+	}
+	log.Debug("Successfully fetched PRs by ids", "count", len(queryResult.Nodes))
+
+	prs := make([]PullRequestData, 0, len(queryResult.Nodes))
+	for _, node := range queryResult.Nodes {
+		if node.PullRequest.Id != "" {
+			prs = append(prs, node.PullRequest)
+		}
+	}
+	return prs, nil
+}
+
+func toGraphQLIds(ids []string) []graphql.ID {
+	gqlIds := make([]graphql.ID, 0, len(ids))
+	for _, id := range ids {
+		gqlIds = append(gqlIds, graphql.ID(id))
+	}
+	return gqlIds
+}
+
+type SuggestedReviewer struct {
+	IsAuthor    bool
+	IsCommenter bool
+	Reviewer    struct {
+		Login string
+		Name  string
+	}
+}
+
+func FetchSuggestedReviewers(prUrl string) ([]SuggestedReviewer, error) {
+	client, err := gh.DefaultGraphQLClient()
+	if err != nil {
+		return nil, err
+	}
+
+	var queryResult struct {
+		Resource struct {
+			PullRequest struct {
+				SuggestedReviewers []SuggestedReviewer
+			} `graphql:"... on PullRequest"`
+		} `graphql:"resource(url: $url)"`
+	}
+	parsedUrl, err := url.Parse(prUrl)
+	if err != nil {
+		return nil, err
+	}
+	variables := map[string]interface{}{
+		"url": githubv4.URI{URL: parsedUrl},
+	}
+	log.Debug("Fetching suggested reviewers", "url", prUrl)
+	err = client.Query("FetchSuggestedReviewers", &queryResult, variables)
+	if err != nil {
+		return nil, err
+	}
+	log.Debug("Successfully fetched suggested reviewers", "url", prUrl, "count", len(queryResult.Resource.PullRequest.SuggestedReviewers))
+
+	return queryResult.Resource.PullRequest.SuggestedReviewers, nil
+}
+
+// FetchPullRequestDiff returns the unified diff of the PR.
+func FetchPullRequestDiff(nameWithOwner string, number int) (string, error) {
+	client, err := gh.NewRESTClient(gh.ClientOptions{
+		Headers: map[string]string{"Accept": "application/vnd.github.v3.diff"},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	log.Debug("Fetching PR diff", "repo", nameWithOwner, "number", number)
+	resp, err := client.Request(http.MethodGet, fmt.Sprintf("repos/%s/pulls/%d", nameWithOwner, number), nil)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	diff, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	log.Debug("Successfully fetched PR diff", "repo", nameWithOwner, "number", number, "bytes", len(diff))
+
+	return string(diff), nil
+}
+
+// EnqueuePullRequest adds the PR to the merge queue of its base branch.
+func EnqueuePullRequest(prId string) (PullRequestData, error) {
+	var mutation struct {
+		EnqueuePullRequest struct {
+			MergeQueueEntry struct {
+				PullRequest PullRequestData
+			}
+		} `graphql:"enqueuePullRequest(input: $input)"`
+	}
+	err := mutate("EnqueuePullRequest", &mutation, githubv4.EnqueuePullRequestInput{
+		PullRequestID: githubv4.ID(prId),
+	})
+	return mutation.EnqueuePullRequest.MergeQueueEntry.PullRequest, err
+}
+
+// UpdatePullRequest sets the title and/or body of a PR. Nil values are left
+// unchanged.
+func UpdatePullRequest(prId string, title *string, body *string) (PullRequestData, error) {
+	var mutation struct {
+		UpdatePullRequest struct {
+			PullRequest PullRequestData
+		} `graphql:"updatePullRequest(input: $input)"`
+	}
+	err := mutate("UpdatePullRequest", &mutation, githubv4.UpdatePullRequestInput{
+		PullRequestID: githubv4.ID(prId),
+		Title:         (*githubv4.String)(title),
+		Body:          (*githubv4.String)(body),
+	})
+	return mutation.UpdatePullRequest.PullRequest, err
+}
+
+func ClosePullRequest(prId string) (PullRequestData, error) {
+	var mutation struct {
+		ClosePullRequest struct {
+			PullRequest PullRequestData
+		} `graphql:"closePullRequest(input: $input)"`
+	}
+	err := mutate("ClosePullRequest", &mutation, githubv4.ClosePullRequestInput{
+		PullRequestID: githubv4.ID(prId),
+	})
+	return mutation.ClosePullRequest.PullRequest, err
+}
+
+func ReopenPullRequest(prId string) (PullRequestData, error) {
+	var mutation struct {
+		ReopenPullRequest struct {
+			PullRequest PullRequestData
+		} `graphql:"reopenPullRequest(input: $input)"`
+	}
+	err := mutate("ReopenPullRequest", &mutation, githubv4.ReopenPullRequestInput{
+		PullRequestID: githubv4.ID(prId),
+	})
+	return mutation.ReopenPullRequest.PullRequest, err
+}
+
+func MarkPullRequestReadyForReview(prId string) (PullRequestData, error) {
+	var mutation struct {
+		MarkPullRequestReadyForReview struct {
+			PullRequest PullRequestData
+		} `graphql:"markPullRequestReadyForReview(input: $input)"`
+	}
+	err := mutate("MarkPullRequestReadyForReview", &mutation, githubv4.MarkPullRequestReadyForReviewInput{
+		PullRequestID: githubv4.ID(prId),
+	})
+	return mutation.MarkPullRequestReadyForReview.PullRequest, err
+}
+
+func ConvertPullRequestToDraft(prId string) (PullRequestData, error) {
+	var mutation struct {
+		ConvertPullRequestToDraft struct {
+			PullRequest PullRequestData
+		} `graphql:"convertPullRequestToDraft(input: $input)"`
+	}
+	err := mutate("ConvertPullRequestToDraft", &mutation, githubv4.ConvertPullRequestToDraftInput{
+		PullRequestID: githubv4.ID(prId),
+	})
+	return mutation.ConvertPullRequestToDraft.PullRequest, err
+}
+
+// UpdatePullRequestBranch merges the base branch into the PR's head branch.
+func UpdatePullRequestBranch(prId string) (PullRequestData, error) {
+	var mutation struct {
+		UpdatePullRequestBranch struct {
+			PullRequest PullRequestData
+		} `graphql:"updatePullRequestBranch(input: $input)"`
+	}
+	err := mutate("UpdatePullRequestBranch", &mutation, githubv4.UpdatePullRequestBranchInput{
+		PullRequestID: githubv4.ID(prId),
+	})
+	return mutation.UpdatePullRequestBranch.PullRequest, err
+}
+
+type MergeOptions struct {
+	// Method is one of merge, squash or rebase.
+	Method      string
+	CommitTitle string
+	CommitBody  string
+}
+
+func (o MergeOptions) mergeMethod() *githubv4.PullRequestMergeMethod {
+	method := githubv4.PullRequestMergeMethod(strings.ToUpper(o.Method))
+	return &method
+}
+
+func (o MergeOptions) commitHeadline() *githubv4.String {
+	if o.CommitTitle == "" || o.Method == "rebase" {
+		return nil
+	}
+	return githubv4.NewString(githubv4.String(o.CommitTitle))
+}
+
+func (o MergeOptions) commitBody() *githubv4.String {
+	if o.CommitBody == "" || o.Method == "rebase" {
+		return nil
+	}
+	return githubv4.NewString(githubv4.String(o.CommitBody))
+}
+
+func MergePullRequest(prId string, opts MergeOptions) (PullRequestData, error) {
+	var mutation struct {
+		MergePullRequest struct {
+			PullRequest PullRequestData
+		} `graphql:"mergePullRequest(input: $input)"`
+	}
+	err := mutate("MergePullRequest", &mutation, githubv4.MergePullRequestInput{
+		PullRequestID:  githubv4.ID(prId),
+		MergeMethod:    opts.mergeMethod(),
+		CommitHeadline: opts.commitHeadline(),
+		CommitBody:     opts.commitBody(),
+	})
+	return mutation.MergePullRequest.PullRequest, err
+}
+
+// EnablePullRequestAutoMerge merges the PR once its requirements are met.
+func EnablePullRequestAutoMerge(prId string, opts MergeOptions) (PullRequestData, error) {
+	var mutation struct {
+		EnablePullRequestAutoMerge struct {
+			PullRequest PullRequestData
+		} `graphql:"enablePullRequestAutoMerge(input: $input)"`
+	}
+	err := mutate("EnablePullRequestAutoMerge", &mutation, githubv4.EnablePullRequestAutoMergeInput{
+		PullRequestID:  githubv4.ID(prId),
+		MergeMethod:    opts.mergeMethod(),
+		CommitHeadline: opts.commitHeadline(),
+		CommitBody:     opts.commitBody(),
+	})
+	return mutation.EnablePullRequestAutoMerge.PullRequest, err
+}
+
+// DeleteRef deletes a branch, e.g. the head branch of a merged PR.
+func DeleteRef(refId string) error {
+	var mutation struct {
+		DeleteRef struct {
+			ClientMutationId string
+		} `graphql:"deleteRef(input: $input)"`
+	}
+	return mutate("DeleteRef", &mutation, githubv4.DeleteRefInput{
+		RefID: githubv4.ID(refId),
+	})
+}
+
+// ApprovePullRequest submits an approving review with an optional comment.
+func ApprovePullRequest(prId string, body string) (PullRequestData, error) {
+	var mutation struct {
+		AddPullRequestReview struct {
+			PullRequestReview struct {
+				PullRequest PullRequestData
+			}
+		} `graphql:"addPullRequestReview(input: $input)"`
+	}
+	event := githubv4.PullRequestReviewEventApprove
+	input := githubv4.AddPullRequestReviewInput{
+		PullRequestID: githubv4.ID(prId),
+		Event:         &event,
+	}
+	if body != "" {
+		input.Body = githubv4.NewString(githubv4.String(body))
+	}
+	err := mutate("ApprovePullRequest", &mutation, input)
+	return mutation.AddPullRequestReview.PullRequestReview.PullRequest, err
+}
+
+// RequestReviews requests reviews from users, given by login, and teams,
+// given as org/team. Reviews that were already requested are kept.
+func RequestReviews(prId string, reviewers []string) (PullRequestData, error) {
+	var logins []string
+	teamIds := []githubv4.ID{}
+	for _, reviewer := range reviewers {
+		if !strings.Contains(reviewer, "/") {
+			logins = append(logins, reviewer)
+			continue
+		}
+		id, err := fetchTeamId(reviewer)
+		if err != nil {
+			return PullRequestData{}, err
+		}
+		teamIds = append(teamIds, id)
+	}
+	userIds, err := fetchUserIds(logins)
+	if err != nil {
+		return PullRequestData{}, err
+	}
+
+	var mutation struct {
+		RequestReviews struct {
+			PullRequest PullRequestData
+		} `graphql:"requestReviews(input: $input)"`
+	}
+	err = mutate("RequestReviews", &mutation, githubv4.RequestReviewsInput{
+		PullRequestID: githubv4.ID(prId),
+		UserIDs:       &userIds,
+		TeamIDs:       &teamIds,
+		Union:         githubv4.NewBoolean(true),
+	})
+	return mutation.RequestReviews.PullRequest, err
+}
+
+func AddPullRequestComment(prId string, body string) (PullRequestData, error) {
+	var mutation struct {
+		AddComment struct {
+			Subject struct {
+				PullRequest PullRequestData `graphql:"... on PullRequest"`
+			}
+		} `graphql:"addComment(input: $input)"`
+	}
+	err := mutate("AddPullRequestComment", &mutation, githubv4.AddCommentInput{
+		SubjectID: githubv4.ID(prId),
+		Body:      githubv4.String(body),
+	})
+	return mutation.AddComment.Subject.PullRequest, err
+}
+
+// UpdatePullRequestAssignees adds and removes assignees, given by login.
+func UpdatePullRequestAssignees(prId string, added []string, removed []string) (PullRequestData, error) {
+	var pr PullRequestData
+	if len(removed) > 0 {
+		ids, err := fetchUserIds(removed)
+		if err != nil {
+			return pr, err
+		}
+		var mutation struct {
+			RemoveAssigneesFromAssignable struct {
+				Assignable struct {
+					PullRequest PullRequestData `graphql:"... on PullRequest"`
+				}
+			} `graphql:"removeAssigneesFromAssignable(input: $input)"`
+		}
+		err = mutate("RemovePullRequestAssignees", &mutation, githubv4.RemoveAssigneesFromAssignableInput{
+			AssignableID: githubv4.ID(prId),
+			AssigneeIDs:  ids,
+		})
+		if err != nil {
+			return pr, err
+		}
+		pr = mutation.RemoveAssigneesFromAssignable.Assignable.PullRequest
+	}
+
+	if len(added) > 0 {
+		ids, err := fetchUserIds(added)
+		if err != nil {
+			return pr, err
+		}
+		var mutation struct {
+			AddAssigneesToAssignable struct {
+				Assignable struct {
+					PullRequest PullRequestData `graphql:"... on PullRequest"`
+				}
+			} `graphql:"addAssigneesToAssignable(input: $input)"`
+		}
+		err = mutate("AddPullRequestAssignees", &mutation, githubv4.AddAssigneesToAssignableInput{
+			AssignableID: githubv4.ID(prId),
+			AssigneeIDs:  ids,
+		})
+		if err != nil {
+			return pr, err
+		}
+		pr = mutation.AddAssigneesToAssignable.Assignable.PullRequest
+	}
+
+	return pr, nil
+}
+
+// UpdatePullRequestLabels adds and removes labels, given by name.
+func UpdatePullRequestLabels(prId string, nameWithOwner string, added []string, removed []string) (PullRequestData, error) {
+	var pr PullRequestData
+	if len(removed) > 0 {
+		ids, err := fetchLabelIds(nameWithOwner, removed)
+		if err != nil {
+			return pr, err
+		}
+		var mutation struct {
+			RemoveLabelsFromLabelable struct {
+				Labelable struct {
+					PullRequest PullRequestData `graphql:"... on PullRequest"`
+				}
+			} `graphql:"removeLabelsFromLabelable(input: $input)"`
+		}
+		err = mutate("RemovePullRequestLabels", &mutation, githubv4.RemoveLabelsFromLabelableInput{
+			LabelableID: githubv4.ID(prId),
+			LabelIDs:    ids,
+		})
+		if err != nil {
+			return pr, err
+		}
+		pr = mutation.RemoveLabelsFromLabelable.Labelable.PullRequest
+	}
+
+	if len(added) > 0 {
+		ids, err := fetchLabelIds(nameWithOwner, added)
+		if err != nil {
+			return pr, err
+		}
+		var mutation struct {
+			AddLabelsToLabelable struct {
+				Labelable struct {
+					PullRequest PullRequestData `graphql:"... on PullRequest"`
+				}
+			} `graphql:"addLabelsToLabelable(input: $input)"`
+		}
+		err = mutate("AddPullRequestLabels", &mutation, githubv4.AddLabelsToLabelableInput{
+			LabelableID: githubv4.ID(prId),
+			LabelIDs:    ids,
+		})
+		if err != nil {
+			return pr, err
+		}
+		pr = mutation.AddLabelsToLabelable.Labelable.PullRequest
+	}
+
+	return pr, nil
+}
+
+// MarkFileAsViewed marks the file at path as viewed by the viewer.
+func MarkFileAsViewed(prId string, path string) (PullRequestData, error) {
+	var mutation struct {
+		MarkFileAsViewed struct {
+			PullRequest PullRequestData
+		} `graphql:"markFileAsViewed(input: $input)"`
+	}
+	err := mutate("MarkFileAsViewed", &mutation, githubv4.MarkFileAsViewedInput{
+		PullRequestID: githubv4.ID(prId),
+		Path:          githubv4.String(path),
+	})
+	return mutation.MarkFileAsViewed.PullRequest, err
+}
+
+// UnmarkFileAsViewed marks the file at path as not viewed by the viewer.
+func UnmarkFileAsViewed(prId string, path string) (PullRequestData, error) {
+	var mutation struct {
+		UnmarkFileAsViewed struct {
+			PullRequest PullRequestData
+		} `graphql:"unmarkFileAsViewed(input: $input)"`
+	}
+	err := mutate("UnmarkFileAsViewed", &mutation, githubv4.UnmarkFileAsViewedInput{
+		PullRequestID: githubv4.ID(prId),
+		Path:          githubv4.String(path),
+	})
+	return mutation.UnmarkFileAsViewed.PullRequest, err
+}
@@ -0,0 +1,174 @@
+package data
+
+import (
+	"net/url"
+	"time"
+
+	"github.com/charmbracelet/log"
+	gh "github.com/cli/go-gh/v2/pkg/api"
+	graphql "github.com/cli/shurcooL-graphql"
+	"github.com/shurcooL/githubv4"
+)
+
+type PullRequestCommit struct {
+	Oid             string
+	AbbreviatedOid  string
+	MessageHeadline string
+	CommittedDate   time.Time
+	Author          struct {
+		Name string
+		User *struct {
+			Login string
+		}
+	}
+	StatusCheckRollup *struct {
+		State string
+	}
+}
+
+// AuthorName returns the login of the commit's author, or the name in the
+// commit if it isn't linked to a GitHub user.
+func (commit PullRequestCommit) AuthorName() string {
+	if commit.Author.User != nil {
+		return commit.Author.User.Login
+	}
+	return commit.Author.Name
+}
+
+// ChecksState returns the combined state of the commit's checks, or "" if
+// it has none.
+func (commit PullRequestCommit) ChecksState() string {
+	if commit.StatusCheckRollup == nil {
+		return ""
+	}
+	return commit.StatusCheckRollup.State
+}
+
+// queryResourcePages runs query for the PR or issue at resourceUrl until
+// onPage, which is called after each page to collect its nodes, returns the
+// last page.
+func queryResourcePages(name string, resourceUrl string, query any, onPage func() PageInfo) error {
+	client, err := gh.DefaultGraphQLClient()
+	if err != nil {
+		return err
+	}
+	parsedUrl, err := url.Parse(resourceUrl)
+	if err != nil {
+		return err
+	}
+
+	var after *graphql.String
+	for page := 1; ; page++ {
+		variables := map[string]interface{}{
+			"url":   githubv4.URI{URL: parsedUrl},
+			"after": after,
+		}
+		log.Debug("Fetching page", "query", name, "url", resourceUrl, "page", page)
+		if err := client.Query(name, query, variables); err != nil {
+			return err
+		}
+
+		pageInfo := onPage()
+		if !pageInfo.HasNextPage {
+			return nil
+		}
+		after = graphql.NewString(graphql.String(pageInfo.EndCursor))
+	}
+}
+
+// FetchPullRequestChecks returns every check run and commit status of the
+// PR's last commit.
+func FetchPullRequestChecks(prUrl string) ([]CheckContext, error) {
+	var checks []CheckContext
+	var query struct {
+		Resource struct {
+			PullRequest struct {
+				Commits struct {
+					Nodes []struct {
+						Commit struct {
+							StatusCheckRollup struct {
+								Contexts struct {
+									Nodes    []CheckContext
+									PageInfo PageInfo
+								} `graphql:"contexts(first: 100, after: $after)"`
+							}
+						}
+					}
+				} `graphql:"commits(last: 1)"`
+			} `graphql:"... on PullRequest"`
+		} `graphql:"resource(url: $url)"`
+	}
+	err := queryResourcePages("FetchPullRequestChecks", prUrl, &query, func() PageInfo {
+		commits := query.Resource.PullRequest.Commits.Nodes
+		if len(commits) == 0 {
+			return PageInfo{}
+		}
+		contexts := commits[0].Commit.StatusCheckRollup.Contexts
+		checks = append(checks, contexts.Nodes...)
+		return contexts.PageInfo
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Debug("Successfully fetched PR checks", "url", prUrl, "count", len(checks))
+	return checks, nil
+}
+
+// FetchPullRequestFiles returns every file changed by the PR.
+func FetchPullRequestFiles(prUrl string) (PullRequestFiles, error) {
+	var files PullRequestFiles
+	var query struct {
+		Resource struct {
+			PullRequest struct {
+				Files struct {
+					Nodes      []PullRequestFile
+					TotalCount int
+					PageInfo   PageInfo
+				} `graphql:"files(first: 100, after: $after)"`
+			} `graphql:"... on PullRequest"`
+		} `graphql:"resource(url: $url)"`
+	}
+	err := queryResourcePages("FetchPullRequestFiles", prUrl, &query, func() PageInfo {
+		page := query.Resource.PullRequest.Files
+		files.Nodes = append(files.Nodes, page.Nodes...)
+		files.TotalCount = page.TotalCount
+		return page.PageInfo
+	})
+	if err != nil {
+		return PullRequestFiles{}, err
+	}
+
+	log.Debug("Successfully fetched PR files", "url", prUrl, "count", len(files.Nodes))
+	return files, nil
+}
+
+// FetchPullRequestCommits returns every commit of the PR, oldest first.
+func FetchPullRequestCommits(prUrl string) ([]PullRequestCommit, error) {
+	var commits []PullRequestCommit
+	var query struct {
+		Resource struct {
+			PullRequest struct {
+				Commits struct {
+					Nodes []struct {
+						Commit PullRequestCommit
+					}
+					PageInfo PageInfo
+				} `graphql:"commits(first: 100, after: $after)"`
+			} `graphql:"... on PullRequest"`
+		} `graphql:"resource(url: $url)"`
+	}
+	err := queryResourcePages("FetchPullRequestCommits", prUrl, &query, func() PageInfo {
+		page := query.Resource.PullRequest.Commits
+		for _, node := range page.Nodes {
+			commits = append(commits, node.Commit)
+		}
+		return page.PageInfo
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Debug("Successfully fetched PR commits", "url", prUrl, "count", len(commits))
+	return commits, nil
+}
@@ -0,0 +1,99 @@
+package data_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dlvhdr/gh-dash/v4/data"
+)
+
+func TestPullRequestFilesViewedProgress(t *testing.T) {
+	testCases := map[string]struct {
+		states       []string
+		totalCount   int
+		wantViewed   int
+		wantProgress data.ViewedProgress
+	}{
+		"no files": {
+			wantProgress: data.NoneViewed,
+		},
+		"none viewed": {
+			states:       []string{"UNVIEWED", "UNVIEWED"},
+			totalCount:   2,
+			wantProgress: data.NoneViewed,
+		},
+		"some viewed": {
+			states:       []string{"VIEWED", "UNVIEWED"},
+			totalCount:   2,
+			wantViewed:   1,
+			wantProgress: data.SomeViewed,
+		},
+		"changed since viewed": {
+			states:       []string{"VIEWED", "DISMISSED"},
+			totalCount:   2,
+			wantViewed:   1,
+			wantProgress: data.SomeViewed,
+		},
+		"all viewed": {
+			states:       []string{"VIEWED", "VIEWED"},
+			totalCount:   2,
+			wantViewed:   2,
+			wantProgress: data.AllViewed,
+		},
+		"all fetched files viewed but some weren't fetched": {
+			states:       []string{"VIEWED", "VIEWED"},
+			totalCount:   150,
+			wantViewed:   2,
+			wantProgress: data.SomeViewed,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			files := data.PullRequestFiles{TotalCount: tc.totalCount}
+			for _, state := range tc.states {
+				files.Nodes = append(files.Nodes, data.PullRequestFile{ViewerViewedState: state})
+			}
+
+			require.Equal(t, tc.wantViewed, files.ViewedCount())
+			require.Equal(t, tc.wantProgress, files.ViewedProgress())
+		})
+	}
+}
+
+func TestPullRequestFileStatesFiles(t *testing.T) {
+	testCases := map[string]struct {
+		states      []string
+		totalCount  int
+		wantViewed  int
+		wantPartial bool
+	}{
+		"no files": {},
+		"every file's state": {
+			states:     []string{"VIEWED", "UNVIEWED"},
+			totalCount: 2,
+			wantViewed: 1,
+		},
+		"first page of states": {
+			states:      []string{"VIEWED", "VIEWED"},
+			totalCount:  150,
+			wantViewed:  2,
+			wantPartial: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			states := data.PullRequestFileStates{TotalCount: tc.totalCount}
+			for _, state := range tc.states {
+				states.Nodes = append(states.Nodes, data.PullRequestFileState{ViewerViewedState: state})
+			}
+
+			files := states.Files()
+			require.Equal(t, tc.totalCount, files.TotalCount)
+			require.Equal(t, tc.wantViewed, files.ViewedCount())
+			require.Equal(t, tc.wantPartial, files.IsPartial())
+		})
+	}
+}
@@ -1,7 +1,234 @@
 package data
 
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/log"
+	gh "github.com/cli/go-gh/v2/pkg/api"
+	graphql "github.com/cli/shurcooL-graphql"
+)
+
 type Repository struct {
 	Name          string
 	NameWithOwner string
 	IsArchived    bool
 }
+
+type RepositoryMergeSettings struct {
+	MergeCommitAllowed       bool
+	SquashMergeAllowed       bool
+	RebaseMergeAllowed       bool
+	AutoMergeAllowed         bool
+	DeleteBranchOnMerge      bool
+	ViewerDefaultMergeMethod string
+	MergeQueue               *struct {
+		Id string
+	} `graphql:"mergeQueue(branch: $branch)"`
+}
+
+func (s RepositoryMergeSettings) IsMergeQueueEnabled() bool {
+	return s.MergeQueue != nil
+}
+
+// DefaultMergeMethod returns the viewer's default merge method if the
+// repository allows it, or else the first allowed method. It returns an empty
+// string if no merge method is allowed.
+func (s RepositoryMergeSettings) DefaultMergeMethod() string {
+	var methods []string
+	if s.MergeCommitAllowed {
+		methods = append(methods, "merge")
+	}
+	if s.SquashMergeAllowed {
+		methods = append(methods, "squash")
+	}
+	if s.RebaseMergeAllowed {
+		methods = append(methods, "rebase")
+	}
+	if len(methods) == 0 {
+		return ""
+	}
+
+	for _, method := range methods {
+		if strings.EqualFold(method, s.ViewerDefaultMergeMethod) {
+			return method
+		}
+	}
+	return methods[0]
+}
+
+func splitRepoNameWithOwner(nameWithOwner string) (string, string, error) {
+	parts := strings.Split(nameWithOwner, "/")
+	if len(parts) != 2 {
+		return "", "", fmt.Errorf("invalid repository name: %s", nameWithOwner)
+	}
+	return parts[0], parts[1], nil
+}
+
+func FetchRepositoryMergeSettings(nameWithOwner string, baseRefName string) (RepositoryMergeSettings, error) {
+	owner, name, err := splitRepoNameWithOwner(nameWithOwner)
+	if err != nil {
+		return RepositoryMergeSettings{}, err
+	}
+
+	client, err := gh.DefaultGraphQLClient()
+	if err != nil {
+		return RepositoryMergeSettings{}, err
+	}
+
+	var queryResult struct {
+		Repository RepositoryMergeSettings `graphql:"repository(owner: $owner, name: $name)"`
+	}
+	variables := map[string]interface{}{
+		"owner":  graphql.String(owner),
+		"name":   graphql.String(name),
+		"branch": graphql.String(baseRefName),
+	}
+	log.Debug("Fetching repository merge settings", "repo", nameWithOwner, "branch", baseRefName)
+	err = client.Query("RepositoryMergeSettings", &queryResult, variables)
+	if err != nil {
+		return RepositoryMergeSettings{}, err
+	}
+	log.Debug("Successfully fetched repository merge settings", "repo", nameWithOwner, "settings", queryResult.Repository)
+
+	return queryResult.Repository, nil
+}
+
+func FetchRepositoryLabels(nameWithOwner string) ([]Label, error) {
+	owner, name, err := splitRepoNameWithOwner(nameWithOwner)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := gh.DefaultGraphQLClient()
+	if err != nil {
+		return nil, err
+	}
+
+	var queryResult struct {
+		Repository struct {
+			Labels struct {
+				Nodes    []Label
+				PageInfo PageInfo
+			} `graphql:"labels(first: 100, after: $endCursor, orderBy: {field: NAME, direction: ASC})"`
+		} `graphql:"repository(owner: $owner, name: $name)"`
+	}
+
+	labels := []Label{}
+	var endCursor *graphql.String
+	for {
+		variables := map[string]interface{}{
+			"owner":     graphql.String(owner),
+			"name":      graphql.String(name),
+			"endCursor": endCursor,
+		}
+		log.Debug("Fetching repository labels", "repo", nameWithOwner, "cursor", endCursor)
+		err = client.Query("RepositoryLabels", &queryResult, variables)
+		if err != nil {
+			return nil, err
+		}
+
+		labels = append(labels, queryResult.Repository.Labels.Nodes...)
+		pageInfo := queryResult.Repository.Labels.PageInfo
+		if !pageInfo.HasNextPage {
+			break
+		}
+		cursor := graphql.String(pageInfo.EndCursor)
+		endCursor = &cursor
+	}
+	log.Debug("Successfully fetched repository labels", "repo", nameWithOwner, "count", len(labels))
+
+	return labels, nil
+}
+
+type AssignableUser struct {
+	Login string
+	Name  string
+}
+
+type Team struct {
+	Slug string
+	Name string
+}
+
+func FetchAssignableUsers(nameWithOwner string) ([]AssignableUser, error) {
+	owner, name, err := splitRepoNameWithOwner(nameWithOwner)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := gh.DefaultGraphQLClient()
+	if err != nil {
+		return nil, err
+	}
+
+	var queryResult struct {
+		Repository struct {
+			AssignableUsers struct {
+				Nodes    []AssignableUser
+				PageInfo PageInfo
+			} `graphql:"assignableUsers(first: 100, after: $endCursor)"`
+		} `graphql:"repository(owner: $owner, name: $name)"`
+	}
+
+	users := []AssignableUser{}
+	var endCursor *graphql.String
+	for {
+		variables := map[string]interface{}{
+			"owner":     graphql.String(owner),
+			"name":      graphql.String(name),
+			"endCursor": endCursor,
+		}
+		log.Debug("Fetching assignable users", "repo", nameWithOwner, "cursor", endCursor)
+		err = client.Query("AssignableUsers", &queryResult, variables)
+		if err != nil {
+			return nil, err
+		}
+
+		users = append(users, queryResult.Repository.AssignableUsers.Nodes...)
+		pageInfo := queryResult.Repository.AssignableUsers.PageInfo
+		if !pageInfo.HasNextPage {
+			break
+		}
+		cursor := graphql.String(pageInfo.EndCursor)
+		endCursor = &cursor
+	}
+	log.Debug("Successfully fetched assignable users", "repo", nameWithOwner, "count", len(users))
+
+	return users, nil
+}
+
+// FetchOrganizationTeams returns the teams of the organization owning the
+// repository, or no teams if the repository is owned by a user.
+func FetchOrganizationTeams(nameWithOwner string) ([]Team, error) {
+	owner, _, err := splitRepoNameWithOwner(nameWithOwner)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := gh.DefaultGraphQLClient()
+	if err != nil {
+		return nil, err
+	}
+
+	var queryResult struct {
+		RepositoryOwner struct {
+			Organization struct {
+				Teams struct {
+					Nodes []Team
+				} `graphql:"teams(first: 100)"`
+			} `graphql:"... on Organization"`
+		} `graphql:"repositoryOwner(login: $owner)"`
+	}
+	variables := map[string]interface{}{
+		"owner": graphql.String(owner),
+	}
+	log.Debug("Fetching organization teams", "owner", owner)
+	err = client.Query("OrganizationTeams", &queryResult, variables)
+	if err != nil {
+		return nil, err
+	}
+	log.Debug("Successfully fetched organization teams", "owner", owner, "count", len(queryResult.RepositoryOwner.Organization.Teams.Nodes))
+
+	return queryResult.RepositoryOwner.Organization.Teams.Nodes, nil
+}
@@ -0,0 +1,300 @@
+package data
+
+import (
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/log"
+)
+
+type TimelineActor struct {
+	Typename string `graphql:"__typename"`
+	Login    string
+}
+
+// IsBot reports whether the actor is a GitHub App or a bot account.
+func (actor *TimelineActor) IsBot() bool {
+	return actor != nil && (actor.Typename == "Bot" || strings.HasSuffix(actor.Login, "[bot]"))
+}
+
+// GetLogin returns the actor's login, or "ghost" for deleted accounts.
+func (actor *TimelineActor) GetLogin() string {
+	if actor == nil {
+		return "ghost"
+	}
+	return actor.Login
+}
+
+// TimelineEvent is what every event on a timeline has: who caused it and
+// when.
+type TimelineEvent struct {
+	Actor     *TimelineActor
+	CreatedAt time.Time
+}
+
+type TimelineComment struct {
+	Author    *TimelineActor
+	Body      string
+	CreatedAt time.Time
+}
+
+type TimelineLabelEvent struct {
+	TimelineEvent
+	Label struct {
+		Name  string
+		Color string
+	}
+}
+
+type TimelineAssignEvent struct {
+	TimelineEvent
+	Assignee *struct {
+		User struct {
+			Login string
+		} `graphql:"... on User"`
+		Bot struct {
+			Login string
+		} `graphql:"... on Bot"`
+	}
+}
+
+// AssigneeLogin returns the login of the user assigned or unassigned.
+func (event TimelineAssignEvent) AssigneeLogin() string {
+	switch {
+	case event.Assignee == nil:
+		return "ghost"
+	case event.Assignee.User.Login != "":
+		return event.Assignee.User.Login
+	default:
+		return event.Assignee.Bot.Login
+	}
+}
+
+type TimelineCrossReference struct {
+	TimelineEvent
+	WillCloseTarget bool
+	Source          struct {
+		Typename string `graphql:"__typename"`
+		Issue    struct {
+			Number     int
+			Title      string
+			Repository struct {
+				NameWithOwner string
+			}
+		} `graphql:"... on Issue"`
+		PullRequest struct {
+			Number     int
+			Title      string
+			Repository struct {
+				NameWithOwner string
+			}
+		} `graphql:"... on PullRequest"`
+	}
+}
+
+type TimelineRenameEvent struct {
+	TimelineEvent
+	PreviousTitle string
+	CurrentTitle  string
+}
+
+// IssueTimelineItem is an event on the timeline of an issue, which PRs have
+// as well. Only the field matching Typename is set.
+type IssueTimelineItem struct {
+	Typename             string                 `graphql:"__typename"`
+	IssueComment         TimelineComment        `graphql:"... on IssueComment"`
+	LabeledEvent         TimelineLabelEvent     `graphql:"... on LabeledEvent"`
+	UnlabeledEvent       TimelineLabelEvent     `graphql:"... on UnlabeledEvent"`
+	AssignedEvent        TimelineAssignEvent    `graphql:"... on AssignedEvent"`
+	UnassignedEvent      TimelineAssignEvent    `graphql:"... on UnassignedEvent"`
+	CrossReferencedEvent TimelineCrossReference `graphql:"... on CrossReferencedEvent"`
+	ClosedEvent          TimelineEvent          `graphql:"... on ClosedEvent"`
+	ReopenedEvent        TimelineEvent          `graphql:"... on ReopenedEvent"`
+	RenamedTitleEvent    TimelineRenameEvent    `graphql:"... on RenamedTitleEvent"`
+}
+
+type TimelineReview struct {
+	Author    *TimelineActor
+	Body      string
+	State     string
+	CreatedAt time.Time
+	Comments  struct {
+		Nodes []struct {
+			Path string
+			Line *int
+			Body string
+		}
+		TotalCount int
+	} `graphql:"comments(first: 20)"`
+}
+
+type TimelineReviewRequest struct {
+	TimelineEvent
+	RequestedReviewer *struct {
+		User struct {
+			Login string
+		} `graphql:"... on User"`
+		Team struct {
+			Name string
+		} `graphql:"... on Team"`
+	}
+}
+
+// ReviewerName returns the login of the user or the name of the team whose
+// review was requested.
+func (event TimelineReviewRequest) ReviewerName() string {
+	switch {
+	case event.RequestedReviewer == nil:
+		return "ghost"
+	case event.RequestedReviewer.User.Login != "":
+		return event.RequestedReviewer.User.Login
+	default:
+		return event.RequestedReviewer.Team.Name
+	}
+}
+
+type TimelineCommit struct {
+	Commit struct {
+		AbbreviatedOid  string
+		MessageHeadline string
+		CommittedDate   time.Time
+		Author          struct {
+			Name string
+			User *TimelineActor
+		}
+	}
+}
+
+type TimelineForcePush struct {
+	TimelineEvent
+	BeforeCommit *struct {
+		AbbreviatedOid string
+	}
+	AfterCommit *struct {
+		AbbreviatedOid string
+	}
+}
+
+type TimelineMergeEvent struct {
+	TimelineEvent
+	MergeRefName string
+}
+
+// PullRequestTimelineItem is an event on the timeline of a PR. Only the field
+// matching Typename is set.
+type PullRequestTimelineItem struct {
+	IssueTimelineItem
+	PullRequestReview         TimelineReview        `graphql:"... on PullRequestReview"`
+	PullRequestCommit         TimelineCommit        `graphql:"... on PullRequestCommit"`
+	HeadRefForcePushedEvent   TimelineForcePush     `graphql:"... on HeadRefForcePushedEvent"`
+	ReviewRequestedEvent      TimelineReviewRequest `graphql:"... on ReviewRequestedEvent"`
+	ReviewRequestRemovedEvent TimelineReviewRequest `graphql:"... on ReviewRequestRemovedEvent"`
+	MergedEvent               TimelineMergeEvent    `graphql:"... on MergedEvent"`
+	ReadyForReviewEvent       TimelineEvent         `graphql:"... on ReadyForReviewEvent"`
+	ConvertToDraftEvent       TimelineEvent         `graphql:"... on ConvertToDraftEvent"`
+}
+
+// Actor returns who caused the event, which is the author for comments,
+// reviews and commits.
+func (item PullRequestTimelineItem) Actor() *TimelineActor {
+	switch item.Typename {
+	case "IssueComment":
+		return item.IssueComment.Author
+	case "PullRequestReview":
+		return item.PullRequestReview.Author
+	case "PullRequestCommit":
+		return item.PullRequestCommit.Commit.Author.User
+	case "LabeledEvent":
+		return item.LabeledEvent.Actor
+	case "UnlabeledEvent":
+		return item.UnlabeledEvent.Actor
+	case "AssignedEvent":
+		return item.AssignedEvent.Actor
+	case "UnassignedEvent":
+		return item.UnassignedEvent.Actor
+	case "CrossReferencedEvent":
+		return item.CrossReferencedEvent.Actor
+	case "ClosedEvent":
+		return item.ClosedEvent.Actor
+	case "ReopenedEvent":
+		return item.ReopenedEvent.Actor
+	case "RenamedTitleEvent":
+		return item.RenamedTitleEvent.Actor
+	case "HeadRefForcePushedEvent":
+		return item.HeadRefForcePushedEvent.Actor
+	case "ReviewRequestedEvent":
+		return item.ReviewRequestedEvent.Actor
+	case "ReviewRequestRemovedEvent":
+		return item.ReviewRequestRemovedEvent.Actor
+	case "MergedEvent":
+		return item.MergedEvent.Actor
+	case "ReadyForReviewEvent":
+		return item.ReadyForReviewEvent.Actor
+	case "ConvertToDraftEvent":
+		return item.ConvertToDraftEvent.Actor
+	}
+	return nil
+}
+
+// IsComment reports whether the item is a comment or a review, rather than
+// an event.
+func (item PullRequestTimelineItem) IsComment() bool {
+	return item.Typename == "IssueComment" || item.Typename == "PullRequestReview"
+}
+
+// FetchPullRequestTimeline returns every comment, review, commit and event on
+// the PR, oldest first.
+func FetchPullRequestTimeline(prUrl string) ([]PullRequestTimelineItem, error) {
+	var items []PullRequestTimelineItem
+	var query struct {
+		Resource struct {
+			PullRequest struct {
+				TimelineItems struct {
+					Nodes    []PullRequestTimelineItem
+					PageInfo PageInfo
+				} `graphql:"timelineItems(first: 100, after: $after, itemTypes: [ISSUE_COMMENT, LABELED_EVENT, UNLABELED_EVENT, ASSIGNED_EVENT, UNASSIGNED_EVENT, CROSS_REFERENCED_EVENT, CLOSED_EVENT, REOPENED_EVENT, RENAMED_TITLE_EVENT, PULL_REQUEST_REVIEW, PULL_REQUEST_COMMIT, HEAD_REF_FORCE_PUSHED_EVENT, REVIEW_REQUESTED_EVENT, REVIEW_REQUEST_REMOVED_EVENT, MERGED_EVENT, READY_FOR_REVIEW_EVENT, CONVERT_TO_DRAFT_EVENT])"`
+			} `graphql:"... on PullRequest"`
+		} `graphql:"resource(url: $url)"`
+	}
+	err := queryResourcePages("FetchPullRequestTimeline", prUrl, &query, func() PageInfo {
+		page := query.Resource.PullRequest.TimelineItems
+		items = append(items, page.Nodes...)
+		return page.PageInfo
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Debug("Successfully fetched PR timeline", "url", prUrl, "count", len(items))
+	return items, nil
+}
+
+// FetchIssueTimeline returns every comment and event on the issue, oldest
+// first. The items are PR timeline items to render them the same way, but
+// only have the events of issues.
+func FetchIssueTimeline(issueUrl string) ([]PullRequestTimelineItem, error) {
+	var items []PullRequestTimelineItem
+	var query struct {
+		Resource struct {
+			Issue struct {
+				TimelineItems struct {
+					Nodes    []IssueTimelineItem
+					PageInfo PageInfo
+				} `graphql:"timelineItems(first: 100, after: $after, itemTypes: [ISSUE_COMMENT, LABELED_EVENT, UNLABELED_EVENT, ASSIGNED_EVENT, UNASSIGNED_EVENT, CROSS_REFERENCED_EVENT, CLOSED_EVENT, REOPENED_EVENT, RENAMED_TITLE_EVENT])"`
+			} `graphql:"... on Issue"`
+		} `graphql:"resource(url: $url)"`
+	}
+	err := queryResourcePages("FetchIssueTimeline", issueUrl, &query, func() PageInfo {
+		page := query.Resource.Issue.TimelineItems
+		for _, node := range page.Nodes {
+			items = append(items, PullRequestTimelineItem{IssueTimelineItem: node})
+		}
+		return page.PageInfo
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Debug("Successfully fetched issue timeline", "url", issueUrl, "count", len(items))
+	return items, nil
+}
@@ -0,0 +1,55 @@
+package data_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dlvhdr/gh-dash/v4/data"
+)
+
+func TestTimelineItemActor(t *testing.T) {
+	testCases := map[string]struct {
+		item      data.PullRequestTimelineItem
+		wantLogin string
+		wantBot   bool
+	}{
+		"comment by a user": {
+			item: data.PullRequestTimelineItem{IssueTimelineItem: data.IssueTimelineItem{
+				Typename:     "IssueComment",
+				IssueComment: data.TimelineComment{Author: &data.TimelineActor{Typename: "User", Login: "octocat"}},
+			}},
+			wantLogin: "octocat",
+		},
+		"event by an app": {
+			item: data.PullRequestTimelineItem{IssueTimelineItem: data.IssueTimelineItem{
+				Typename:     "LabeledEvent",
+				LabeledEvent: data.TimelineLabelEvent{TimelineEvent: data.TimelineEvent{Actor: &data.TimelineActor{Typename: "Bot", Login: "github-actions"}}},
+			}},
+			wantLogin: "github-actions",
+			wantBot:   true,
+		},
+		"review by a bot account": {
+			item: data.PullRequestTimelineItem{
+				IssueTimelineItem: data.IssueTimelineItem{Typename: "PullRequestReview"},
+				PullRequestReview: data.TimelineReview{Author: &data.TimelineActor{Typename: "User", Login: "renovate[bot]"}},
+			},
+			wantLogin: "renovate[bot]",
+			wantBot:   true,
+		},
+		"commit without a linked user": {
+			item: data.PullRequestTimelineItem{
+				IssueTimelineItem: data.IssueTimelineItem{Typename: "PullRequestCommit"},
+			},
+			wantLogin: "ghost",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			actor := tc.item.Actor()
+			require.Equal(t, tc.wantLogin, actor.GetLogin())
+			require.Equal(t, tc.wantBot, actor.IsBot())
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package common
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/cli/go-gh/v2/pkg/config"
+)
+
+// GetEditor returns the editor gh is configured to use, falling back to
+// $VISUAL, $EDITOR and finally vi.
+func GetEditor() string {
+	if editor := os.Getenv("GH_EDITOR"); editor != "" {
+		return editor
+	}
+	if cfg, err := config.Read(nil); err == nil {
+		if editor, err := cfg.Get([]string{"editor"}); err == nil && editor != "" {
+			return editor
+		}
+	}
+	if editor := os.Getenv("VISUAL"); editor != "" {
+		return editor
+	}
+	if editor := os.Getenv("EDITOR"); editor != "" {
+		return editor
+	}
+	return "vi"
+}
+
+// EditInEditor suspends the program, opens content in the user's editor and
+// passes the edited content to onFinished once the editor exits.
+// pattern is used to name the temporary file, e.g. "*.md".
+func EditInEditor(content string, pattern string, onFinished func(content string, err error) tea.Msg) tea.Cmd {
+	f, err := os.CreateTemp("", "gh-dash-"+pattern)
+	if err != nil {
+		return func() tea.Msg { return onFinished("", err) }
+	}
+	path := f.Name()
+	_, err = f.WriteString(content)
+	f.Close()
+	if err != nil {
+		os.Remove(path)
+		return func() tea.Msg { return onFinished("", err) }
+	}
+
+	args := strings.Fields(GetEditor())
+	args = append(args, path)
+	c := exec.Command(args[0], args[1:]...)
+	return tea.ExecProcess(c, func(err error) tea.Msg {
+		defer os.Remove(path)
+		if err != nil {
+			return onFinished("", err)
+		}
+		edited, err := os.ReadFile(path)
+		if err != nil {
+			return onFinished("", err)
+		}
+		return onFinished(string(edited), nil)
+	})
+}
+
+// FormatTitleAndBody formats a title and body for editing in a single file,
+// with the title on the first line like a commit message.
+func FormatTitleAndBody(title string, body string) string {
+	return title + "\n\n" + strings.ReplaceAll(body, "\r\n", "\n")
+}
+
+// ParseTitleAndBody parses content written in the FormatTitleAndBody format.
+func ParseTitleAndBody(content string) (string, string, error) {
+	title, body, _ := strings.Cut(content, "\n")
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return "", "", errors.New("the title can't be empty")
+	}
+	return title, normalizeBody(body), nil
+}
+
+// EditedTitleAndBody parses content written in the FormatTitleAndBody format
+// from title and body, and returns the title and body if they were changed,
+// or nil for the ones that weren't. Whitespace around them and line endings
+// don't count as changes.
+func EditedTitleAndBody(content string, title string, body string) (*string, *string, error) {
+	editedTitle, editedBody, err := ParseTitleAndBody(content)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var newTitle, newBody *string
+	if editedTitle != strings.TrimSpace(title) {
+		newTitle = &editedTitle
+	}
+	if editedBody != normalizeBody(body) {
+		newBody = &editedBody
+	}
+	return newTitle, newBody, nil
+}
+
+func normalizeBody(body string) string {
+	return strings.TrimSpace(strings.ReplaceAll(body, "\r\n", "\n"))
+}
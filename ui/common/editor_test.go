@@ -0,0 +1,66 @@
+package common_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dlvhdr/gh-dash/v4/ui/common"
+)
+
+func TestEditedTitleAndBody(t *testing.T) {
+	testCases := map[string]struct {
+		title     string
+		body      string
+		edit      func(content string) string
+		wantTitle *string
+		wantBody  *string
+		wantErr   bool
+	}{
+		"unchanged": {
+			title: "Fix the thing",
+			body:  "Some details.\r\n\r\n- a list\r\n",
+			edit:  func(content string) string { return content },
+		},
+		"unchanged with trailing newline": {
+			title: "Fix the thing",
+			body:  "Some details.",
+			edit:  func(content string) string { return content + "\n" },
+		},
+		"title changed": {
+			title:     "Fix the thing",
+			body:      "Some details.\r\n",
+			edit:      func(string) string { return "Fix the other thing\n\nSome details.\n" },
+			wantTitle: ptr("Fix the other thing"),
+		},
+		"body changed": {
+			title:    "Fix the thing",
+			body:     "Some details.",
+			edit:     func(string) string { return "Fix the thing\n\nMore details." },
+			wantBody: ptr("More details."),
+		},
+		"empty title": {
+			title:   "Fix the thing",
+			edit:    func(string) string { return "\n\nSome details." },
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			content := tc.edit(common.FormatTitleAndBody(tc.title, tc.body))
+			title, body, err := common.EditedTitleAndBody(content, tc.title, tc.body)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.wantTitle, title)
+			require.Equal(t, tc.wantBody, body)
+		})
+	}
+}
+
+func ptr(s string) *string {
+	return &s
+}
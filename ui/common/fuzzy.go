@@ -0,0 +1,46 @@
+package common
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// RenderFuzzyMatch renders str with the runes at matchedIndexes styled with
+// matchStyle and the rest with style.
+func RenderFuzzyMatch(str string, matchedIndexes []int, style lipgloss.Style, matchStyle lipgloss.Style) string {
+	if len(matchedIndexes) == 0 {
+		return style.Render(str)
+	}
+
+	matched := make(map[int]bool, len(matchedIndexes))
+	for _, i := range matchedIndexes {
+		matched[i] = true
+	}
+
+	var b strings.Builder
+	var chunk strings.Builder
+	chunkMatched := false
+	flush := func() {
+		if chunk.Len() == 0 {
+			return
+		}
+		if chunkMatched {
+			b.WriteString(matchStyle.Render(chunk.String()))
+		} else {
+			b.WriteString(style.Render(chunk.String()))
+		}
+		chunk.Reset()
+	}
+
+	for i, r := range []rune(str) {
+		if matched[i] != chunkMatched {
+			flush()
+			chunkMatched = matched[i]
+		}
+		chunk.WriteRune(r)
+	}
+	flush()
+
+	return b.String()
+}
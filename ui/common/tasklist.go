@@ -0,0 +1,84 @@
+package common
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	taskListItemRegex = regexp.MustCompile(`^(\s*(?:[-*+]|\d+[.)])\s+\[)([ xX])(\]\s+)(.*?)\r?$`)
+	codeFenceRegex    = regexp.MustCompile("^\\s*(```|~~~)")
+)
+
+// TaskListItem is a GitHub task-list item (`- [ ] task`) in a markdown body.
+// Line is the index of the line the item is on.
+type TaskListItem struct {
+	Line    int
+	Checked bool
+	Text    string
+}
+
+// ParseTaskList returns the task-list items of body, skipping code blocks.
+func ParseTaskList(body string) []TaskListItem {
+	items := []TaskListItem{}
+	inCodeBlock := false
+	for i, line := range strings.Split(body, "\n") {
+		if codeFenceRegex.MatchString(line) {
+			inCodeBlock = !inCodeBlock
+			continue
+		}
+		if inCodeBlock {
+			continue
+		}
+
+		match := taskListItemRegex.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		items = append(items, TaskListItem{
+			Line:    i,
+			Checked: match[2] != " ",
+			Text:    match[4],
+		})
+	}
+	return items
+}
+
+// ToggleTaskListItem returns body with the checkbox of item toggled.
+func ToggleTaskListItem(body string, item TaskListItem) string {
+	mark := "x"
+	if item.Checked {
+		mark = " "
+	}
+	return replaceTaskListLine(body, item, func(match []string) string {
+		return match[1] + mark + match[3] + match[4]
+	})
+}
+
+// HighlightTaskListItem returns body with the text of item replaced by
+// highlighted, used to show the selected item when rendering the body.
+func HighlightTaskListItem(body string, item TaskListItem, highlighted string) string {
+	return replaceTaskListLine(body, item, func(match []string) string {
+		return match[1] + match[2] + match[3] + highlighted
+	})
+}
+
+func replaceTaskListLine(body string, item TaskListItem, replace func(match []string) string) string {
+	lines := strings.Split(body, "\n")
+	if item.Line < 0 || item.Line >= len(lines) {
+		return body
+	}
+
+	line := lines[item.Line]
+	match := taskListItemRegex.FindStringSubmatch(line)
+	if match == nil {
+		return body
+	}
+
+	newLine := replace(match)
+	if strings.HasSuffix(line, "\r") {
+		newLine += "\r"
+	}
+	lines[item.Line] = newLine
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,67 @@
+package common_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dlvhdr/gh-dash/v4/ui/common"
+)
+
+func TestParseTaskList(t *testing.T) {
+	testCases := map[string]struct {
+		body string
+		want []common.TaskListItem
+	}{
+		"no tasks": {
+			body: "Just a description\n- a list item",
+			want: []common.TaskListItem{},
+		},
+		"checked and unchecked tasks": {
+			body: "Todo:\n- [ ] first\n  * [x] nested\r\n1. [X] numbered",
+			want: []common.TaskListItem{
+				{Line: 1, Checked: false, Text: "first"},
+				{Line: 2, Checked: true, Text: "nested"},
+				{Line: 3, Checked: true, Text: "numbered"},
+			},
+		},
+		"tasks in code blocks are skipped": {
+			body: "```md\n- [ ] in code\n```\n- [ ] outside",
+			want: []common.TaskListItem{
+				{Line: 3, Checked: false, Text: "outside"},
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.want, common.ParseTaskList(tc.body))
+		})
+	}
+}
+
+func TestToggleTaskListItem(t *testing.T) {
+	testCases := map[string]struct {
+		body string
+		item int
+		want string
+	}{
+		"check": {
+			body: "- [ ] first\n- [ ] second",
+			item: 1,
+			want: "- [ ] first\n- [x] second",
+		},
+		"uncheck and keep line endings": {
+			body: "- [X] first\r\n- [ ] second",
+			item: 0,
+			want: "- [ ] first\r\n- [ ] second",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			items := common.ParseTaskList(tc.body)
+			require.Equal(t, tc.want, common.ToggleTaskListItem(tc.body, items[tc.item]))
+		})
+	}
+}
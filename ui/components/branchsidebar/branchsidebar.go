@@ -59,7 +59,7 @@ func (m Model) View() string {
 
 	s.WriteString("\n\n")
 	s.WriteString(lipgloss.NewStyle().Foreground(m.ctx.Theme.FaintBorder).Render(
-		strings.Repeat(lipgloss.NormalBorder().Bottom, m.ctx.Config.Defaults.Preview.Width-5)),
+		strings.Repeat(lipgloss.NormalBorder().Bottom, m.ctx.PreviewWidth-5)),
 	)
 	s.WriteString("\n\n")
 
@@ -0,0 +1,512 @@
+package diffview
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/dlvhdr/gh-dash/v4/data"
+	"github.com/dlvhdr/gh-dash/v4/ui/constants"
+	"github.com/dlvhdr/gh-dash/v4/ui/context"
+)
+
+const (
+	// splitMinWidth is the minimal width for showing the file tree next to
+	// the diff. Narrower terminals show one of them at a time.
+	splitMinWidth = 120
+	minTreeWidth  = 24
+	maxTreeWidth  = 48
+)
+
+var (
+	upKey         = key.NewBinding(key.WithKeys("up", "k"), key.WithHelp("↑/k", "up"))
+	downKey       = key.NewBinding(key.WithKeys("down", "j"), key.WithHelp("↓/j", "down"))
+	pageUpKey     = key.NewBinding(key.WithKeys("ctrl+u", "pgup"), key.WithHelp("ctrl+u", "page up"))
+	pageDownKey   = key.NewBinding(key.WithKeys("ctrl+d", "pgdown"), key.WithHelp("ctrl+d", "page down"))
+	topKey        = key.NewBinding(key.WithKeys("g", "home"), key.WithHelp("g", "top"))
+	bottomKey     = key.NewBinding(key.WithKeys("G", "end"), key.WithHelp("G", "bottom"))
+	nextHunkKey   = key.NewBinding(key.WithKeys("n"), key.WithHelp("n/N", "next/prev hunk"))
+	prevHunkKey   = key.NewBinding(key.WithKeys("N"))
+	nextFileKey   = key.NewBinding(key.WithKeys("]"), key.WithHelp("]/[", "next/prev file"))
+	prevFileKey   = key.NewBinding(key.WithKeys("["))
+	selectKey     = key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "show file"))
+	focusKey      = key.NewBinding(key.WithKeys("tab"), key.WithHelp("tab", "switch focus"))
+	treeKey       = key.NewBinding(key.WithKeys("t"), key.WithHelp("t", "toggle files"))
+	sideBySideKey = key.NewBinding(key.WithKeys("s"), key.WithHelp("s", "side-by-side"))
+	pagerKey      = key.NewBinding(key.WithKeys("p"), key.WithHelp("p", "open in pager"))
+	closeKey      = key.NewBinding(
+		key.WithKeys(tea.KeyEsc.String(), tea.KeyCtrlC.String(), "q"),
+		key.WithHelp("esc", "close"),
+	)
+)
+
+// OpenMsg opens the diff of Pr in the diff view.
+type OpenMsg struct {
+	Pr *data.PullRequestData
+}
+
+type diffFetchedMsg struct {
+	url  string
+	diff string
+	err  error
+}
+
+// Model is the full screen diff view of a PR. It lists the changed files in
+// a tree next to their syntax highlighted diff, with the PR's review threads
+// shown below the lines they're attached to.
+type Model struct {
+	ctx         *context.ProgramContext
+	pr          *data.PullRequestData
+	isOpen      bool
+	isLoading   bool
+	err         error
+	files       []File
+	tree        []treeEntry
+	treeCursor  int
+	treeOffset  int
+	isTreeFocus bool
+	showTree    bool
+	sideBySide  bool
+	out         rendered
+	viewport    viewport.Model
+	highlighter *highlighter
+	width       int
+	height      int
+	help        help.Model
+}
+
+func NewModel(ctx *context.ProgramContext) Model {
+	return Model{
+		ctx:      ctx,
+		showTree: true,
+		viewport: viewport.New(0, 0),
+		help:     help.New(),
+	}
+}
+
+func (m *Model) Open(pr *data.PullRequestData) tea.Cmd {
+	m.isOpen = true
+	m.isLoading = true
+	m.err = nil
+	m.pr = pr
+	m.files = nil
+	m.tree = nil
+	m.out = rendered{}
+	m.treeCursor = 0
+	m.treeOffset = 0
+	m.isTreeFocus = false
+	m.viewport.SetContent("")
+	m.viewport.GotoTop()
+	if m.highlighter == nil {
+		m.highlighter = newHighlighter()
+	}
+
+	url, repo, number := pr.Url, pr.GetRepoNameWithOwner(), pr.Number
+	return func() tea.Msg {
+		diff, err := data.FetchPullRequestDiff(repo, number)
+		return diffFetchedMsg{url: url, diff: diff, err: err}
+	}
+}
+
+func (m *Model) Close() {
+	m.isOpen = false
+}
+
+func (m *Model) IsOpen() bool {
+	return m.isOpen
+}
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case diffFetchedMsg:
+		if m.pr == nil || msg.url != m.pr.Url {
+			return m, nil
+		}
+		m.isLoading = false
+		m.err = msg.err
+		m.files = Parse(msg.diff)
+		sortFiles(m.files)
+		m.tree = buildTree(m.files)
+		m.treeCursor = m.nextFileEntry(-1, 1)
+		m.render()
+
+	case tea.KeyMsg:
+		return m.onKey(msg)
+	}
+
+	return m, nil
+}
+
+func (m Model) onKey(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, closeKey):
+		m.Close()
+		return m, nil
+
+	case key.Matches(msg, pagerKey):
+		return m, m.openInPager()
+
+	case key.Matches(msg, sideBySideKey):
+		m.sideBySide = !m.sideBySide
+		file := m.currFile()
+		m.render()
+		m.gotoFile(file)
+		return m, nil
+
+	case key.Matches(msg, treeKey):
+		m.showTree = !m.showTree
+		m.isTreeFocus = false
+		file := m.currFile()
+		m.render()
+		m.gotoFile(file)
+		return m, nil
+
+	case key.Matches(msg, focusKey):
+		if m.isSplit() {
+			m.isTreeFocus = !m.isTreeFocus
+		}
+		return m, nil
+	}
+
+	if m.isTreeFocused() {
+		m.onTreeKey(msg)
+		return m, nil
+	}
+
+	switch {
+	case key.Matches(msg, upKey):
+		m.viewport.LineUp(1)
+	case key.Matches(msg, downKey):
+		m.viewport.LineDown(1)
+	case key.Matches(msg, pageUpKey):
+		m.viewport.HalfViewUp()
+	case key.Matches(msg, pageDownKey):
+		m.viewport.HalfViewDown()
+	case key.Matches(msg, topKey):
+		m.viewport.GotoTop()
+	case key.Matches(msg, bottomKey):
+		m.viewport.GotoBottom()
+	case key.Matches(msg, nextHunkKey):
+		m.jumpForward(m.out.hunkOffsets)
+	case key.Matches(msg, prevHunkKey):
+		m.jumpBackward(m.out.hunkOffsets)
+	case key.Matches(msg, nextFileKey):
+		m.selectFile(m.jumpForward(m.out.fileOffsets))
+		return m, nil
+	case key.Matches(msg, prevFileKey):
+		m.selectFile(m.jumpBackward(m.out.fileOffsets))
+		return m, nil
+	}
+	m.syncTreeCursor()
+
+	return m, nil
+}
+
+func (m *Model) onTreeKey(msg tea.KeyMsg) {
+	switch {
+	case key.Matches(msg, upKey):
+		m.treeCursor = m.nextFileEntry(m.treeCursor, -1)
+	case key.Matches(msg, downKey):
+		m.treeCursor = m.nextFileEntry(m.treeCursor, 1)
+	case key.Matches(msg, topKey):
+		m.treeCursor = m.nextFileEntry(-1, 1)
+	case key.Matches(msg, bottomKey):
+		m.treeCursor = m.nextFileEntry(len(m.tree), -1)
+	case key.Matches(msg, selectKey):
+		m.isTreeFocus = false
+		if !m.isSplit() {
+			m.showTree = false
+			m.render()
+		}
+	default:
+		return
+	}
+
+	if m.treeCursor >= 0 && m.treeCursor < len(m.tree) {
+		m.gotoFile(m.tree[m.treeCursor].file)
+	}
+}
+
+// nextFileEntry returns the index of the closest file entry in the tree
+// after, or before when dir is negative, the entry at i. It returns i if there
+// is none.
+func (m *Model) nextFileEntry(i int, dir int) int {
+	for j := i + dir; j >= 0 && j < len(m.tree); j += dir {
+		if m.tree[j].file != -1 {
+			return j
+		}
+	}
+	return max(0, min(i, len(m.tree)-1))
+}
+
+// jumpForward scrolls to the first of offsets below the top of the diff and
+// returns its index, or -1 if there is none.
+func (m *Model) jumpForward(offsets []int) int {
+	for i, offset := range offsets {
+		if offset > m.viewport.YOffset {
+			m.viewport.SetYOffset(offset)
+			return i
+		}
+	}
+	return -1
+}
+
+// jumpBackward scrolls to the last of offsets above the top of the diff and
+// returns its index, or -1 if there is none.
+func (m *Model) jumpBackward(offsets []int) int {
+	for i := len(offsets) - 1; i >= 0; i-- {
+		if offsets[i] < m.viewport.YOffset {
+			m.viewport.SetYOffset(offsets[i])
+			return i
+		}
+	}
+	return -1
+}
+
+// selectFile moves the tree's cursor to file. Files near the end of the diff
+// may not reach the top of it, so the cursor can't always follow the scroll.
+func (m *Model) selectFile(file int) {
+	for i, e := range m.tree {
+		if e.file == file {
+			m.treeCursor = i
+			return
+		}
+	}
+}
+
+// currFile returns the index of the file at the top of the diff.
+func (m *Model) currFile() int {
+	file := 0
+	for i, offset := range m.out.fileOffsets {
+		if offset <= m.viewport.YOffset {
+			file = i
+		}
+	}
+	return file
+}
+
+func (m *Model) gotoFile(file int) {
+	if file >= 0 && file < len(m.out.fileOffsets) {
+		m.viewport.SetYOffset(m.out.fileOffsets[file])
+	}
+}
+
+// syncTreeCursor moves the tree's cursor to the file at the top of the diff.
+func (m *Model) syncTreeCursor() {
+	file := m.currFile()
+	// Keep a file that can't reach the top of the diff selected.
+	if m.viewport.AtBottom() && m.treeCursor >= 0 && m.treeCursor < len(m.tree) && m.tree[m.treeCursor].file > file {
+		return
+	}
+	m.selectFile(file)
+}
+
+// isTreeFocused returns whether keys move the tree's cursor, which they do
+// when the tree is focused or shown on its own.
+func (m *Model) isTreeFocused() bool {
+	return m.showTree && (m.isTreeFocus || !m.isSplit())
+}
+
+func (m *Model) isSplit() bool {
+	return m.showTree && m.width >= splitMinWidth
+}
+
+func (m *Model) treeWidth() int {
+	return max(minTreeWidth, min(maxTreeWidth, m.width/4))
+}
+
+func (m *Model) bodyHeight() int {
+	// The title and the help take a line each.
+	return max(1, m.height-2)
+}
+
+func (m *Model) diffWidth() int {
+	if m.isSplit() {
+		return max(1, m.width-m.treeWidth()-1)
+	}
+	return max(1, m.width)
+}
+
+// render renders the diff for the current size and mode. The rendered
+// lines are kept so scrolling doesn't render them again.
+func (m *Model) render() {
+	m.viewport.Width = m.diffWidth()
+	m.viewport.Height = m.bodyHeight()
+	if m.isLoading || len(m.files) == 0 {
+		return
+	}
+
+	r := renderer{
+		ctx:         m.ctx,
+		highlighter: m.highlighter,
+		threads:     threadsByPath(m.pr),
+		width:       m.diffWidth(),
+		sideBySide:  m.sideBySide,
+	}
+	m.out = r.render(m.files)
+	m.viewport.SetContent(strings.Join(m.out.lines, "\n"))
+}
+
+func (m *Model) SetSize(width int, height int) {
+	if width == m.width && height == m.height {
+		return
+	}
+	file := m.currFile()
+	m.width = width
+	m.height = height
+	m.help.Width = width
+	m.render()
+	m.gotoFile(file)
+}
+
+func (m *Model) UpdateProgramContext(ctx *context.ProgramContext) {
+	m.ctx = ctx
+	m.help.Styles = ctx.Styles.Help.BubbleStyles
+}
+
+func (m *Model) openInPager() tea.Cmd {
+	if m.pr == nil {
+		return nil
+	}
+	c := exec.Command(
+		"gh",
+		"pr",
+		"diff",
+		fmt.Sprint(m.pr.Number),
+		"-R",
+		m.pr.GetRepoNameWithOwner(),
+	)
+	c.Env = m.ctx.Config.GetFullScreenDiffPagerEnv()
+
+	return tea.ExecProcess(c, func(err error) tea.Msg {
+		if err != nil {
+			return constants.ErrMsg{Err: err}
+		}
+		return nil
+	})
+}
+
+func (m *Model) View() string {
+	if m.pr == nil {
+		return ""
+	}
+
+	body := m.viewBody()
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		m.viewTitle(),
+		lipgloss.NewStyle().Height(m.bodyHeight()).MaxHeight(m.bodyHeight()).Render(body),
+		m.viewHelp(),
+	)
+}
+
+func (m *Model) viewTitle() string {
+	theme := m.ctx.Theme
+	title := lipgloss.NewStyle().Bold(true).Foreground(theme.PrimaryText).
+		Render(fmt.Sprintf("#%d %s", m.pr.Number, m.pr.Title))
+
+	additions, deletions := 0, 0
+	for _, f := range m.files {
+		additions += f.Additions
+		deletions += f.Deletions
+	}
+	mode := "unified"
+	if m.sideBySide {
+		mode = "side-by-side"
+	}
+	stats := lipgloss.JoinHorizontal(
+		lipgloss.Top,
+		lipgloss.NewStyle().Foreground(theme.FaintText).Render(fmt.Sprintf("%s · %d files ", mode, len(m.files))),
+		lipgloss.NewStyle().Foreground(theme.SuccessText).Render(fmt.Sprintf("+%d", additions)),
+		" ",
+		lipgloss.NewStyle().Foreground(theme.ErrorText).Render(fmt.Sprintf("-%d", deletions)),
+	)
+
+	titleWidth := max(0, m.width-lipgloss.Width(stats)-1)
+	title = ansi.Truncate(title, titleWidth, "…")
+	gap := strings.Repeat(" ", max(1, m.width-lipgloss.Width(title)-lipgloss.Width(stats)))
+	return title + gap + stats
+}
+
+func (m *Model) viewBody() string {
+	faint := lipgloss.NewStyle().Foreground(m.ctx.Theme.FaintText)
+	switch {
+	case m.isLoading:
+		return faint.Render("Loading diff...")
+	case m.err != nil:
+		return lipgloss.NewStyle().Foreground(m.ctx.Theme.ErrorText).Render(m.err.Error())
+	case len(m.files) == 0:
+		return faint.Render("No changes")
+	}
+
+	if !m.showTree {
+		return m.viewport.View()
+	}
+	if !m.isSplit() {
+		return m.viewTree(m.width)
+	}
+
+	sep := lipgloss.NewStyle().
+		Foreground(m.ctx.Theme.FaintBorder).
+		Render(strings.TrimSuffix(strings.Repeat("│\n", m.bodyHeight()), "\n"))
+	return lipgloss.JoinHorizontal(lipgloss.Top, m.viewTree(m.treeWidth()), sep, m.viewport.View())
+}
+
+func (m *Model) viewTree(width int) string {
+	theme := m.ctx.Theme
+	height := m.bodyHeight()
+	if m.treeCursor < m.treeOffset {
+		m.treeOffset = m.treeCursor
+	}
+	if m.treeCursor >= m.treeOffset+height {
+		m.treeOffset = m.treeCursor - height + 1
+	}
+
+	var lines []string
+	for i := m.treeOffset; i < min(len(m.tree), m.treeOffset+height); i++ {
+		e := m.tree[i]
+		indent := strings.Repeat("  ", e.depth)
+		if e.file == -1 {
+			lines = append(lines, lipgloss.NewStyle().Foreground(theme.FaintText).
+				Width(width).MaxWidth(width).Render(indent+e.name))
+			continue
+		}
+
+		f := m.files[e.file]
+		stats := lipgloss.NewStyle().Foreground(theme.SuccessText).Render(fmt.Sprintf("+%d", f.Additions)) +
+			" " + lipgloss.NewStyle().Foreground(theme.ErrorText).Render(fmt.Sprintf("-%d", f.Deletions))
+		nameWidth := max(0, width-lipgloss.Width(stats)-1)
+		name := ansi.Truncate(indent+e.name, nameWidth, "…")
+		nameStyle := lipgloss.NewStyle().Foreground(theme.PrimaryText)
+		if i == m.treeCursor {
+			nameStyle = nameStyle.Bold(true)
+			if m.isTreeFocused() {
+				nameStyle = nameStyle.Background(theme.SelectedBackground)
+			}
+		}
+		gap := strings.Repeat(" ", max(1, width-lipgloss.Width(name)-lipgloss.Width(stats)))
+		lines = append(lines, nameStyle.Render(name+gap)+stats)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (m *Model) viewHelp() string {
+	bindings := []key.Binding{}
+	if m.isTreeFocused() {
+		bindings = append(bindings, upKey, downKey, selectKey)
+	} else {
+		bindings = append(bindings, upKey, downKey, pageDownKey, nextHunkKey, nextFileKey)
+	}
+	if m.isSplit() {
+		bindings = append(bindings, focusKey)
+	}
+	bindings = append(bindings, treeKey, sideBySideKey, pagerKey, closeKey)
+	return m.help.ShortHelpView(bindings)
+}
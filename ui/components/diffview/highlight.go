@@ -0,0 +1,88 @@
+package diffview
+
+import (
+	"strings"
+
+	"github.com/alecthomas/chroma/v2"
+	"github.com/alecthomas/chroma/v2/lexers"
+	"github.com/alecthomas/chroma/v2/styles"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const tabWidth = 4
+
+// highlighter renders lines of code with the colors of a chroma style, on
+// top of the background of the diff line they're in.
+type highlighter struct {
+	style   *chroma.Style
+	lexers  map[string]chroma.Lexer
+	entries map[chroma.TokenType]lipgloss.Style
+}
+
+func newHighlighter() *highlighter {
+	name := "github"
+	if lipgloss.HasDarkBackground() {
+		name = "github-dark"
+	}
+	return &highlighter{
+		style:   styles.Get(name),
+		lexers:  map[string]chroma.Lexer{},
+		entries: map[chroma.TokenType]lipgloss.Style{},
+	}
+}
+
+func (h *highlighter) lexer(path string) chroma.Lexer {
+	if l, ok := h.lexers[path]; ok {
+		return l
+	}
+	l := lexers.Match(path)
+	if l == nil {
+		l = lexers.Fallback
+	}
+	l = chroma.Coalesce(l)
+	h.lexers[path] = l
+	return l
+}
+
+func (h *highlighter) tokenStyle(t chroma.TokenType) lipgloss.Style {
+	if s, ok := h.entries[t]; ok {
+		return s
+	}
+	entry := h.style.Get(t)
+	s := lipgloss.NewStyle()
+	if entry.Colour.IsSet() {
+		s = s.Foreground(lipgloss.Color(entry.Colour.String()))
+	}
+	if entry.Bold == chroma.Yes {
+		s = s.Bold(true)
+	}
+	if entry.Italic == chroma.Yes {
+		s = s.Italic(true)
+	}
+	h.entries[t] = s
+	return s
+}
+
+// highlight returns the line of the file at path with its tokens colored.
+// Every token is rendered with bg as its background.
+func (h *highlighter) highlight(path string, line string, bg lipgloss.TerminalColor) string {
+	line = expandTabs(line)
+	it, err := h.lexer(path).Tokenise(nil, line)
+	if err != nil {
+		return lipgloss.NewStyle().Background(bg).Render(line)
+	}
+
+	var b strings.Builder
+	for _, token := range it.Tokens() {
+		value := strings.TrimRight(token.Value, "\n")
+		if value == "" {
+			continue
+		}
+		b.WriteString(h.tokenStyle(token.Type).Background(bg).Render(value))
+	}
+	return b.String()
+}
+
+func expandTabs(s string) string {
+	return strings.ReplaceAll(s, "\t", strings.Repeat(" ", tabWidth))
+}
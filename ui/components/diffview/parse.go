@@ -0,0 +1,201 @@
+package diffview
+
+import (
+	"strconv"
+	"strings"
+)
+
+type LineKind int
+
+const (
+	ContextLine LineKind = iota
+	AddedLine
+	RemovedLine
+)
+
+type Line struct {
+	Kind    LineKind
+	Content string
+	// OldLine and NewLine are the line numbers in the old and new versions
+	// of the file. Added lines have no old line and removed lines have no new
+	// line, which are 0.
+	OldLine int
+	NewLine int
+}
+
+type Hunk struct {
+	Header string
+	Lines  []Line
+}
+
+type File struct {
+	// OldPath is empty for added files and NewPath is empty for deleted ones.
+	OldPath   string
+	NewPath   string
+	Hunks     []Hunk
+	Additions int
+	Deletions int
+	IsBinary  bool
+}
+
+func (f File) Path() string {
+	if f.NewPath == "" {
+		return f.OldPath
+	}
+	return f.NewPath
+}
+
+func (f File) Status() string {
+	switch {
+	case f.OldPath == "":
+		return "added"
+	case f.NewPath == "":
+		return "deleted"
+	case f.OldPath != f.NewPath:
+		return "renamed"
+	default:
+		return "modified"
+	}
+}
+
+// Parse parses a unified diff as produced by git into its files.
+func Parse(diff string) []File {
+	var (
+		files    []File
+		file     *File
+		hunk     *Hunk
+		oldLn    int
+		newLn    int
+		oldCount int
+		newCount int
+	)
+
+	for _, line := range strings.Split(diff, "\n") {
+		// Lines of a hunk are consumed by count so removed lines that look
+		// like file headers, e.g. "--- a", aren't mistaken for them.
+		if hunk != nil && (oldCount > 0 || newCount > 0) {
+			switch {
+			case strings.HasPrefix(line, "+"):
+				hunk.Lines = append(hunk.Lines, Line{Kind: AddedLine, Content: line[1:], NewLine: newLn})
+				file.Additions++
+				newLn++
+				newCount--
+			case strings.HasPrefix(line, "-"):
+				hunk.Lines = append(hunk.Lines, Line{Kind: RemovedLine, Content: line[1:], OldLine: oldLn})
+				file.Deletions++
+				oldLn++
+				oldCount--
+			case strings.HasPrefix(line, `\`):
+				// "\ No newline at end of file"
+			default:
+				hunk.Lines = append(hunk.Lines, Line{
+					Kind:    ContextLine,
+					Content: strings.TrimPrefix(line, " "),
+					OldLine: oldLn,
+					NewLine: newLn,
+				})
+				oldLn++
+				newLn++
+				oldCount--
+				newCount--
+			}
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(line, "diff --git "):
+			files = append(files, File{})
+			file = &files[len(files)-1]
+			hunk = nil
+			file.OldPath, file.NewPath = parseGitHeader(line)
+
+		case file == nil:
+			continue
+
+		case strings.HasPrefix(line, "new file mode"):
+			file.OldPath = ""
+
+		case strings.HasPrefix(line, "deleted file mode"):
+			file.NewPath = ""
+
+		case strings.HasPrefix(line, "rename from "):
+			file.OldPath = strings.TrimPrefix(line, "rename from ")
+
+		case strings.HasPrefix(line, "rename to "):
+			file.NewPath = strings.TrimPrefix(line, "rename to ")
+
+		case strings.HasPrefix(line, "Binary files "):
+			file.IsBinary = true
+
+		case strings.HasPrefix(line, "--- "):
+			file.OldPath = parseFilePath(strings.TrimPrefix(line, "--- "), "a/")
+
+		case strings.HasPrefix(line, "+++ "):
+			file.NewPath = parseFilePath(strings.TrimPrefix(line, "+++ "), "b/")
+
+		case strings.HasPrefix(line, "@@ "):
+			var ok bool
+			oldLn, oldCount, newLn, newCount, ok = parseHunkHeader(line)
+			if !ok {
+				continue
+			}
+			file.Hunks = append(file.Hunks, Hunk{Header: line})
+			hunk = &file.Hunks[len(file.Hunks)-1]
+		}
+	}
+
+	return files
+}
+
+// parseGitHeader returns the paths in a "diff --git a/old b/new" line.
+func parseGitHeader(line string) (string, string) {
+	paths := strings.TrimPrefix(line, "diff --git ")
+	i := strings.LastIndex(paths, " b/")
+	if i == -1 {
+		return "", ""
+	}
+	return strings.TrimPrefix(paths[:i], "a/"), paths[i+len(" b/"):]
+}
+
+func parseFilePath(path string, prefix string) string {
+	path = strings.TrimSuffix(path, "\t")
+	if path == "/dev/null" {
+		return ""
+	}
+	return strings.TrimPrefix(path, prefix)
+}
+
+// parseHunkHeader parses a "@@ -oldStart,oldCount +newStart,newCount @@"
+// line. Counts that are left out are 1.
+func parseHunkHeader(line string) (oldStart, oldCount, newStart, newCount int, ok bool) {
+	fields := strings.Fields(line)
+	if len(fields) < 3 {
+		return 0, 0, 0, 0, false
+	}
+	oldStart, oldCount, ok = parseRange(fields[1], "-")
+	if !ok {
+		return 0, 0, 0, 0, false
+	}
+	newStart, newCount, ok = parseRange(fields[2], "+")
+	return oldStart, oldCount, newStart, newCount, ok
+}
+
+func parseRange(r string, prefix string) (int, int, bool) {
+	r, ok := strings.CutPrefix(r, prefix)
+	if !ok {
+		return 0, 0, false
+	}
+	startStr, countStr, hasCount := strings.Cut(r, ",")
+	start, err := strconv.Atoi(startStr)
+	if err != nil {
+		return 0, 0, false
+	}
+	count := 1
+	if hasCount {
+		count, err = strconv.Atoi(countStr)
+		if err != nil {
+			return 0, 0, false
+		}
+	}
+	return start, count, true
+}
@@ -0,0 +1,80 @@
+package diffview_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dlvhdr/gh-dash/v4/ui/components/diffview"
+)
+
+const testDiff = `diff --git a/main.go b/main.go
+index 1111111..2222222 100644
+--- a/main.go
++++ b/main.go
+@@ -1,4 +1,4 @@ package main
+ package main
+
+--- old comment
++// new comment
+ func main() {}
+@@ -10 +10,2 @@ func other() {
+ 	return
++	// done
+diff --git a/docs/new.md b/docs/new.md
+new file mode 100644
+index 0000000..3333333
+--- /dev/null
++++ b/docs/new.md
+@@ -0,0 +1 @@
++# New
+\ No newline at end of file
+diff --git a/old.txt b/old.txt
+deleted file mode 100644
+index 4444444..0000000
+--- a/old.txt
++++ /dev/null
+@@ -1 +0,0 @@
+-bye
+diff --git a/logo.png b/assets/logo.png
+similarity index 100%
+rename from logo.png
+rename to assets/logo.png
+diff --git a/image.png b/image.png
+index 5555555..6666666 100644
+Binary files a/image.png and b/image.png differ
+`
+
+func TestParse(t *testing.T) {
+	files := diffview.Parse(testDiff)
+	require.Len(t, files, 5)
+
+	main := files[0]
+	require.Equal(t, "main.go", main.Path())
+	require.Equal(t, "modified", main.Status())
+	require.Equal(t, 2, main.Additions)
+	require.Equal(t, 1, main.Deletions)
+	require.Len(t, main.Hunks, 2)
+	require.Equal(t, []diffview.Line{
+		{Kind: diffview.ContextLine, Content: "package main", OldLine: 1, NewLine: 1},
+		{Kind: diffview.ContextLine, Content: "", OldLine: 2, NewLine: 2},
+		{Kind: diffview.RemovedLine, Content: "-- old comment", OldLine: 3},
+		{Kind: diffview.AddedLine, Content: "// new comment", NewLine: 3},
+		{Kind: diffview.ContextLine, Content: "func main() {}", OldLine: 4, NewLine: 4},
+	}, main.Hunks[0].Lines)
+	require.Equal(t, diffview.Line{Kind: diffview.AddedLine, Content: "\t// done", NewLine: 11}, main.Hunks[1].Lines[1])
+
+	require.Equal(t, "docs/new.md", files[1].Path())
+	require.Equal(t, "added", files[1].Status())
+	require.Equal(t, []diffview.Line{{Kind: diffview.AddedLine, Content: "# New", NewLine: 1}}, files[1].Hunks[0].Lines)
+
+	require.Equal(t, "old.txt", files[2].Path())
+	require.Equal(t, "deleted", files[2].Status())
+	require.Equal(t, 1, files[2].Deletions)
+
+	require.Equal(t, "assets/logo.png", files[3].Path())
+	require.Equal(t, "renamed", files[3].Status())
+	require.Empty(t, files[3].Hunks)
+
+	require.True(t, files[4].IsBinary)
+}
@@ -0,0 +1,259 @@
+package diffview
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/dlvhdr/gh-dash/v4/data"
+	"github.com/dlvhdr/gh-dash/v4/ui/context"
+	"github.com/dlvhdr/gh-dash/v4/utils"
+)
+
+var (
+	addedBackground   = lipgloss.AdaptiveColor{Light: "#e6ffec", Dark: "#16301f"}
+	removedBackground = lipgloss.AdaptiveColor{Light: "#ffebe9", Dark: "#3a1d20"}
+)
+
+// thread is a review thread shown below the line it's attached to.
+type thread struct {
+	side       string
+	line       int
+	isResolved bool
+	comments   []data.ReviewComment
+}
+
+// threadsByPath returns the review threads of the PR that are attached to a
+// line of the current diff.
+func threadsByPath(pr *data.PullRequestData) map[string][]thread {
+	threads := map[string][]thread{}
+	for _, t := range pr.ReviewThreads.Nodes {
+		if t.IsOutdated || t.Line == 0 || len(t.Comments.Nodes) == 0 {
+			continue
+		}
+		threads[t.Path] = append(threads[t.Path], thread{
+			side:       t.DiffSide,
+			line:       t.Line,
+			isResolved: t.IsResolved,
+			comments:   t.Comments.Nodes,
+		})
+	}
+	return threads
+}
+
+// rendered is the diff rendered as lines, along with the lines where each of
+// its files and hunks start.
+type rendered struct {
+	lines       []string
+	fileOffsets []int
+	hunkOffsets []int
+}
+
+type renderer struct {
+	ctx         *context.ProgramContext
+	highlighter *highlighter
+	threads     map[string][]thread
+	width       int
+	sideBySide  bool
+	out         rendered
+}
+
+func (r *renderer) render(files []File) rendered {
+	r.out = rendered{}
+	for i, f := range files {
+		if i > 0 {
+			r.add("")
+		}
+		r.out.fileOffsets = append(r.out.fileOffsets, len(r.out.lines))
+		r.renderFile(f)
+	}
+	return r.out
+}
+
+func (r *renderer) add(lines ...string) {
+	r.out.lines = append(r.out.lines, lines...)
+}
+
+func (r *renderer) renderFile(f File) {
+	theme := r.ctx.Theme
+	name := f.Path()
+	if f.Status() == "renamed" {
+		name = fmt.Sprintf("%s → %s", f.OldPath, f.NewPath)
+	}
+	header := lipgloss.JoinHorizontal(
+		lipgloss.Top,
+		lipgloss.NewStyle().Bold(true).Foreground(theme.PrimaryText).Render(name),
+		" ",
+		lipgloss.NewStyle().Foreground(theme.SuccessText).Render(fmt.Sprintf("+%d", f.Additions)),
+		" ",
+		lipgloss.NewStyle().Foreground(theme.ErrorText).Render(fmt.Sprintf("-%d", f.Deletions)),
+	)
+	if f.Status() == "added" || f.Status() == "deleted" {
+		header += lipgloss.NewStyle().Foreground(theme.FaintText).Render(" · " + f.Status())
+	}
+	r.add(ansi.Truncate(header, r.width, "…"))
+	r.add(lipgloss.NewStyle().Foreground(theme.FaintBorder).Render(strings.Repeat("─", r.width)))
+
+	faint := lipgloss.NewStyle().Foreground(theme.FaintText)
+	if f.IsBinary {
+		r.add(faint.Render("Binary file not shown"))
+		return
+	}
+	if len(f.Hunks) == 0 {
+		r.add(faint.Render("No content changes"))
+		return
+	}
+
+	numWidth := lineNumberWidth(f)
+	threads := r.threads[f.Path()]
+	for _, h := range f.Hunks {
+		r.out.hunkOffsets = append(r.out.hunkOffsets, len(r.out.lines))
+		r.add(faint.Render(ansi.Truncate(h.Header, r.width, "…")))
+		if r.sideBySide {
+			r.renderSideBySide(f, h, numWidth, threads)
+		} else {
+			r.renderUnified(f, h, numWidth, threads)
+		}
+	}
+}
+
+func (r *renderer) renderUnified(f File, h Hunk, numWidth int, threads []thread) {
+	for _, l := range h.Lines {
+		gutter := fmt.Sprintf("%s %s", formatLineNumber(l.OldLine, numWidth), formatLineNumber(l.NewLine, numWidth))
+		r.add(r.renderLine(f.Path(), l, gutter, r.width))
+		r.renderThreads(threadsAt(threads, l))
+	}
+}
+
+func (r *renderer) renderSideBySide(f File, h Hunk, numWidth int, threads []thread) {
+	colWidth := (r.width - 1) / 2
+	sep := lipgloss.NewStyle().Foreground(r.ctx.Theme.FaintBorder).Render("│")
+
+	var removed, added []Line
+	flush := func() {
+		for i := 0; i < max(len(removed), len(added)); i++ {
+			left := strings.Repeat(" ", colWidth)
+			right := left
+			var rowThreads []thread
+			if i < len(removed) {
+				left = r.renderLine(f.Path(), removed[i], formatLineNumber(removed[i].OldLine, numWidth), colWidth)
+				rowThreads = append(rowThreads, threadsAt(threads, removed[i])...)
+			}
+			if i < len(added) {
+				right = r.renderLine(f.Path(), added[i], formatLineNumber(added[i].NewLine, numWidth), colWidth)
+				rowThreads = append(rowThreads, threadsAt(threads, added[i])...)
+			}
+			r.add(left + sep + right)
+			r.renderThreads(rowThreads)
+		}
+		removed, added = nil, nil
+	}
+
+	for _, l := range h.Lines {
+		switch l.Kind {
+		case RemovedLine:
+			removed = append(removed, l)
+		case AddedLine:
+			added = append(added, l)
+		default:
+			flush()
+			left := r.renderLine(f.Path(), l, formatLineNumber(l.OldLine, numWidth), colWidth)
+			right := r.renderLine(f.Path(), l, formatLineNumber(l.NewLine, numWidth), colWidth)
+			r.add(left + sep + right)
+			r.renderThreads(threadsAt(threads, l))
+		}
+	}
+	flush()
+}
+
+// renderLine renders l with the line numbers in gutter, padded to width.
+func (r *renderer) renderLine(path string, l Line, gutter string, width int) string {
+	var bg lipgloss.TerminalColor = lipgloss.NoColor{}
+	sign := " "
+	signStyle := lipgloss.NewStyle()
+	switch l.Kind {
+	case AddedLine:
+		bg, sign = addedBackground, "+"
+		signStyle = signStyle.Foreground(r.ctx.Theme.SuccessText)
+	case RemovedLine:
+		bg, sign = removedBackground, "-"
+		signStyle = signStyle.Foreground(r.ctx.Theme.ErrorText)
+	}
+
+	prefix := lipgloss.NewStyle().Foreground(r.ctx.Theme.FaintText).Background(bg).Render(gutter+" ") +
+		signStyle.Background(bg).Render(sign+" ")
+	contentWidth := max(0, width-lipgloss.Width(gutter)-3)
+	content := ansi.Truncate(expandTabs(l.Content), contentWidth, "")
+	line := prefix + r.highlighter.highlight(path, content, bg)
+	if pad := width - lipgloss.Width(line); pad > 0 {
+		line += lipgloss.NewStyle().Background(bg).Render(strings.Repeat(" ", pad))
+	}
+	return line
+}
+
+func (r *renderer) renderThreads(threads []thread) {
+	if len(threads) == 0 {
+		return
+	}
+
+	theme := r.ctx.Theme
+	box := lipgloss.NewStyle().
+		Border(lipgloss.ThickBorder(), false, false, false, true).
+		BorderForeground(theme.WarningText).
+		PaddingLeft(1).
+		MarginLeft(2).
+		Width(max(10, r.width-4))
+	faint := lipgloss.NewStyle().Foreground(theme.FaintText)
+
+	for _, t := range threads {
+		borderColor := theme.WarningText
+		if t.isResolved {
+			borderColor = theme.FaintBorder
+		}
+
+		var lines []string
+		for i, c := range t.comments {
+			if i > 0 {
+				lines = append(lines, "")
+			}
+			header := lipgloss.NewStyle().Bold(true).Foreground(theme.PrimaryText).Render(c.Author.Login) +
+				faint.Render(" · "+utils.TimeElapsed(c.UpdatedAt))
+			if i == 0 && t.isResolved {
+				header += faint.Render(" · resolved")
+			}
+			lines = append(lines, header, lipgloss.NewStyle().Foreground(theme.SecondaryText).Render(strings.TrimSpace(c.Body)))
+		}
+		r.add(strings.Split(box.BorderForeground(borderColor).Render(strings.Join(lines, "\n")), "\n")...)
+	}
+}
+
+// threadsAt returns the threads attached to l.
+func threadsAt(threads []thread, l Line) []thread {
+	var res []thread
+	for _, t := range threads {
+		if (t.side == "LEFT" && l.Kind != AddedLine && t.line == l.OldLine) ||
+			(t.side != "LEFT" && l.Kind != RemovedLine && t.line == l.NewLine) {
+			res = append(res, t)
+		}
+	}
+	return res
+}
+
+func lineNumberWidth(f File) int {
+	maxLine := 0
+	for _, h := range f.Hunks {
+		for _, l := range h.Lines {
+			maxLine = max(maxLine, l.OldLine, l.NewLine)
+		}
+	}
+	return max(3, len(fmt.Sprint(maxLine)))
+}
+
+func formatLineNumber(n int, width int) string {
+	if n == 0 {
+		return strings.Repeat(" ", width)
+	}
+	return fmt.Sprintf("%*d", width, n)
+}
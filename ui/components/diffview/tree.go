@@ -0,0 +1,57 @@
+package diffview
+
+import (
+	"path"
+	"slices"
+	"strings"
+)
+
+type treeEntry struct {
+	name  string
+	depth int
+	// file is the index of the entry's file, or -1 for directories.
+	file int
+}
+
+// sortFiles sorts files by path so the files of a directory are next to each
+// other, listing a directory's files before its subdirectories.
+func sortFiles(files []File) {
+	slices.SortStableFunc(files, func(a, b File) int {
+		aDir, aName := path.Split(a.Path())
+		bDir, bName := path.Split(b.Path())
+		if aDir == bDir {
+			return strings.Compare(aName, bName)
+		}
+		if strings.HasPrefix(bDir, aDir) {
+			return -1
+		}
+		if strings.HasPrefix(aDir, bDir) {
+			return 1
+		}
+		return strings.Compare(aDir, bDir)
+	})
+}
+
+// buildTree returns the entries of the file tree of files, which are sorted
+// with sortFiles.
+func buildTree(files []File) []treeEntry {
+	var (
+		entries []treeEntry
+		prev    []string
+	)
+	for i, f := range files {
+		parts := strings.Split(f.Path(), "/")
+		dirs := parts[:len(parts)-1]
+
+		common := 0
+		for common < len(dirs) && common < len(prev) && dirs[common] == prev[common] {
+			common++
+		}
+		for d := common; d < len(dirs); d++ {
+			entries = append(entries, treeEntry{name: dirs[d] + "/", depth: d, file: -1})
+		}
+		entries = append(entries, treeEntry{name: parts[len(parts)-1], depth: len(dirs), file: i})
+		prev = dirs
+	}
+	return entries
+}
@@ -0,0 +1,158 @@
+package filelist
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/dlvhdr/gh-dash/v4/data"
+	"github.com/dlvhdr/gh-dash/v4/ui/context"
+	"github.com/dlvhdr/gh-dash/v4/utils"
+)
+
+const maxVisibleFiles = 10
+
+// Model lists the changed files of a PR along with whether the viewer marked
+// them as viewed.
+type Model struct {
+	ctx    *context.ProgramContext
+	files  data.PullRequestFiles
+	cursor int
+	help   help.Model
+	width  int
+}
+
+var listKeys = []key.Binding{
+	key.NewBinding(key.WithKeys("up", "down"), key.WithHelp("↑/↓", "navigate")),
+	key.NewBinding(key.WithKeys("enter", " "), key.WithHelp("enter/space", "toggle viewed")),
+	key.NewBinding(key.WithKeys(tea.KeyCtrlC.String(), tea.KeyEsc.String()), key.WithHelp("Ctrl+c/esc", "close")),
+}
+
+func NewModel(ctx *context.ProgramContext) Model {
+	m := Model{
+		ctx:  ctx,
+		help: help.New(),
+	}
+	m.UpdateProgramContext(ctx)
+	return m
+}
+
+// Open shows files with the cursor on the first one.
+func (m *Model) Open(files data.PullRequestFiles) {
+	m.cursor = 0
+	m.SetFiles(files)
+}
+
+// SetFiles updates the listed files, keeping the cursor where it is.
+func (m *Model) SetFiles(files data.PullRequestFiles) {
+	m.files = files
+	m.cursor = utils.Max(0, utils.Min(m.cursor, len(files.Nodes)-1))
+}
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "up", "k", "ctrl+p", "shift+tab":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case "down", "j", "ctrl+n", "tab":
+			if m.cursor < len(m.files.Nodes)-1 {
+				m.cursor++
+			}
+		case "g", "home":
+			m.cursor = 0
+		case "G", "end":
+			m.cursor = utils.Max(0, len(m.files.Nodes)-1)
+		}
+	}
+
+	return m, nil
+}
+
+// CurrFile returns the file under the cursor.
+func (m *Model) CurrFile() (data.PullRequestFile, bool) {
+	if m.cursor >= len(m.files.Nodes) {
+		return data.PullRequestFile{}, false
+	}
+	return m.files.Nodes[m.cursor], true
+}
+
+func (m *Model) SetWidth(width int) {
+	m.width = width
+}
+
+func (m *Model) UpdateProgramContext(ctx *context.ProgramContext) {
+	m.ctx = ctx
+	m.help.Styles = ctx.Styles.Help.BubbleStyles
+}
+
+func (m Model) View() string {
+	return lipgloss.NewStyle().
+		BorderTop(true).
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderForeground(m.ctx.Theme.SecondaryBorder).
+		MarginTop(1).
+		Render(
+			lipgloss.JoinVertical(
+				lipgloss.Left,
+				fmt.Sprintf("Files (%d of %d viewed)\n", m.files.ViewedCount(), m.files.TotalCount),
+				m.renderFiles(),
+				lipgloss.NewStyle().
+					MarginTop(1).
+					Render(m.help.ShortHelpView(listKeys)),
+			),
+		)
+}
+
+func (m *Model) renderFiles() string {
+	faint := lipgloss.NewStyle().Foreground(m.ctx.Theme.FaintText)
+	if len(m.files.Nodes) == 0 {
+		if m.files.TotalCount > 0 {
+			return faint.Render("Loading files...")
+		}
+		return faint.Render("No changed files")
+	}
+
+	start := 0
+	if m.cursor >= maxVisibleFiles {
+		start = m.cursor - maxVisibleFiles + 1
+	}
+	end := utils.Min(start+maxVisibleFiles, len(m.files.Nodes))
+
+	rows := make([]string, 0, end-start)
+	for i := start; i < end; i++ {
+		file := m.files.Nodes[i]
+
+		textStyle := lipgloss.NewStyle().Foreground(m.ctx.Theme.SecondaryText)
+		if i == m.cursor {
+			textStyle = textStyle.Foreground(m.ctx.Theme.PrimaryText).Bold(true)
+		}
+
+		box := "[ ]"
+		switch file.ViewerViewedState {
+		case "VIEWED":
+			box = "[x]"
+		case "DISMISSED":
+			box = "[~]"
+		}
+		changes := lipgloss.NewStyle().Foreground(m.ctx.Theme.SuccessText).Render(fmt.Sprintf(" +%d", file.Additions)) +
+			lipgloss.NewStyle().Foreground(m.ctx.Theme.ErrorText).Render(fmt.Sprintf(" -%d", file.Deletions))
+		pathWidth := utils.Max(0, m.width-lipgloss.Width(box)-1-lipgloss.Width(changes))
+		rows = append(rows, textStyle.Render(box+" "+ansi.Truncate(file.Path, pathWidth, "…"))+changes)
+	}
+
+	if len(m.files.Nodes) > end || start > 0 {
+		rows = append(rows, faint.Render(fmt.Sprintf("%d/%d", m.cursor+1, len(m.files.Nodes))))
+	}
+	if m.files.TotalCount > len(m.files.Nodes) {
+		rows = append(rows, faint.Render(fmt.Sprintf("Showing the first %d of %d files", len(m.files.Nodes), m.files.TotalCount)))
+	}
+
+	return strings.Join(rows, "\n")
+}
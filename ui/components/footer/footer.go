@@ -117,27 +117,65 @@ func (m *Model) UpdateProgramContext(ctx *context.ProgramContext) {
 }
 
 func (m *Model) renderViewSwitcher(ctx context.ProgramContext) string {
-	var view string
-	if ctx.View == config.PRsView {
-		view += " PRs"
-	} else if ctx.View == config.IssuesView {
-		view += " Issues"
-	} else if ctx.View == config.RepoView {
-		repo := m.ctx.RepoPath
-		if m.ctx.RepoUrl != nil {
-			shortName := git.GetRepoShortName(*m.ctx.RepoUrl)
-			repo = &shortName
-		}
-		view += fmt.Sprintf(" %s", *repo)
-	}
-
 	var user string
 	if ctx.User != "" {
 		user = ctx.Styles.Tabs.ViewSwitcher.Background(ctx.Theme.FaintText).Render("@" + ctx.User)
 	}
 
-	return lipgloss.JoinHorizontal(lipgloss.Top, ctx.Styles.Tabs.ViewSwitcher.
-		Render(view), user)
+	return lipgloss.JoinHorizontal(lipgloss.Top, append(m.renderViewSegments(ctx), user)...)
+}
+
+// ViewAt returns the view whose segment of the view switcher is at column x.
+func (m *Model) ViewAt(x int) (config.ViewType, bool) {
+	start := 0
+	views := m.getViews()
+	for i, segment := range m.renderViewSegments(*m.ctx) {
+		end := start + lipgloss.Width(segment)
+		if x >= start && x < end {
+			return views[i], true
+		}
+		start = end
+	}
+	return "", false
+}
+
+func (m *Model) renderViewSegments(ctx context.ProgramContext) []string {
+	views := m.getViews()
+	segments := make([]string, 0, len(views))
+	for _, view := range views {
+		label := m.getViewLabel(view)
+		if view == ctx.View {
+			segments = append(segments, ctx.Styles.Tabs.ViewSwitcher.Render(label))
+		} else {
+			segments = append(segments, ctx.Styles.Tabs.InactiveView.Padding(0, 1).Render(label))
+		}
+	}
+	return segments
+}
+
+// getViews returns the views that can be switched to. The repo view is only
+// available behind its feature flag when running in a repository.
+func (m *Model) getViews() []config.ViewType {
+	views := []config.ViewType{config.PRsView, config.IssuesView}
+	if config.IsFeatureEnabled(config.FF_REPO_VIEW) && m.ctx.RepoPath != nil {
+		views = append(views, config.RepoView)
+	}
+	return views
+}
+
+func (m *Model) getViewLabel(view config.ViewType) string {
+	switch view {
+	case config.PRsView:
+		return " PRs"
+	case config.IssuesView:
+		return " Issues"
+	default:
+		repo := *m.ctx.RepoPath
+		if m.ctx.RepoUrl != nil {
+			repo = git.GetRepoShortName(*m.ctx.RepoUrl)
+		}
+		return fmt.Sprintf(" %s", repo)
+	}
 }
 
 func (m *Model) SetLeftSection(leftSection string) {
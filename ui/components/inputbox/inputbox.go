@@ -2,6 +2,8 @@ package inputbox
 
 import (
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/key"
@@ -9,14 +11,29 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
+	"github.com/dlvhdr/gh-dash/v4/ui/common"
 	"github.com/dlvhdr/gh-dash/v4/ui/context"
+	"github.com/dlvhdr/gh-dash/v4/utils"
 )
 
+const maxVisibleSuggestions = 5
+
+// Suggestion is a completion for the whitespace-separated word being typed.
+// Suggestions marked as Suggested are listed before anything is typed.
+type Suggestion struct {
+	Value     string
+	Detail    string
+	Suggested bool
+}
+
 type Model struct {
-	ctx       *context.ProgramContext
-	textArea  textarea.Model
-	inputHelp help.Model
-	prompt    string
+	ctx                *context.ProgramContext
+	textArea           textarea.Model
+	inputHelp          help.Model
+	prompt             string
+	suggestions        []Suggestion
+	matches            []utils.FuzzyMatch
+	selectedSuggestion int
 }
 
 var inputKeys = []key.Binding{
@@ -24,6 +41,11 @@ var inputKeys = []key.Binding{
 	key.NewBinding(key.WithKeys(tea.KeyCtrlC.String(), tea.KeyEsc.String()), key.WithHelp("Ctrl+c/esc", "cancel")),
 }
 
+var suggestionKeys = []key.Binding{
+	key.NewBinding(key.WithKeys(tea.KeyTab.String()), key.WithHelp("tab", "complete")),
+	key.NewBinding(key.WithKeys(tea.KeyCtrlN.String(), tea.KeyCtrlP.String()), key.WithHelp("Ctrl+n/p", "next/prev suggestion")),
+}
+
 func NewModel(ctx *context.ProgramContext) Model {
 	ta := textarea.New()
 	ta.ShowLineNumbers = true
@@ -51,10 +73,116 @@ func NewModel(ctx *context.ProgramContext) Model {
 
 func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	var cmd tea.Cmd
+
+	if msg, ok := msg.(tea.KeyMsg); ok && len(m.matches) > 0 {
+		switch msg.Type {
+		case tea.KeyTab:
+			m.acceptSuggestion()
+			return m, nil
+		case tea.KeyCtrlN:
+			m.selectedSuggestion = (m.selectedSuggestion + 1) % m.numVisibleMatches()
+			return m, nil
+		case tea.KeyCtrlP:
+			n := m.numVisibleMatches()
+			m.selectedSuggestion = (m.selectedSuggestion - 1 + n) % n
+			return m, nil
+		}
+	}
+
 	m.textArea, cmd = m.textArea.Update(msg)
+	if _, ok := msg.(tea.KeyMsg); ok && m.suggestions != nil {
+		m.updateMatches()
+	}
 	return m, cmd
 }
 
+// SetSuggestions sets the values offered as completions for the word being
+// typed. Suggestions are cleared by Reset.
+func (m *Model) SetSuggestions(suggestions []Suggestion) {
+	m.suggestions = suggestions
+	m.updateMatches()
+}
+
+func (m *Model) currentWord() string {
+	value := m.textArea.Value()
+	if value == "" || strings.TrimRight(value, " \t\n") != value {
+		return ""
+	}
+	words := strings.Fields(value)
+	return words[len(words)-1]
+}
+
+func (m *Model) updateMatches() {
+	m.matches = nil
+	m.selectedSuggestion = 0
+	if len(m.suggestions) == 0 {
+		return
+	}
+
+	word := m.currentWord()
+	words := strings.Fields(m.textArea.Value())
+	if word != "" {
+		words = words[:len(words)-1]
+	}
+
+	values := make([]string, 0, len(m.suggestions))
+	for _, s := range m.suggestions {
+		values = append(values, s.Value)
+	}
+
+	for _, match := range utils.FuzzyFind(word, values) {
+		s := m.suggestions[match.Index]
+		if slices.Contains(words, s.Value) || s.Value == word {
+			continue
+		}
+		if word == "" && !s.Suggested {
+			continue
+		}
+		m.matches = append(m.matches, match)
+	}
+}
+
+func (m *Model) numVisibleMatches() int {
+	return utils.Min(len(m.matches), maxVisibleSuggestions)
+}
+
+func (m *Model) acceptSuggestion() {
+	value := m.textArea.Value()
+	value = strings.TrimSuffix(value, m.currentWord())
+	value += m.suggestions[m.matches[m.selectedSuggestion].Index].Value + " "
+	m.textArea.SetValue(value)
+	m.updateMatches()
+}
+
+func (m *Model) renderSuggestions() string {
+	if len(m.matches) == 0 {
+		return ""
+	}
+
+	rows := make([]string, 0, m.numVisibleMatches())
+	for i, match := range m.matches[:m.numVisibleMatches()] {
+		s := m.suggestions[match.Index]
+		style := lipgloss.NewStyle().Foreground(m.ctx.Theme.SecondaryText)
+		if i == m.selectedSuggestion {
+			style = style.Foreground(m.ctx.Theme.PrimaryText).Bold(true)
+		}
+		matchStyle := style.Underline(true)
+		detail := s.Detail
+		if s.Suggested {
+			detail = strings.TrimSpace(detail + " (suggested)")
+		}
+
+		rows = append(rows, lipgloss.JoinHorizontal(
+			lipgloss.Top,
+			style.Render("  "),
+			common.RenderFuzzyMatch(s.Value, match.MatchedIndexes, style, matchStyle),
+			lipgloss.NewStyle().Foreground(m.ctx.Theme.FaintText).Render(" "+detail),
+		))
+	}
+
+	return lipgloss.NewStyle().MarginTop(1).Render(lipgloss.JoinVertical(lipgloss.Left, rows...))
+}
+
 func (m Model) View() string {
 	return lipgloss.NewStyle().
 		BorderTop(true).
@@ -66,9 +194,10 @@ func (m Model) View() string {
 				lipgloss.Left,
 				fmt.Sprintf("%s\n", m.prompt),
 				m.textArea.View(),
+				m.renderSuggestions(),
 				lipgloss.NewStyle().
 					MarginTop(1).
-					Render(m.inputHelp.ShortHelpView(inputKeys)),
+					Render(m.inputHelp.ShortHelpView(m.helpKeys())),
 			),
 		)
 }
@@ -77,8 +206,18 @@ func (m *Model) Value() string {
 	return m.textArea.Value()
 }
 
+func (m *Model) helpKeys() []key.Binding {
+	if len(m.suggestions) == 0 {
+		return inputKeys
+	}
+	return append(slices.Clone(suggestionKeys), inputKeys...)
+}
+
 func (m *Model) SetValue(s string) {
 	m.textArea.SetValue(s)
+	if m.suggestions != nil {
+		m.updateMatches()
+	}
 }
 
 func (m *Model) Blur() {
@@ -103,6 +242,9 @@ func (m *Model) SetPrompt(prompt string) {
 
 func (m *Model) Reset() {
 	m.textArea.Reset()
+	m.suggestions = nil
+	m.matches = nil
+	m.selectedSuggestion = 0
 }
 
 func (m *Model) UpdateProgramContext(ctx *context.ProgramContext) {
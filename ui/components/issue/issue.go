@@ -23,6 +23,7 @@ func (issue *Issue) ToTableRow() table.Row {
 		issue.renderStatus(),
 		issue.renderRepoName(),
 		issue.renderTitle(),
+		issue.renderNote(),
 		issue.renderOpenedBy(),
 		issue.renderAssignees(),
 		issue.renderNumComments(),
@@ -54,7 +55,12 @@ func (issue *Issue) renderRepoName() string {
 }
 
 func (issue *Issue) renderTitle() string {
-	return components.RenderIssueTitle(issue.Ctx, issue.Data.State, issue.Data.Title, issue.Data.Number)
+	return components.RenderNoteTags(issue.Ctx, issue.Data.Url, lipgloss.NewStyle()) +
+		components.RenderIssueTitle(issue.Ctx, issue.Data.State, issue.Data.Title, issue.Data.Number)
+}
+
+func (issue *Issue) renderNote() string {
+	return components.RenderNoteIndicator(issue.Ctx, issue.Data.Url)
 }
 
 func (issue *Issue) renderOpenedBy() string {
@@ -0,0 +1,428 @@
+package issuecreator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/dlvhdr/gh-dash/v4/data"
+	"github.com/dlvhdr/gh-dash/v4/ui/common"
+	"github.com/dlvhdr/gh-dash/v4/ui/components/tasks"
+	"github.com/dlvhdr/gh-dash/v4/ui/context"
+)
+
+type step int
+
+const (
+	repoStep step = iota
+	templateStep
+	formStep
+)
+
+type field int
+
+const (
+	titleField field = iota
+	bodyField
+	labelsField
+	assigneesField
+	numFields
+)
+
+type Model struct {
+	ctx            *context.ProgramContext
+	isOpen         bool
+	step           step
+	repoInput      textinput.Model
+	repo           string
+	templates      []data.IssueTemplate
+	templateCursor int
+	err            error
+	focused        field
+	titleInput     textinput.Model
+	bodyInput      textarea.Model
+	labelsInput    textinput.Model
+	assigneesInput textinput.Model
+	help           help.Model
+	width          int
+}
+
+type TemplatesFetchedMsg struct {
+	Repo      string
+	Templates []data.IssueTemplate
+	Err       error
+}
+
+type bodyEditedMsg struct {
+	body string
+	err  error
+}
+
+var (
+	repoKeys = []key.Binding{
+		key.NewBinding(key.WithKeys("tab"), key.WithHelp("tab", "complete")),
+		key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "next")),
+		key.NewBinding(key.WithKeys(tea.KeyCtrlC.String(), tea.KeyEsc.String()), key.WithHelp("Ctrl+c/esc", "cancel")),
+	}
+	templateKeys = []key.Binding{
+		key.NewBinding(key.WithKeys("up", "down"), key.WithHelp("↑/↓", "navigate")),
+		key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "select")),
+		key.NewBinding(key.WithKeys(tea.KeyCtrlC.String(), tea.KeyEsc.String()), key.WithHelp("Ctrl+c/esc", "cancel")),
+	}
+	formKeys = []key.Binding{
+		key.NewBinding(key.WithKeys("tab", "shift+tab"), key.WithHelp("tab/shift+tab", "next/prev field")),
+		key.NewBinding(key.WithKeys(tea.KeyCtrlE.String()), key.WithHelp("Ctrl+e", "edit body in $EDITOR")),
+		key.NewBinding(key.WithKeys(tea.KeyCtrlD.String()), key.WithHelp("Ctrl+d", "create")),
+		key.NewBinding(key.WithKeys(tea.KeyCtrlC.String(), tea.KeyEsc.String()), key.WithHelp("Ctrl+c/esc", "cancel")),
+	}
+)
+
+func NewModel(ctx *context.ProgramContext) Model {
+	repoInput := textinput.New()
+	repoInput.Prompt = ""
+	repoInput.Placeholder = "owner/repo"
+	repoInput.ShowSuggestions = true
+
+	titleInput := textinput.New()
+	titleInput.Prompt = ""
+	titleInput.Placeholder = "Title"
+
+	bodyInput := textarea.New()
+	bodyInput.ShowLineNumbers = false
+	bodyInput.Prompt = ""
+	bodyInput.Placeholder = "Description (Ctrl+e to open in your editor)"
+	bodyInput.SetHeight(common.InputBoxHeight)
+
+	labelsInput := textinput.New()
+	labelsInput.Prompt = ""
+	labelsInput.Placeholder = "bug, enhancement"
+
+	assigneesInput := textinput.New()
+	assigneesInput.Prompt = ""
+	assigneesInput.Placeholder = "@me"
+
+	m := Model{
+		ctx:            ctx,
+		repoInput:      repoInput,
+		titleInput:     titleInput,
+		bodyInput:      bodyInput,
+		labelsInput:    labelsInput,
+		assigneesInput: assigneesInput,
+		help:           help.New(),
+	}
+	m.UpdateProgramContext(ctx)
+	return m
+}
+
+// Open starts the new issue flow. The repository input is prefilled with
+// defaultRepo and completes the given repositories.
+func (m *Model) Open(defaultRepo string, repos []string) tea.Cmd {
+	m.isOpen = true
+	m.step = repoStep
+	m.repo = ""
+	m.templates = nil
+	m.templateCursor = 0
+	m.err = nil
+	m.repoInput.SetSuggestions(repos)
+	m.repoInput.SetValue(defaultRepo)
+	m.repoInput.CursorEnd()
+	return m.repoInput.Focus()
+}
+
+func (m *Model) Close() {
+	m.isOpen = false
+	m.repoInput.Blur()
+	m.blurFields()
+}
+
+func (m *Model) IsOpen() bool {
+	return m.isOpen
+}
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case TemplatesFetchedMsg:
+		if msg.Repo != m.repo || m.step != templateStep {
+			return m, nil
+		}
+		if msg.Err != nil {
+			// A blank issue can still be created without the templates.
+			m.err = fmt.Errorf("failed fetching issue templates: %w", msg.Err)
+			m.templates = []data.IssueTemplate{}
+			return m, nil
+		}
+		m.templates = msg.Templates
+		if len(m.templates) == 0 {
+			return m, m.selectTemplate(nil)
+		}
+		return m, nil
+
+	case bodyEditedMsg:
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		m.bodyInput.SetValue(msg.body)
+		return m, nil
+
+	case tea.KeyMsg:
+		if msg.Type == tea.KeyEsc || msg.Type == tea.KeyCtrlC {
+			m.Close()
+			return m, nil
+		}
+
+		switch m.step {
+		case repoStep:
+			if msg.Type == tea.KeyEnter {
+				repo := strings.TrimSpace(m.repoInput.Value())
+				if strings.Count(repo, "/") != 1 {
+					m.err = fmt.Errorf("invalid repository %q, expected owner/repo", repo)
+					return m, nil
+				}
+				m.err = nil
+				m.repo = repo
+				m.step = templateStep
+				m.repoInput.Blur()
+				return m, func() tea.Msg {
+					templates, err := data.FetchIssueTemplates(repo)
+					return TemplatesFetchedMsg{Repo: repo, Templates: templates, Err: err}
+				}
+			}
+			m.repoInput, cmd = m.repoInput.Update(msg)
+
+		case templateStep:
+			if m.templates == nil {
+				return m, nil
+			}
+			switch msg.String() {
+			case "up", "k", "ctrl+p":
+				if m.templateCursor > 0 {
+					m.templateCursor--
+				}
+			case "down", "j", "ctrl+n":
+				if m.templateCursor < len(m.templates) {
+					m.templateCursor++
+				}
+			case "enter":
+				if m.templateCursor == len(m.templates) {
+					cmd = m.selectTemplate(nil)
+				} else {
+					cmd = m.selectTemplate(&m.templates[m.templateCursor])
+				}
+			}
+
+		case formStep:
+			switch msg.Type {
+			case tea.KeyTab:
+				return m, m.focusField((m.focused + 1) % numFields)
+			case tea.KeyShiftTab:
+				return m, m.focusField((m.focused + numFields - 1) % numFields)
+			case tea.KeyCtrlE:
+				return m, common.EditInEditor(m.bodyInput.Value(), "*.md", func(body string, err error) tea.Msg {
+					return bodyEditedMsg{body: body, err: err}
+				})
+			case tea.KeyCtrlD:
+				if strings.TrimSpace(m.titleInput.Value()) == "" {
+					m.err = fmt.Errorf("the issue title can't be empty")
+					return m, m.focusField(titleField)
+				}
+				return m, m.submit()
+			}
+
+			switch m.focused {
+			case titleField:
+				m.titleInput, cmd = m.titleInput.Update(msg)
+			case bodyField:
+				m.bodyInput, cmd = m.bodyInput.Update(msg)
+			case labelsField:
+				m.labelsInput, cmd = m.labelsInput.Update(msg)
+			case assigneesField:
+				m.assigneesInput, cmd = m.assigneesInput.Update(msg)
+			}
+		}
+	}
+
+	return m, cmd
+}
+
+func (m *Model) selectTemplate(template *data.IssueTemplate) tea.Cmd {
+	m.step = formStep
+	m.err = nil
+	m.titleInput.Reset()
+	m.bodyInput.Reset()
+	m.labelsInput.Reset()
+	m.assigneesInput.Reset()
+	if template != nil {
+		m.titleInput.SetValue(template.Title)
+		m.bodyInput.SetValue(template.Body)
+		m.labelsInput.SetValue(strings.Join(template.Labels, ", "))
+		m.assigneesInput.SetValue(strings.Join(template.Assignees, ", "))
+	}
+	m.titleInput.CursorEnd()
+	return m.focusField(titleField)
+}
+
+func (m *Model) blurFields() {
+	m.titleInput.Blur()
+	m.bodyInput.Blur()
+	m.labelsInput.Blur()
+	m.assigneesInput.Blur()
+}
+
+func (m *Model) focusField(f field) tea.Cmd {
+	m.focused = f
+	m.blurFields()
+	switch f {
+	case titleField:
+		return m.titleInput.Focus()
+	case bodyField:
+		return m.bodyInput.Focus()
+	case labelsField:
+		return m.labelsInput.Focus()
+	case assigneesField:
+		return m.assigneesInput.Focus()
+	}
+	return nil
+}
+
+func (m *Model) submit() tea.Cmd {
+	issue := tasks.NewIssue{
+		Repo:      m.repo,
+		Title:     strings.TrimSpace(m.titleInput.Value()),
+		Body:      m.bodyInput.Value(),
+		Labels:    splitList(m.labelsInput.Value()),
+		Assignees: splitList(m.assigneesInput.Value()),
+	}
+	m.Close()
+	return tasks.CreateIssue(m.ctx, tasks.SectionIdentifer{}, issue)
+}
+
+func splitList(s string) []string {
+	return strings.FieldsFunc(s, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\n' || r == '\t'
+	})
+}
+
+func (m *Model) SetWidth(width int) {
+	m.width = width
+	m.repoInput.Width = width - 1
+	m.titleInput.Width = width - 1
+	m.bodyInput.SetWidth(width)
+	m.labelsInput.Width = width - 1
+	m.assigneesInput.Width = width - 1
+}
+
+func (m *Model) UpdateProgramContext(ctx *context.ProgramContext) {
+	m.ctx = ctx
+	m.help.Styles = ctx.Styles.Help.BubbleStyles
+	placeholder := lipgloss.NewStyle().Foreground(ctx.Theme.FaintText)
+	for _, ti := range []*textinput.Model{&m.repoInput, &m.titleInput, &m.labelsInput, &m.assigneesInput} {
+		ti.PlaceholderStyle = placeholder
+		ti.CompletionStyle = placeholder
+		ti.TextStyle = lipgloss.NewStyle().Foreground(ctx.Theme.PrimaryText)
+	}
+	m.bodyInput.FocusedStyle.Base = lipgloss.NewStyle()
+	m.bodyInput.FocusedStyle.CursorLine = lipgloss.NewStyle()
+	m.bodyInput.FocusedStyle.Placeholder = placeholder
+	m.bodyInput.FocusedStyle.Text = lipgloss.NewStyle().Foreground(ctx.Theme.PrimaryText)
+	m.bodyInput.BlurredStyle = m.bodyInput.FocusedStyle
+	m.bodyInput.BlurredStyle.Text = lipgloss.NewStyle().Foreground(ctx.Theme.SecondaryText)
+}
+
+func (m Model) View() string {
+	var content string
+	var keys []key.Binding
+	switch m.step {
+	case repoStep:
+		content = m.renderField("Repository", m.repoInput.View(), true)
+		keys = repoKeys
+	case templateStep:
+		content = m.renderTemplates()
+		keys = templateKeys
+	case formStep:
+		content = lipgloss.JoinVertical(
+			lipgloss.Left,
+			m.renderField("Title", m.titleInput.View(), m.focused == titleField),
+			m.renderField("Body", m.bodyInput.View(), m.focused == bodyField),
+			m.renderField("Labels", m.labelsInput.View(), m.focused == labelsField),
+			m.renderField("Assignees", m.assigneesInput.View(), m.focused == assigneesField),
+		)
+		keys = formKeys
+	}
+
+	if m.err != nil {
+		content = lipgloss.JoinVertical(
+			lipgloss.Left,
+			content,
+			lipgloss.NewStyle().Foreground(m.ctx.Theme.ErrorText).Render(m.err.Error()),
+		)
+	}
+
+	title := "New issue"
+	if m.repo != "" {
+		title = fmt.Sprintf("New issue in %s", m.repo)
+	}
+
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		m.ctx.Styles.Common.MainTextStyle.Render(title),
+		"",
+		content,
+		lipgloss.NewStyle().
+			MarginTop(1).
+			Render(m.help.ShortHelpView(keys)),
+	)
+}
+
+func (m *Model) renderField(label string, value string, isFocused bool) string {
+	labelStyle := lipgloss.NewStyle().Foreground(m.ctx.Theme.SecondaryText)
+	if isFocused {
+		labelStyle = labelStyle.Foreground(m.ctx.Theme.PrimaryText).Bold(true)
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, labelStyle.Render(label), value, "")
+}
+
+func (m *Model) renderTemplates() string {
+	if m.templates == nil {
+		if m.err != nil {
+			return ""
+		}
+		return lipgloss.NewStyle().Foreground(m.ctx.Theme.FaintText).Render("Fetching issue templates...")
+	}
+
+	rows := []string{lipgloss.NewStyle().Foreground(m.ctx.Theme.SecondaryText).Render("Choose a template"), ""}
+	for i := 0; i <= len(m.templates); i++ {
+		name, about := "Blank issue", "Start from scratch"
+		if i < len(m.templates) {
+			name, about = m.templates[i].Name, m.templates[i].About
+			if m.templates[i].IsForm {
+				name += " (form)"
+			}
+		}
+
+		style := lipgloss.NewStyle().Foreground(m.ctx.Theme.SecondaryText)
+		prefix := "  "
+		if i == m.templateCursor {
+			style = style.Foreground(m.ctx.Theme.PrimaryText).Bold(true)
+			prefix = "> "
+		}
+		rows = append(rows, style.Render(prefix+name))
+		if about != "" {
+			rows = append(rows, lipgloss.NewStyle().
+				Foreground(m.ctx.Theme.FaintText).
+				PaddingLeft(2).
+				Width(m.width).
+				Render(about))
+		}
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, rows...)
+}
@@ -2,57 +2,28 @@ package issuesidebar
 
 import (
 	"fmt"
-	"os/exec"
 
 	tea "github.com/charmbracelet/bubbletea"
+
 	"github.com/dlvhdr/gh-dash/v4/data"
 	"github.com/dlvhdr/gh-dash/v4/ui/components/issuessection"
-	"github.com/dlvhdr/gh-dash/v4/ui/constants"
+	"github.com/dlvhdr/gh-dash/v4/ui/components/tasks"
 	"github.com/dlvhdr/gh-dash/v4/ui/context"
 )
 
 func (m *Model) assign(usernames []string) tea.Cmd {
 	issue := m.issue.Data
-	issueNumber := issue.GetNumber()
-	taskId := fmt.Sprintf("issue_assign_%d", issueNumber)
+	issueNumber, issueId := issue.GetNumber(), issue.Id
 	task := context.Task{
-		Id:           taskId,
+		Id:           fmt.Sprintf("issue_assign_%d", issueNumber),
 		StartText:    fmt.Sprintf("Assigning issue #%d to %s", issueNumber, usernames),
 		FinishedText: fmt.Sprintf("Issue #%d has been assigned to %s", issueNumber, usernames),
 		State:        context.TaskStart,
 		Error:        nil,
 	}
 
-	commandArgs := []string{
-		"issue",
-		"edit",
-		fmt.Sprint(issueNumber),
-		"-R",
-		issue.GetRepoNameWithOwner(),
-	}
-	for _, assignee := range usernames {
-		commandArgs = append(commandArgs, "--add-assignee")
-		commandArgs = append(commandArgs, assignee)
-	}
-
-	startCmd := m.ctx.StartTask(task)
-	return tea.Batch(startCmd, func() tea.Msg {
-		c := exec.Command("gh", commandArgs...)
-
-		err := c.Run()
-		returnedAssignees := data.Assignees{Nodes: []data.Assignee{}}
-		for _, assignee := range usernames {
-			returnedAssignees.Nodes = append(returnedAssignees.Nodes, data.Assignee{Login: assignee})
-		}
-		return constants.TaskFinishedMsg{
-			SectionId:   m.sectionId,
-			SectionType: issuessection.SectionType,
-			TaskId:      taskId,
-			Err:         err,
-			Msg: issuessection.UpdateIssueMsg{
-				IssueNumber:    issueNumber,
-				AddedAssignees: &returnedAssignees,
-			},
-		}
+	section := tasks.SectionIdentifer{Id: m.sectionId, Type: issuessection.SectionType}
+	return tasks.Mutate(m.ctx, section, task, issue, "addAssigneesToAssignable", func() (tea.Msg, error) {
+		return tasks.UpdatedIssueMsg(data.UpdateIssueAssignees(issueId, usernames, nil))
 	})
 }
@@ -8,11 +8,18 @@ import (
 	"github.com/charmbracelet/bubbles/textarea"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/log"
 
 	"github.com/dlvhdr/gh-dash/v4/data"
 	"github.com/dlvhdr/gh-dash/v4/ui/common"
 	"github.com/dlvhdr/gh-dash/v4/ui/components/inputbox"
 	"github.com/dlvhdr/gh-dash/v4/ui/components/issue"
+	"github.com/dlvhdr/gh-dash/v4/ui/components/labelpicker"
+	"github.com/dlvhdr/gh-dash/v4/ui/components/linked"
+	"github.com/dlvhdr/gh-dash/v4/ui/components/localnote"
+	"github.com/dlvhdr/gh-dash/v4/ui/components/tasklist"
+	"github.com/dlvhdr/gh-dash/v4/ui/components/timeline"
+	"github.com/dlvhdr/gh-dash/v4/ui/components/usercompletion"
 	"github.com/dlvhdr/gh-dash/v4/ui/context"
 	"github.com/dlvhdr/gh-dash/v4/ui/markdown"
 )
@@ -26,8 +33,15 @@ type Model struct {
 	isCommenting  bool
 	isAssigning   bool
 	isUnassigning bool
+	isLabeling    bool
 
-	inputBox inputbox.Model
+	isEditingTasks bool
+
+	inputBox    inputbox.Model
+	labelPicker labelpicker.Model
+	taskList    tasklist.Model
+	timeline    timeline.Model
+	timelines   map[string]*issueTimeline
 }
 
 func NewModel(ctx context.ProgramContext) Model {
@@ -40,8 +54,13 @@ func NewModel(ctx context.ProgramContext) Model {
 		isCommenting:  false,
 		isAssigning:   false,
 		isUnassigning: false,
+		isLabeling:    false,
 
-		inputBox: inputBox,
+		inputBox:    inputBox,
+		labelPicker: labelpicker.NewModel(&ctx),
+		taskList:    tasklist.NewModel(&ctx),
+		timeline:    timeline.NewModel(&ctx),
+		timelines:   map[string]*issueTimeline{},
 	}
 }
 
@@ -53,8 +72,62 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	)
 
 	switch msg := msg.(type) {
+	case TimelineFetchedMsg:
+		m.onTimelineFetched(msg)
+		return m, nil
+
+	case labelpicker.LabelsFetchedMsg:
+		m.labelPicker, cmd = m.labelPicker.Update(msg)
+		return m, cmd
+
+	case usercompletion.FetchedMsg:
+		if m.issue == nil || msg.Url != m.issue.Data.GetUrl() {
+			return m, nil
+		}
+		if msg.Err != nil {
+			log.Error("Failed fetching user completions", "url", msg.Url, "err", msg.Err)
+			return m, nil
+		}
+		if m.isAssigning || m.isUnassigning {
+			m.inputBox.SetSuggestions(msg.Suggestions)
+		}
+		return m, nil
+
 	case tea.KeyMsg:
-		if m.isCommenting {
+		if m.isEditingTasks {
+			switch msg.Type {
+
+			case tea.KeyCtrlD:
+				cmd = m.saveTasks()
+				m.isEditingTasks = false
+				return m, cmd
+
+			case tea.KeyEsc, tea.KeyCtrlC:
+				m.isEditingTasks = false
+				return m, nil
+			}
+
+			m.taskList, cmd = m.taskList.Update(msg)
+			cmds = append(cmds, cmd)
+		} else if m.isLabeling {
+			switch msg.Type {
+
+			case tea.KeyCtrlD:
+				if !m.labelPicker.IsReady() {
+					return m, nil
+				}
+				cmd = m.editLabels()
+				m.isLabeling = false
+				return m, cmd
+
+			case tea.KeyEsc, tea.KeyCtrlC:
+				m.isLabeling = false
+				return m, nil
+			}
+
+			m.labelPicker, cmd = m.labelPicker.Update(msg)
+			cmds = append(cmds, cmd)
+		} else if m.isCommenting {
 			switch msg.Type {
 
 			case tea.KeyCtrlD:
@@ -138,14 +211,34 @@ func (m Model) View() string {
 		s.WriteString("\n\n")
 	}
 
+	note := localnote.View(m.ctx, m.getIndentedContentWidth(), m.issue.Data.Url)
+	if note != "" {
+		s.WriteString(note)
+		s.WriteString("\n\n")
+	}
+
+	closingPrs := linked.View(m.ctx, m.getIndentedContentWidth(), " Closed by", linked.Prs, m.issue.Data.ClosingPrs)
+	if closingPrs != "" {
+		s.WriteString(closingPrs)
+		s.WriteString("\n\n")
+	}
+
 	s.WriteString(m.renderBody())
 	s.WriteString("\n\n")
-	s.WriteString(m.renderActivity())
+	s.WriteString(m.renderTimeline())
 
 	if m.isCommenting || m.isAssigning || m.isUnassigning {
 		s.WriteString(m.inputBox.View())
 	}
 
+	if m.isLabeling {
+		s.WriteString(m.labelPicker.View())
+	}
+
+	if m.isEditingTasks {
+		s.WriteString(m.taskList.View())
+	}
+
 	return s.String()
 }
 
@@ -180,7 +273,11 @@ func (m *Model) renderStatusPill() string {
 func (m *Model) renderBody() string {
 	width := m.getIndentedContentWidth()
 	regex := regexp.MustCompile("(?U)<!--(.|[[:space:]])*-->")
-	body := regex.ReplaceAllString(m.issue.Data.Body, "")
+	body := m.issue.Data.Body
+	if m.isEditingTasks {
+		body = m.taskList.HighlightedBody()
+	}
+	body = regex.ReplaceAllString(body, "")
 
 	regex = regexp.MustCompile(`((\n)+|^)([^\r\n]*\|[^\r\n]*(\n)?)+`)
 	body = regex.ReplaceAllString(body, "")
@@ -218,22 +315,28 @@ func (m *Model) getIndentedContentWidth() int {
 func (m *Model) SetWidth(width int) {
 	m.width = width
 	m.inputBox.SetWidth(width)
+	m.labelPicker.SetWidth(width)
+	m.timeline.SetWidth(m.getIndentedContentWidth() - 2)
 }
 
 func (m *Model) SetSectionId(id int) {
 	m.sectionId = id
 }
 
-func (m *Model) SetRow(data *data.IssueData) {
+// SetRow shows the issue in the sidebar, fetching its full timeline if it's
+// missing.
+func (m *Model) SetRow(data *data.IssueData) tea.Cmd {
 	if data == nil {
 		m.issue = nil
-	} else {
-		m.issue = &issue.Issue{Ctx: m.ctx, Data: *data}
+		return nil
 	}
+	m.issue = &issue.Issue{Ctx: m.ctx, Data: *data}
+	return m.fetchTimeline()
 }
 
 func (m *Model) IsTextInputBoxFocused() bool {
-	return m.isCommenting || m.isAssigning || m.isUnassigning
+	return m.isCommenting || m.isAssigning || m.isUnassigning || m.isLabeling ||
+		m.isEditingTasks
 }
 
 func (m *Model) GetIsCommenting() bool {
@@ -268,7 +371,10 @@ func (m *Model) SetIsAssigning(isAssigning bool) tea.Cmd {
 	}
 
 	if isAssigning {
-		return tea.Sequence(textarea.Blink, m.inputBox.Focus())
+		return tea.Batch(
+			tea.Sequence(textarea.Blink, m.inputBox.Focus()),
+			m.fetchUserCompletions(usercompletion.Options{}),
+		)
 	}
 	return nil
 }
@@ -295,11 +401,18 @@ func (m *Model) SetIsUnassigning(isUnassigning bool) tea.Cmd {
 	m.inputBox.SetValue(strings.Join(m.issueAssignees(), "\n"))
 
 	if isUnassigning {
-		return tea.Sequence(textarea.Blink, m.inputBox.Focus())
+		return tea.Batch(
+			tea.Sequence(textarea.Blink, m.inputBox.Focus()),
+			m.fetchUserCompletions(usercompletion.Options{Suggested: m.issueAssignees()}),
+		)
 	}
 	return nil
 }
 
+func (m *Model) fetchUserCompletions(opts usercompletion.Options) tea.Cmd {
+	return usercompletion.Fetch(m.issue.Data.GetRepoNameWithOwner(), m.issue.Data.GetUrl(), opts)
+}
+
 func (m *Model) issueAssignees() []string {
 	var assignees []string
 	for _, n := range m.issue.Data.Assignees.Nodes {
@@ -311,4 +424,7 @@ func (m *Model) issueAssignees() []string {
 func (m *Model) UpdateProgramContext(ctx *context.ProgramContext) {
 	m.ctx = ctx
 	m.inputBox.UpdateProgramContext(ctx)
+	m.labelPicker.UpdateProgramContext(ctx)
+	m.taskList.UpdateProgramContext(ctx)
+	m.timeline.UpdateProgramContext(ctx)
 }
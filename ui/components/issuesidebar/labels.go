@@ -0,0 +1,75 @@
+package issuesidebar
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/dlvhdr/gh-dash/v4/data"
+	"github.com/dlvhdr/gh-dash/v4/ui/components/issuessection"
+	"github.com/dlvhdr/gh-dash/v4/ui/components/section"
+	"github.com/dlvhdr/gh-dash/v4/ui/components/tasks"
+	"github.com/dlvhdr/gh-dash/v4/ui/context"
+)
+
+func (m *Model) GetIsLabeling() bool {
+	return m.isLabeling
+}
+
+func (m *Model) SetIsLabeling(isLabeling bool) tea.Cmd {
+	if m.issue == nil {
+		return nil
+	}
+
+	var cmd tea.Cmd
+	if !m.isLabeling && isLabeling {
+		cmd = m.labelPicker.Open(m.issue.Data.GetRepoNameWithOwner(), m.issue.Data.Labels.Nodes)
+	}
+	m.isLabeling = isLabeling
+	return cmd
+}
+
+// editLabels updates the labels in the section right away and reverts them
+// if the change can't be applied.
+func (m *Model) editLabels() tea.Cmd {
+	added := m.labelPicker.Added()
+	removed := m.labelPicker.Removed()
+	if len(added) == 0 && len(removed) == 0 {
+		return nil
+	}
+
+	issue := m.issue.Data
+	issueNumber, issueId, issueUrl := issue.GetNumber(), issue.Id, issue.Url
+	repo := issue.GetRepoNameWithOwner()
+	sectionId := m.sectionId
+	original := append([]data.Label{}, issue.Labels.Nodes...)
+	updated := m.labelPicker.Apply(issue.Labels.Nodes)
+
+	task := context.Task{
+		Id:           fmt.Sprintf("issue_labels_%d", issueNumber),
+		StartText:    fmt.Sprintf("Updating labels of issue #%d", issueNumber),
+		FinishedText: fmt.Sprintf("Labels of issue #%d have been updated", issueNumber),
+		State:        context.TaskStart,
+		Error:        nil,
+	}
+
+	optimisticCmd := func() tea.Msg {
+		return section.SectionMsg{
+			Id:   sectionId,
+			Type: issuessection.SectionType,
+			InternalMsg: tasks.UpdateIssueMsg{
+				IssueUrl: issueUrl,
+				Labels:   &updated,
+			},
+		}
+	}
+
+	sid := tasks.SectionIdentifer{Id: sectionId, Type: issuessection.SectionType}
+	return tea.Batch(optimisticCmd, tasks.Mutate(m.ctx, sid, task, issue, tasks.LabelsMutation(added, removed), func() (tea.Msg, error) {
+		updatedIssue, err := data.UpdateIssueLabels(issueId, repo, added, removed)
+		if err != nil {
+			return tasks.UpdateIssueMsg{IssueUrl: issueUrl, Labels: &original}, err
+		}
+		return tasks.UpdatedIssueMsg(updatedIssue, nil)
+	}))
+}
@@ -0,0 +1,46 @@
+package issuesidebar
+
+import (
+	"errors"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/dlvhdr/gh-dash/v4/ui/components/issuessection"
+	"github.com/dlvhdr/gh-dash/v4/ui/components/tasks"
+	"github.com/dlvhdr/gh-dash/v4/ui/constants"
+)
+
+func (m *Model) GetIsEditingTasks() bool {
+	return m.isEditingTasks
+}
+
+func (m *Model) SetIsEditingTasks(isEditingTasks bool) tea.Cmd {
+	if m.issue == nil {
+		return nil
+	}
+
+	if !m.isEditingTasks && isEditingTasks {
+		if !m.taskList.Open(m.issue.Data.Body) {
+			return func() tea.Msg {
+				return constants.ErrMsg{Err: errors.New("the description has no task-list items")}
+			}
+		}
+	}
+	m.isEditingTasks = isEditingTasks
+	return nil
+}
+
+func (m *Model) saveTasks() tea.Cmd {
+	if !m.taskList.IsChanged() {
+		return nil
+	}
+
+	body := m.taskList.Body()
+	return tasks.EditIssue(
+		m.ctx,
+		tasks.SectionIdentifer{Id: m.sectionId, Type: issuessection.SectionType},
+		&m.issue.Data,
+		nil,
+		&body,
+	)
+}
@@ -0,0 +1,116 @@
+package issuesidebar
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/dlvhdr/gh-dash/v4/data"
+)
+
+// issueTimeline is the full timeline of an issue, which is only fetched once
+// the issue is shown. It's dropped when the issue is updated.
+type issueTimeline struct {
+	updatedAt time.Time
+	fetching  bool
+	fetched   bool
+	err       error
+	items     []data.PullRequestTimelineItem
+}
+
+// TimelineFetchedMsg has the timeline of the issue at Url, as it was when the
+// issue was last updated at UpdatedAt.
+type TimelineFetchedMsg struct {
+	Url       string
+	UpdatedAt time.Time
+	Items     []data.PullRequestTimelineItem
+	Err       error
+}
+
+func (m *Model) getTimeline() *issueTimeline {
+	url := m.issue.Data.GetUrl()
+	t, ok := m.timelines[url]
+	if !ok || !t.updatedAt.Equal(m.issue.Data.UpdatedAt) {
+		t = &issueTimeline{updatedAt: m.issue.Data.UpdatedAt}
+		m.timelines[url] = t
+	}
+	return t
+}
+
+// fetchTimeline fetches the timeline of the issue if it wasn't fetched yet.
+func (m *Model) fetchTimeline() tea.Cmd {
+	if m.issue == nil {
+		return nil
+	}
+
+	t := m.getTimeline()
+	if t.fetching || t.fetched {
+		return nil
+	}
+	t.fetching = true
+	t.err = nil
+
+	url, updatedAt := m.issue.Data.GetUrl(), m.issue.Data.UpdatedAt
+	return func() tea.Msg {
+		items, err := data.FetchIssueTimeline(url)
+		return TimelineFetchedMsg{Url: url, UpdatedAt: updatedAt, Items: items, Err: err}
+	}
+}
+
+func (m *Model) onTimelineFetched(msg TimelineFetchedMsg) {
+	t, ok := m.timelines[msg.Url]
+	if !ok || !t.updatedAt.Equal(msg.UpdatedAt) {
+		return
+	}
+
+	t.fetching = false
+	if msg.Err != nil {
+		t.err = msg.Err
+		return
+	}
+	t.fetched = true
+	t.items = msg.Items
+}
+
+// renderTimeline renders the full timeline of the issue, or its latest
+// comments until the timeline is fetched.
+func (m *Model) renderTimeline() string {
+	t := m.getTimeline()
+	if !t.fetched {
+		note := "Loading the full timeline..."
+		if t.err != nil {
+			note = fmt.Sprintf("Failed fetching the full timeline: %v", t.err)
+		}
+		return lipgloss.JoinVertical(
+			lipgloss.Left,
+			m.renderActivity(),
+			lipgloss.NewStyle().
+				Italic(true).
+				Foreground(m.ctx.Theme.FaintText).
+				Width(m.getIndentedContentWidth()).
+				Render(note),
+		)
+	}
+
+	title := m.ctx.Styles.Common.MainTextStyle.
+		MarginBottom(1).
+		Underline(true).
+		Render(" Activity")
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		title,
+		lipgloss.NewStyle().PaddingLeft(2).Render(m.timeline.View(t.items)),
+	)
+}
+
+// CycleActivityFilter shows the next kind of items in the timeline.
+func (m *Model) CycleActivityFilter() {
+	m.timeline.CycleFilter()
+}
+
+// ToggleBotActivity expands or collapses the items by bots in the timeline.
+func (m *Model) ToggleBotActivity() {
+	m.timeline.ToggleBots()
+}
@@ -0,0 +1,123 @@
+package issuessection
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/dlvhdr/gh-dash/v4/data"
+	"github.com/dlvhdr/gh-dash/v4/ui/components/tasks"
+)
+
+func (m *Model) GetRows() []data.RowData {
+	return issueRows(m.getFilteredIssues())
+}
+
+// getSelectedRows returns the selected issues, including the ones the filter
+// hides.
+func (m *Model) getSelectedRows() []data.RowData {
+	var rows []data.RowData
+	for _, row := range issueRows(m.getVisibleIssues()) {
+		if m.IsSelected(row) {
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
+
+func issueRows(issues []data.IssueData) []data.RowData {
+	rows := make([]data.RowData, 0, len(issues))
+	for _, issue := range issues {
+		issue := issue
+		rows = append(rows, &issue)
+	}
+	return rows
+}
+
+// isBulkAction reports whether action runs on all selected issues, rather
+// than only the current one.
+func isBulkAction(action string) bool {
+	switch action {
+	case "close", "reopen", "labels", "assign", "unassign":
+		return true
+	}
+	return false
+}
+
+// bulk runs the confirmation action on all selected issues. input is the
+// answer to the bulk prompt.
+func (m *Model) bulk(action string, input string) tea.Cmd {
+	rows := m.getSelectedRows()
+	n := len(rows)
+	confirmed := input == "Y" || input == "y"
+
+	var task tasks.BulkTask
+	switch action {
+	case "close", "reopen":
+		if !confirmed {
+			return nil
+		}
+		isClosed := action == "close"
+		task.StartText = fmt.Sprintf("Closing %d issues", n)
+		task.FinishedText = fmt.Sprintf("%d issues have been closed", n)
+		if !isClosed {
+			task.StartText = fmt.Sprintf("Reopening %d issues", n)
+			task.FinishedText = fmt.Sprintf("%d issues have been reopened", n)
+		}
+		mutation := data.ReopenIssue
+		task.Mutation = "reopenIssue"
+		if isClosed {
+			mutation = data.CloseIssue
+			task.Mutation = "closeIssue"
+		}
+		task.Action = func(row data.RowData) (tea.Msg, error) {
+			return tasks.UpdatedIssueMsg(mutation(row.(*data.IssueData).Id))
+		}
+
+	case "labels":
+		added, removed := tasks.ParseLabelChanges(input)
+		if len(added) == 0 && len(removed) == 0 {
+			return nil
+		}
+		task.StartText = fmt.Sprintf("Updating labels of %d issues", n)
+		task.FinishedText = fmt.Sprintf("Labels of %d issues have been updated", n)
+		task.Mutation = tasks.LabelsMutation(added, removed)
+		task.Action = editLabels(added, removed)
+
+	case "assign", "unassign":
+		logins := strings.Fields(input)
+		if len(logins) == 0 {
+			return nil
+		}
+		added, removed := logins, []string(nil)
+		task.StartText = fmt.Sprintf("Assigning %d issues", n)
+		task.FinishedText = fmt.Sprintf("%d issues have been assigned", n)
+		task.Mutation = "addAssigneesToAssignable"
+		if action == "unassign" {
+			task.Mutation = "removeAssigneesFromAssignable"
+			added, removed = nil, logins
+			task.StartText = fmt.Sprintf("Unassigning %d issues", n)
+			task.FinishedText = fmt.Sprintf("%d issues have been unassigned", n)
+		}
+		task.Action = func(row data.RowData) (tea.Msg, error) {
+			issue := row.(*data.IssueData)
+			return tasks.UpdatedIssueMsg(data.UpdateIssueAssignees(issue.Id, added, removed))
+		}
+
+	default:
+		return nil
+	}
+
+	task.Id = fmt.Sprintf("issue_bulk_%s_%d", action, m.Id)
+	task.Section = tasks.SectionIdentifer{Id: m.Id, Type: SectionType}
+	task.Rows = rows
+	return tasks.RunBulkTask(m.Ctx, task)
+}
+
+func editLabels(added []string, removed []string) tasks.BulkAction {
+	return func(row data.RowData) (tea.Msg, error) {
+		issue := row.(*data.IssueData)
+		return tasks.UpdatedIssueMsg(data.UpdateIssueLabels(issue.Id, issue.GetRepoNameWithOwner(), added, removed))
+	}
+}
@@ -2,46 +2,30 @@ package issuessection
 
 import (
 	"fmt"
-	"os/exec"
 
 	tea "github.com/charmbracelet/bubbletea"
-	"github.com/dlvhdr/gh-dash/v4/ui/constants"
+
+	"github.com/dlvhdr/gh-dash/v4/data"
+	"github.com/dlvhdr/gh-dash/v4/ui/components/tasks"
 	"github.com/dlvhdr/gh-dash/v4/ui/context"
-	"github.com/dlvhdr/gh-dash/v4/utils"
 )
 
 func (m *Model) close() tea.Cmd {
-	issue := m.GetCurrRow()
-	issueNumber := issue.GetNumber()
-	taskId := fmt.Sprintf("issue_close_%d", issueNumber)
+	issue, ok := m.GetCurrRow().(*data.IssueData)
+	if !ok {
+		return nil
+	}
+	issueNumber, issueId := issue.Number, issue.Id
 	task := context.Task{
-		Id:           taskId,
+		Id:           fmt.Sprintf("issue_close_%d", issueNumber),
 		StartText:    fmt.Sprintf("Closing issue #%d", issueNumber),
 		FinishedText: fmt.Sprintf("Issue #%d has been closed", issueNumber),
 		State:        context.TaskStart,
 		Error:        nil,
 	}
-	startCmd := m.Ctx.StartTask(task)
-	return tea.Batch(startCmd, func() tea.Msg {
-		c := exec.Command(
-			"gh",
-			"issue",
-			"close",
-			fmt.Sprint(m.GetCurrRow().GetNumber()),
-			"-R",
-			m.GetCurrRow().GetRepoNameWithOwner(),
-		)
 
-		err := c.Run()
-		return constants.TaskFinishedMsg{
-			SectionId:   m.Id,
-			SectionType: SectionType,
-			TaskId:      taskId,
-			Err:         err,
-			Msg: UpdateIssueMsg{
-				IssueNumber: issueNumber,
-				IsClosed:    utils.BoolPtr(true),
-			},
-		}
+	section := tasks.SectionIdentifer{Id: m.Id, Type: SectionType}
+	return tasks.Mutate(m.Ctx, section, task, issue, "closeIssue", func() (tea.Msg, error) {
+		return tasks.UpdatedIssueMsg(data.CloseIssue(issueId))
 	})
 }
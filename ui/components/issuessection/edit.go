@@ -0,0 +1,47 @@
+package issuessection
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/dlvhdr/gh-dash/v4/data"
+	"github.com/dlvhdr/gh-dash/v4/ui/common"
+	"github.com/dlvhdr/gh-dash/v4/ui/components/tasks"
+	"github.com/dlvhdr/gh-dash/v4/ui/constants"
+)
+
+type issueEditedMsg struct {
+	issue   *data.IssueData
+	content string
+	err     error
+}
+
+// edit opens the issue's title and body in the user's editor.
+func (m *Model) edit() tea.Cmd {
+	row := m.GetCurrRow()
+	if row == nil {
+		return nil
+	}
+	issue := row.(*data.IssueData)
+
+	content := common.FormatTitleAndBody(issue.Title, issue.Body)
+	return common.EditInEditor(content, "*.md", func(content string, err error) tea.Msg {
+		return issueEditedMsg{issue: issue, content: content, err: err}
+	})
+}
+
+func (m *Model) onIssueEdited(msg issueEditedMsg) tea.Cmd {
+	if msg.err != nil {
+		return func() tea.Msg { return constants.ErrMsg{Err: msg.err} }
+	}
+
+	title, body, err := common.EditedTitleAndBody(msg.content, msg.issue.Title, msg.issue.Body)
+	if err != nil {
+		return func() tea.Msg { return constants.ErrMsg{Err: err} }
+	}
+	if title == nil && body == nil {
+		return nil
+	}
+
+	sid := tasks.SectionIdentifer{Id: m.Id, Type: SectionType}
+	return tasks.EditIssue(m.Ctx, sid, msg.issue, title, body)
+}
@@ -4,15 +4,20 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 
 	"github.com/dlvhdr/gh-dash/v4/config"
 	"github.com/dlvhdr/gh-dash/v4/data"
 	"github.com/dlvhdr/gh-dash/v4/ui/components/issue"
+	"github.com/dlvhdr/gh-dash/v4/ui/components/search"
 	"github.com/dlvhdr/gh-dash/v4/ui/components/section"
 	"github.com/dlvhdr/gh-dash/v4/ui/components/table"
+	"github.com/dlvhdr/gh-dash/v4/ui/components/tasks"
 	"github.com/dlvhdr/gh-dash/v4/ui/constants"
 	"github.com/dlvhdr/gh-dash/v4/ui/context"
+	"github.com/dlvhdr/gh-dash/v4/ui/keys"
+	"github.com/dlvhdr/gh-dash/v4/ui/triage"
 	"github.com/dlvhdr/gh-dash/v4/utils"
 )
 
@@ -21,6 +26,8 @@ const SectionType = "issue"
 type Model struct {
 	section.BaseModel
 	Issues []data.IssueData
+	// isPinned is set on the section of the pinned issues.
+	isPinned bool
 }
 
 func NewModel(
@@ -63,6 +70,10 @@ func (m Model) Update(msg tea.Msg) (section.Section, tea.Cmd) {
 				return &m, blinkCmd
 
 			case msg.Type == tea.KeyEnter:
+				if !m.SearchBar.ConfirmSubmit() {
+					return &m, nil
+				}
+				m.SearchBar.AddToHistory()
 				m.SearchValue = m.SearchBar.Value()
 				m.SetIsSearching(false)
 				m.ResetRows()
@@ -72,6 +83,11 @@ func (m Model) Update(msg tea.Msg) (section.Section, tea.Cmd) {
 			break
 		}
 
+		if m.IsFilterFocused() {
+			cmd = m.UpdateFilter(msg)
+			break
+		}
+
 		if m.IsPromptConfirmationFocused() {
 
 			switch {
@@ -84,7 +100,11 @@ func (m Model) Update(msg tea.Msg) (section.Section, tea.Cmd) {
 			case msg.Type == tea.KeyEnter:
 				input := m.PromptConfirmationBox.Value()
 				action := m.GetPromptConfirmationAction()
-				if input == "Y" || input == "y" {
+				if action == "save_section" {
+					cmd = m.saveSection(input)
+				} else if m.NumSelected() > 0 && isBulkAction(action) {
+					cmd = m.bulk(action, input)
+				} else if input == "Y" || input == "y" {
 					switch action {
 					case "close":
 						cmd = m.close()
@@ -101,24 +121,30 @@ func (m Model) Update(msg tea.Msg) (section.Section, tea.Cmd) {
 			break
 		}
 
-	case UpdateIssueMsg:
+		switch {
+
+		case key.Matches(msg, keys.IssueKeys.Edit):
+			cmd = m.edit()
+
+		}
+
+	case issueEditedMsg:
+		cmd = m.onIssueEdited(msg)
+
+	case tasks.UpdateIssueMsg:
 		for i, currIssue := range m.Issues {
-			if currIssue.Number == msg.IssueNumber {
-				if msg.IsClosed != nil {
-					if *msg.IsClosed {
-						currIssue.State = "CLOSED"
-					} else {
-						currIssue.State = "OPEN"
-					}
+			if currIssue.Url == msg.IssueUrl {
+				if msg.Issue != nil {
+					currIssue = *msg.Issue
 				}
-				if msg.NewComment != nil {
-					currIssue.Comments.Nodes = append(currIssue.Comments.Nodes, *msg.NewComment)
+				if msg.Labels != nil {
+					currIssue.Labels.Nodes = *msg.Labels
 				}
-				if msg.AddedAssignees != nil {
-					currIssue.Assignees.Nodes = addAssignees(currIssue.Assignees.Nodes, msg.AddedAssignees.Nodes)
+				if msg.Title != nil {
+					currIssue.Title = *msg.Title
 				}
-				if msg.RemovedAssignees != nil {
-					currIssue.Assignees.Nodes = removeAssignees(currIssue.Assignees.Nodes, msg.RemovedAssignees.Nodes)
+				if msg.Body != nil {
+					currIssue.Body = *msg.Body
 				}
 				m.Issues[i] = currIssue
 				m.Table.SetIsLoading(false)
@@ -127,6 +153,10 @@ func (m Model) Update(msg tea.Msg) (section.Section, tea.Cmd) {
 			}
 		}
 
+	case triage.ChangedMsg:
+		m.Table.SetRows(m.BuildRows())
+		m.clampCurrItem()
+
 	case SectionIssuesFetchedMsg:
 		if m.LastFetchTaskId == msg.TaskId {
 			if m.PageInfo != nil {
@@ -138,12 +168,14 @@ func (m Model) Update(msg tea.Msg) (section.Section, tea.Cmd) {
 			m.Table.SetIsLoading(false)
 			m.PageInfo = &msg.PageInfo
 			m.Table.SetRows(m.BuildRows())
+			m.SearchBar.SetCompletions(m.searchCompletions())
 			m.UpdateLastUpdated(time.Now())
 			m.UpdateTotalItemsCount(m.TotalCount)
 		}
 	}
 
 	search, searchCmd := m.SearchBar.Update(msg)
+	m.Table.SetRows(m.BuildRows())
 	m.SearchBar = search
 
 	prompt, promptCmd := m.PromptConfirmationBox.Update(msg)
@@ -182,6 +214,7 @@ func GetSectionColumns(
 		dLayout.Reactions,
 		sLayout.Reactions,
 	)
+	notesLayout := config.MergeColumnConfigs(dLayout.Notes, sLayout.Notes)
 
 	return []table.Column{
 		{
@@ -199,6 +232,11 @@ func GetSectionColumns(
 			Grow:   utils.BoolPtr(true),
 			Hidden: titleLayout.Hidden,
 		},
+		{
+			Title:  constants.NoteIcon,
+			Width:  notesLayout.Width,
+			Hidden: notesLayout.Hidden,
+		},
 		{
 			Title:  "Creator",
 			Width:  creatorLayout.Width,
@@ -229,9 +267,9 @@ func GetSectionColumns(
 
 func (m Model) BuildRows() []table.Row {
 	var rows []table.Row
-	for _, currIssue := range m.Issues {
+	for _, currIssue := range m.getVisibleIssues() {
 		issueModel := issue.Issue{Ctx: m.Ctx, Data: currIssue}
-		rows = append(rows, issueModel.ToTableRow())
+		rows = append(rows, m.MarkSelectedRow(issueModel.ToTableRow(), &currIssue))
 	}
 
 	if rows == nil {
@@ -242,14 +280,16 @@ func (m Model) BuildRows() []table.Row {
 }
 
 func (m *Model) NumRows() int {
-	return len(m.Issues)
+	return len(m.getFilteredIssues())
 }
 
 func (m *Model) GetCurrRow() data.RowData {
-	if len(m.Issues) == 0 {
+	issues := m.getVisibleIssues()
+	i, ok := m.Table.RowIndex(m.Table.GetCurrItem())
+	if !ok || i >= len(issues) {
 		return nil
 	}
-	issue := m.Issues[m.Table.GetCurrItem()]
+	issue := issues[i]
 	return &issue
 }
 
@@ -288,7 +328,13 @@ func (m *Model) FetchNextPageSectionRows() []tea.Cmd {
 		if limit == nil {
 			limit = &m.Ctx.Config.Defaults.IssuesLimit
 		}
-		res, err := data.FetchIssues(m.GetFilters(), *limit, m.PageInfo)
+		var res data.IssuesResponse
+		var err error
+		if m.isPinned {
+			res, err = m.fetchPinnedIssues()
+		} else {
+			res, err = data.FetchIssues(m.GetFilters(), *limit, m.PageInfo)
+		}
 		if err != nil {
 			return constants.TaskFinishedMsg{
 				SectionId:   m.Id,
@@ -328,11 +374,16 @@ func FetchAllSections(
 	ctx context.ProgramContext,
 ) (sections []section.Section, fetchAllCmd tea.Cmd) {
 	sectionConfigs := ctx.Config.IssuesSections
-	fetchIssuesCmds := make([]tea.Cmd, 0, len(sectionConfigs))
-	sections = make([]section.Section, 0, len(sectionConfigs))
-	for i, sectionConfig := range sectionConfigs {
+	fetchIssuesCmds := make([]tea.Cmd, 0, len(sectionConfigs)+1)
+	sections = make([]section.Section, 0, len(sectionConfigs)+1)
+	if ctx.Triage.HasPinned(false) {
+		pinned := NewPinnedModel(&ctx, time.Now())
+		sections = append(sections, &pinned)
+		fetchIssuesCmds = append(fetchIssuesCmds, pinned.FetchNextPageSectionRows()...)
+	}
+	for _, sectionConfig := range sectionConfigs {
 		sectionModel := NewModel(
-			i+1,
+			len(sections)+1,
 			&ctx,
 			sectionConfig,
 			time.Now(),
@@ -352,47 +403,6 @@ type SectionIssuesFetchedMsg struct {
 	TaskId     string
 }
 
-type UpdateIssueMsg struct {
-	IssueNumber      int
-	NewComment       *data.IssueComment
-	IsClosed         *bool
-	AddedAssignees   *data.Assignees
-	RemovedAssignees *data.Assignees
-}
-
-func addAssignees(assignees, addedAssignees []data.Assignee) []data.Assignee {
-	newAssignees := assignees
-	for _, assignee := range addedAssignees {
-		if !assigneesContains(newAssignees, assignee) {
-			newAssignees = append(newAssignees, assignee)
-		}
-	}
-
-	return newAssignees
-}
-
-func removeAssignees(
-	assignees, removedAssignees []data.Assignee,
-) []data.Assignee {
-	newAssignees := []data.Assignee{}
-	for _, assignee := range assignees {
-		if !assigneesContains(removedAssignees, assignee) {
-			newAssignees = append(newAssignees, assignee)
-		}
-	}
-
-	return newAssignees
-}
-
-func assigneesContains(assignees []data.Assignee, assignee data.Assignee) bool {
-	for _, a := range assignees {
-		if assignee == a {
-			return true
-		}
-	}
-	return false
-}
-
 func (m Model) GetItemSingularForm() string {
 	return "Issue"
 }
@@ -405,7 +415,8 @@ func (m Model) GetTotalCount() *int {
 	if m.IsLoading() {
 		return nil
 	}
-	return &m.TotalCount
+	count := m.TotalCount - m.numHidden()
+	return &count
 }
 
 func (m Model) IsLoading() bool {
@@ -415,16 +426,34 @@ func (m Model) IsLoading() bool {
 func (m Model) GetPagerContent() string {
 	pagerContent := ""
 	if m.TotalCount > 0 {
+		hidden := m.numHidden()
 		pagerContent = fmt.Sprintf(
 			"%v %v • %v %v/%v • Fetched %v",
 			constants.WaitingIcon,
 			m.LastUpdated().Format("01/02 15:04:05"),
 			m.SingularForm,
 			m.Table.GetCurrItem()+1,
-			m.TotalCount,
+			m.TotalCount-hidden,
 			len(m.Table.Rows),
-		)
+		) + m.GetHiddenPagerContent(hidden) + m.GetFilterPagerContent() + m.GetSelectionPagerContent()
 	}
 	pager := m.Ctx.Styles.ListViewPort.PagerStyle.Render(pagerContent)
 	return pager
 }
+
+// searchCompletions returns the repos, labels and users of the loaded issues,
+// which the search bar completes qualifiers with.
+func (m *Model) searchCompletions() search.Completions {
+	var completions search.Completions
+	for _, issue := range m.Issues {
+		completions.Repos = append(completions.Repos, issue.GetRepoNameWithOwner())
+		completions.Users = append(completions.Users, issue.Author.Login)
+		for _, assignee := range issue.Assignees.Nodes {
+			completions.Users = append(completions.Users, assignee.Login)
+		}
+		for _, label := range issue.Labels.Nodes {
+			completions.Labels = append(completions.Labels, label.Name)
+		}
+	}
+	return completions
+}
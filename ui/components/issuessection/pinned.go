@@ -0,0 +1,69 @@
+package issuessection
+
+import (
+	"time"
+
+	"github.com/dlvhdr/gh-dash/v4/config"
+	"github.com/dlvhdr/gh-dash/v4/data"
+	"github.com/dlvhdr/gh-dash/v4/ui/components/table"
+	"github.com/dlvhdr/gh-dash/v4/ui/context"
+)
+
+// NewPinnedModel returns the section of the pinned issues, which is always
+// the first one after the search section.
+func NewPinnedModel(ctx *context.ProgramContext, lastUpdated time.Time) Model {
+	m := NewModel(
+		1,
+		ctx,
+		config.IssuesSectionConfig{Title: context.PinnedSectionConfig.Title},
+		lastUpdated,
+	)
+	m.isPinned = true
+	return m
+}
+
+// fetchPinnedIssues fetches the pinned issues, in the order they were pinned
+// in.
+func (m *Model) fetchPinnedIssues() (data.IssuesResponse, error) {
+	issues, err := data.FetchIssuesByIds(m.Ctx.Triage.PinnedIds(false))
+	if err != nil {
+		return data.IssuesResponse{}, err
+	}
+	return data.IssuesResponse{Issues: issues, TotalCount: len(issues)}, nil
+}
+
+// getVisibleIssues returns the issues that aren't snoozed or muted. The
+// search and pinned sections show every issue they have.
+func (m *Model) getVisibleIssues() []data.IssueData {
+	if m.Id == 0 || m.isPinned {
+		return m.Issues
+	}
+
+	issues := make([]data.IssueData, 0, len(m.Issues))
+	for _, issue := range m.Issues {
+		if !m.Ctx.Triage.IsHidden(issue.Url, issue.Author.Login, issue.UpdatedAt) {
+			issues = append(issues, issue)
+		}
+	}
+	return issues
+}
+
+// numHidden returns how many of the fetched issues aren't shown, as they were
+// snoozed or muted.
+func (m *Model) numHidden() int {
+	return len(m.Issues) - len(m.getVisibleIssues())
+}
+
+// getFilteredIssues returns the visible issues whose rows match the table's
+// filter.
+func (m *Model) getFilteredIssues() []data.IssueData {
+	return table.Visible(&m.Table, m.getVisibleIssues())
+}
+
+// clampCurrItem moves the selection back onto the last row when the row it
+// was on is no longer shown.
+func (m *Model) clampCurrItem() {
+	for m.Table.GetCurrItem() > 0 && m.Table.GetCurrItem() >= m.NumRows() {
+		m.Table.PrevItem()
+	}
+}
@@ -0,0 +1,25 @@
+package issuessection
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/dlvhdr/gh-dash/v4/config"
+	"github.com/dlvhdr/gh-dash/v4/ui/components/tasks"
+)
+
+// saveSection saves the current search as a new section called title.
+func (m *Model) saveSection(title string) tea.Cmd {
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return nil
+	}
+
+	sid := tasks.SectionIdentifer{Id: m.Id, Type: SectionType}
+	return tasks.SaveSection(m.Ctx, sid, config.IssuesView, config.SectionConfig{
+		Title:   title,
+		Filters: m.SearchValue,
+		Limit:   m.Config.Limit,
+	})
+}
@@ -0,0 +1,23 @@
+package issuessection
+
+import (
+	"fmt"
+
+	"github.com/dlvhdr/gh-dash/v4/data"
+)
+
+// ShowIssue replaces the section's issues with issue alone, as if a search
+// matched only it, to show an issue that no other section has.
+func (m *Model) ShowIssue(issue data.IssueData) {
+	m.ResetFilter()
+	m.ResetRows()
+	m.LastFetchTaskId = ""
+	m.SearchValue = fmt.Sprintf("repo:%s %d", issue.GetRepoNameWithOwner(), issue.Number)
+	m.SearchBar.SetValue(m.SearchValue)
+	m.Issues = []data.IssueData{issue}
+	m.TotalCount = 1
+	m.PageInfo = &data.PageInfo{}
+	m.UpdateTotalItemsCount(m.TotalCount)
+	m.Table.SetIsLoading(false)
+	m.Table.SetRows(m.BuildRows())
+}
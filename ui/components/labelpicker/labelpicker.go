@@ -0,0 +1,275 @@
+package labelpicker
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/dlvhdr/gh-dash/v4/data"
+	"github.com/dlvhdr/gh-dash/v4/ui/common"
+	"github.com/dlvhdr/gh-dash/v4/ui/context"
+	"github.com/dlvhdr/gh-dash/v4/utils"
+)
+
+const maxVisibleLabels = 8
+
+type Model struct {
+	ctx      *context.ProgramContext
+	repo     string
+	input    textinput.Model
+	labels   []data.Label
+	original []string
+	selected map[string]bool
+	matches  []utils.FuzzyMatch
+	cursor   int
+	err      error
+	help     help.Model
+	width    int
+}
+
+type LabelsFetchedMsg struct {
+	Repo   string
+	Labels []data.Label
+	Err    error
+}
+
+var pickerKeys = []key.Binding{
+	key.NewBinding(key.WithKeys("up", "down"), key.WithHelp("↑/↓", "navigate")),
+	key.NewBinding(key.WithKeys("enter", "tab"), key.WithHelp("enter/tab", "toggle")),
+	key.NewBinding(key.WithKeys(tea.KeyCtrlD.String()), key.WithHelp("Ctrl+d", "apply")),
+	key.NewBinding(key.WithKeys(tea.KeyCtrlC.String(), tea.KeyEsc.String()), key.WithHelp("Ctrl+c/esc", "cancel")),
+}
+
+func NewModel(ctx *context.ProgramContext) Model {
+	ti := textinput.New()
+	ti.Prompt = "  "
+	ti.Placeholder = "Filter labels..."
+
+	m := Model{
+		ctx:      ctx,
+		input:    ti,
+		selected: map[string]bool{},
+		help:     help.New(),
+	}
+	m.UpdateProgramContext(ctx)
+	return m
+}
+
+// Open resets the picker with the labels currently set on the PR or issue and
+// fetches all the labels of its repository.
+func (m *Model) Open(repo string, current []data.Label) tea.Cmd {
+	m.repo = repo
+	m.labels = nil
+	m.matches = nil
+	m.cursor = 0
+	m.err = nil
+	m.original = []string{}
+	m.selected = map[string]bool{}
+	for _, l := range current {
+		m.original = append(m.original, l.Name)
+		m.selected[l.Name] = true
+	}
+	m.input.Reset()
+
+	return tea.Batch(m.input.Focus(), func() tea.Msg {
+		labels, err := data.FetchRepositoryLabels(repo)
+		return LabelsFetchedMsg{Repo: repo, Labels: labels, Err: err}
+	})
+}
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case LabelsFetchedMsg:
+		if msg.Repo != m.repo {
+			return m, nil
+		}
+		if msg.Err != nil {
+			m.err = msg.Err
+			return m, nil
+		}
+		m.labels = msg.Labels
+		m.filter()
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "up", "ctrl+p", "shift+tab":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+			return m, nil
+		case "down", "ctrl+n":
+			if m.cursor < len(m.matches)-1 {
+				m.cursor++
+			}
+			return m, nil
+		case "enter", "tab":
+			if m.cursor < len(m.matches) {
+				name := m.labels[m.matches[m.cursor].Index].Name
+				m.selected[name] = !m.selected[name]
+			}
+			return m, nil
+		}
+
+		prev := m.input.Value()
+		m.input, cmd = m.input.Update(msg)
+		if prev != m.input.Value() {
+			m.filter()
+		}
+	}
+
+	return m, cmd
+}
+
+func (m *Model) filter() {
+	names := make([]string, 0, len(m.labels))
+	for _, l := range m.labels {
+		names = append(names, l.Name)
+	}
+	m.matches = utils.FuzzyFind(m.input.Value(), names)
+	m.cursor = 0
+}
+
+func (m *Model) IsReady() bool {
+	return m.labels != nil && m.err == nil
+}
+
+// Added returns the names of the labels that were selected in the picker but
+// aren't set on the item yet.
+func (m *Model) Added() []string {
+	added := []string{}
+	for _, l := range m.labels {
+		if m.selected[l.Name] && !slices.Contains(m.original, l.Name) {
+			added = append(added, l.Name)
+		}
+	}
+	return added
+}
+
+// Removed returns the names of the labels set on the item that were
+// deselected in the picker.
+func (m *Model) Removed() []string {
+	removed := []string{}
+	for _, name := range m.original {
+		if !m.selected[name] {
+			removed = append(removed, name)
+		}
+	}
+	return removed
+}
+
+// Apply returns labels with the picker's changes applied, keeping the order
+// of the labels that were already set.
+func (m *Model) Apply(labels []data.Label) []data.Label {
+	res := []data.Label{}
+	for _, l := range labels {
+		if m.selected[l.Name] {
+			res = append(res, l)
+		}
+	}
+	added := m.Added()
+	for _, l := range m.labels {
+		if slices.Contains(added, l.Name) {
+			res = append(res, l)
+		}
+	}
+	return res
+}
+
+func (m *Model) SetWidth(width int) {
+	m.width = width
+	m.input.Width = width - lipgloss.Width(m.input.Prompt) - 1
+}
+
+func (m *Model) UpdateProgramContext(ctx *context.ProgramContext) {
+	m.ctx = ctx
+	m.help.Styles = ctx.Styles.Help.BubbleStyles
+	m.input.PromptStyle = lipgloss.NewStyle().Foreground(ctx.Theme.SecondaryText)
+	m.input.PlaceholderStyle = lipgloss.NewStyle().Foreground(ctx.Theme.FaintText)
+	m.input.TextStyle = lipgloss.NewStyle().Foreground(ctx.Theme.PrimaryText)
+}
+
+func (m Model) View() string {
+	return lipgloss.NewStyle().
+		BorderTop(true).
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderForeground(m.ctx.Theme.SecondaryBorder).
+		MarginTop(1).
+		Render(
+			lipgloss.JoinVertical(
+				lipgloss.Left,
+				fmt.Sprintf("Edit labels (%d selected)\n", m.numSelected()),
+				m.input.View(),
+				"",
+				m.renderLabels(),
+				lipgloss.NewStyle().
+					MarginTop(1).
+					Render(m.help.ShortHelpView(pickerKeys)),
+			),
+		)
+}
+
+func (m *Model) numSelected() int {
+	n := 0
+	for _, selected := range m.selected {
+		if selected {
+			n++
+		}
+	}
+	return n
+}
+
+func (m *Model) renderLabels() string {
+	faint := lipgloss.NewStyle().Foreground(m.ctx.Theme.FaintText)
+	switch {
+	case m.err != nil:
+		return lipgloss.NewStyle().Foreground(m.ctx.Theme.ErrorText).Render(m.err.Error())
+	case m.labels == nil:
+		return faint.Render("Fetching labels...")
+	case len(m.matches) == 0:
+		return faint.Render("No matching labels")
+	}
+
+	start := 0
+	if m.cursor >= maxVisibleLabels {
+		start = m.cursor - maxVisibleLabels + 1
+	}
+	end := utils.Min(start+maxVisibleLabels, len(m.matches))
+
+	rows := make([]string, 0, end-start)
+	for i := start; i < end; i++ {
+		match := m.matches[i]
+		label := m.labels[match.Index]
+
+		textStyle := lipgloss.NewStyle().Foreground(m.ctx.Theme.SecondaryText)
+		if i == m.cursor {
+			textStyle = textStyle.Foreground(m.ctx.Theme.PrimaryText).Bold(true)
+		}
+		matchStyle := textStyle.Underline(true).Foreground(m.ctx.Theme.PrimaryText)
+
+		box := "[ ]"
+		if m.selected[label.Name] {
+			box = "[x]"
+		}
+		rows = append(rows, lipgloss.JoinHorizontal(
+			lipgloss.Top,
+			textStyle.Render(box+" "),
+			lipgloss.NewStyle().Foreground(lipgloss.Color("#"+label.Color)).Render("● "),
+			common.RenderFuzzyMatch(label.Name, match.MatchedIndexes, textStyle, matchStyle),
+		))
+	}
+
+	if len(m.matches) > end || start > 0 {
+		rows = append(rows, faint.Render(fmt.Sprintf("%d/%d", m.cursor+1, len(m.matches))))
+	}
+
+	return strings.Join(rows, "\n")
+}
@@ -0,0 +1,70 @@
+package linked
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/dlvhdr/gh-dash/v4/data"
+	"github.com/dlvhdr/gh-dash/v4/ui/constants"
+	"github.com/dlvhdr/gh-dash/v4/ui/context"
+)
+
+// Kind is what the linked items are.
+type Kind int
+
+const (
+	Issues Kind = iota
+	Prs
+)
+
+// View renders items under title, one per line with its state, number and
+// title. It renders nothing when there are no items.
+func View(ctx *context.ProgramContext, width int, title string, kind Kind, items data.LinkedItems) string {
+	if len(items.Nodes) == 0 {
+		return ""
+	}
+
+	lines := []string{
+		ctx.Styles.Common.MainTextStyle.MarginBottom(1).Underline(true).Render(title),
+	}
+	for _, item := range items.Nodes {
+		lines = append(lines, lipgloss.NewStyle().
+			PaddingLeft(2).
+			Width(width).
+			MaxHeight(1).
+			Render(fmt.Sprintf("%s %s %s",
+				renderState(ctx, kind, item.State),
+				lipgloss.NewStyle().Foreground(ctx.Theme.SecondaryText).Render(
+					fmt.Sprintf("%s#%d", item.Repository.NameWithOwner, item.Number)),
+				item.Title,
+			)))
+	}
+	if hidden := items.TotalCount - len(items.Nodes); hidden > 0 {
+		lines = append(lines, lipgloss.NewStyle().
+			PaddingLeft(2).
+			Foreground(ctx.Theme.FaintText).
+			Render(fmt.Sprintf("…and %d more", hidden)))
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
+
+func renderState(ctx *context.ProgramContext, kind Kind, state string) string {
+	style := lipgloss.NewStyle()
+	if kind == Issues {
+		if state == "OPEN" {
+			return style.Foreground(ctx.Styles.Colors.OpenIssue).Render("")
+		}
+		return style.Foreground(ctx.Theme.FaintText).Render("")
+	}
+
+	switch state {
+	case "OPEN":
+		return style.Foreground(ctx.Styles.Colors.OpenPR).Render(constants.OpenIcon)
+	case "CLOSED":
+		return style.Foreground(ctx.Styles.Colors.ClosedPR).Render(constants.ClosedIcon)
+	case "MERGED":
+		return style.Foreground(ctx.Styles.Colors.MergedPR).Render(constants.MergedIcon)
+	}
+	return style.Foreground(ctx.Theme.FaintText).Render("-")
+}
@@ -80,6 +80,21 @@ func (m *Model) GetCurrItem() int {
 	return m.currId
 }
 
+// ItemAt returns the item shown at line y of the viewport.
+func (m *Model) ItemAt(y int) (int, bool) {
+	if y < 0 || y >= m.viewport.Height || m.ListItemHeight == 0 {
+		return 0, false
+	}
+	item := (m.viewport.YOffset + y) / m.ListItemHeight
+	return item, item < m.NumCurrentItems
+}
+
+// SetCurrItem selects item, which has to be one of the items shown in the
+// viewport.
+func (m *Model) SetCurrItem(item int) {
+	m.currId = utils.Max(utils.Min(item, m.NumCurrentItems-1), 0)
+}
+
 func (m *Model) NextItem() int {
 	atBottomOfViewport := m.currId >= m.bottomBoundId
 	if atBottomOfViewport {
@@ -0,0 +1,35 @@
+package localnote
+
+import (
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/dlvhdr/gh-dash/v4/ui/constants"
+	"github.com/dlvhdr/gh-dash/v4/ui/context"
+)
+
+// View renders the private note and tags of the item at url. It renders
+// nothing when the item has no note.
+func View(ctx *context.ProgramContext, width int, url string) string {
+	note, ok := ctx.Notes.Get(url)
+	if !ok {
+		return ""
+	}
+
+	lines := []string{
+		ctx.Styles.Common.MainTextStyle.MarginBottom(1).Underline(true).Render(constants.NoteIcon + " Private note"),
+	}
+	if note.Text != "" {
+		lines = append(lines, lipgloss.NewStyle().
+			PaddingLeft(2).
+			Width(width).
+			Render(note.Text))
+	}
+	if len(note.Tags) > 0 {
+		lines = append(lines, lipgloss.NewStyle().
+			PaddingLeft(2).
+			Width(width).
+			Foreground(ctx.Theme.WarningText).
+			Render(note.FormattedTags()))
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
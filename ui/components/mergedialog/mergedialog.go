@@ -0,0 +1,422 @@
+package mergedialog
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/dlvhdr/gh-dash/v4/data"
+	"github.com/dlvhdr/gh-dash/v4/ui/components/tasks"
+	"github.com/dlvhdr/gh-dash/v4/ui/context"
+)
+
+type field int
+
+const (
+	methodField field = iota
+	titleField
+	bodyField
+	deleteBranchField
+	autoMergeField
+	mergeQueueField
+)
+
+const (
+	MethodMerge  = "merge"
+	MethodSquash = "squash"
+	MethodRebase = "rebase"
+)
+
+var methodLabels = map[string]string{
+	MethodMerge:  "Create a merge commit",
+	MethodSquash: "Squash and merge",
+	MethodRebase: "Rebase and merge",
+}
+
+type Model struct {
+	ctx          *context.ProgramContext
+	pr           *data.PullRequestData
+	settings     *data.RepositoryMergeSettings
+	err          error
+	methods      []string
+	methodIdx    int
+	focused      field
+	titleInput   textinput.Model
+	titleEdited  bool
+	bodyInput    textarea.Model
+	deleteBranch bool
+	autoMerge    bool
+	mergeQueue   bool
+	help         help.Model
+	width        int
+}
+
+type SettingsFetchedMsg struct {
+	PrUrl    string
+	Settings data.RepositoryMergeSettings
+	Err      error
+}
+
+var dialogKeys = []key.Binding{
+	key.NewBinding(key.WithKeys("tab", "shift+tab"), key.WithHelp("tab/shift+tab", "next/prev field")),
+	key.NewBinding(key.WithKeys("left", "right", " "), key.WithHelp("←/→/space", "change")),
+	key.NewBinding(key.WithKeys(tea.KeyCtrlD.String()), key.WithHelp("Ctrl+d", "merge")),
+	key.NewBinding(key.WithKeys(tea.KeyCtrlC.String(), tea.KeyEsc.String()), key.WithHelp("Ctrl+c/esc", "cancel")),
+}
+
+func NewModel(ctx *context.ProgramContext) Model {
+	ti := textinput.New()
+	ti.Prompt = ""
+
+	ta := textarea.New()
+	ta.ShowLineNumbers = false
+	ta.Prompt = ""
+	ta.Placeholder = "Leave empty to use the default commit message"
+	ta.SetHeight(4)
+
+	m := Model{
+		ctx:        ctx,
+		titleInput: ti,
+		bodyInput:  ta,
+		help:       help.New(),
+	}
+	m.UpdateProgramContext(ctx)
+	return m
+}
+
+// Open resets the dialog for the given PR and fetches the merge settings of
+// its repository.
+func (m *Model) Open(pr *data.PullRequestData) tea.Cmd {
+	m.pr = pr
+	m.settings = nil
+	m.err = nil
+	m.methods = nil
+	m.methodIdx = 0
+	m.focused = methodField
+	m.titleEdited = false
+	m.deleteBranch = false
+	m.autoMerge = false
+	m.mergeQueue = false
+	m.titleInput.Reset()
+	m.bodyInput.Reset()
+	m.titleInput.Blur()
+	m.bodyInput.Blur()
+
+	url := pr.GetUrl()
+	repo := pr.GetRepoNameWithOwner()
+	base := pr.BaseRefName
+	return func() tea.Msg {
+		settings, err := data.FetchRepositoryMergeSettings(repo, base)
+		return SettingsFetchedMsg{PrUrl: url, Settings: settings, Err: err}
+	}
+}
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case SettingsFetchedMsg:
+		if m.pr == nil || msg.PrUrl != m.pr.GetUrl() {
+			return m, nil
+		}
+		if msg.Err != nil {
+			m.err = msg.Err
+			return m, nil
+		}
+		m.setSettings(msg.Settings)
+		return m, nil
+
+	case tea.KeyMsg:
+		if !m.IsReady() {
+			return m, nil
+		}
+
+		switch msg.String() {
+		case "tab", "down":
+			if m.focused != bodyField || msg.String() == "tab" {
+				return m, m.focusField(m.nextField(1))
+			}
+		case "shift+tab", "up":
+			if m.focused != bodyField || msg.String() == "shift+tab" {
+				return m, m.focusField(m.nextField(-1))
+			}
+		}
+
+		switch m.focused {
+		case methodField:
+			switch msg.String() {
+			case "left", "h":
+				m.setMethodIdx(m.methodIdx - 1)
+			case "right", "l", " ":
+				m.setMethodIdx(m.methodIdx + 1)
+			}
+		case titleField:
+			prev := m.titleInput.Value()
+			m.titleInput, cmd = m.titleInput.Update(msg)
+			if prev != m.titleInput.Value() {
+				m.titleEdited = true
+			}
+		case bodyField:
+			m.bodyInput, cmd = m.bodyInput.Update(msg)
+		case deleteBranchField:
+			if msg.String() == " " || msg.String() == "enter" {
+				m.deleteBranch = !m.deleteBranch
+			}
+		case autoMergeField:
+			if msg.String() == " " || msg.String() == "enter" {
+				m.autoMerge = !m.autoMerge
+				if m.autoMerge {
+					m.mergeQueue = false
+				}
+			}
+		case mergeQueueField:
+			if msg.String() == " " || msg.String() == "enter" {
+				m.mergeQueue = !m.mergeQueue
+				if m.mergeQueue {
+					m.autoMerge = false
+				}
+			}
+		}
+	}
+
+	return m, cmd
+}
+
+func (m *Model) setSettings(settings data.RepositoryMergeSettings) {
+	m.settings = &settings
+	m.methods = nil
+	if settings.MergeCommitAllowed {
+		m.methods = append(m.methods, MethodMerge)
+	}
+	if settings.SquashMergeAllowed {
+		m.methods = append(m.methods, MethodSquash)
+	}
+	if settings.RebaseMergeAllowed {
+		m.methods = append(m.methods, MethodRebase)
+	}
+	if len(m.methods) == 0 {
+		m.err = fmt.Errorf("no merge methods are allowed in %s", m.pr.GetRepoNameWithOwner())
+		return
+	}
+
+	idx := 0
+	for i, method := range m.methods {
+		if strings.EqualFold(method, settings.ViewerDefaultMergeMethod) {
+			idx = i
+		}
+	}
+	m.deleteBranch = settings.DeleteBranchOnMerge
+	m.setMethodIdx(idx)
+}
+
+func (m *Model) setMethodIdx(idx int) {
+	if len(m.methods) == 0 {
+		return
+	}
+	m.methodIdx = (idx + len(m.methods)) % len(m.methods)
+	if !m.titleEdited {
+		m.titleInput.SetValue(m.defaultCommitTitle())
+		m.titleInput.CursorEnd()
+	}
+}
+
+func (m *Model) defaultCommitTitle() string {
+	switch m.Method() {
+	case MethodMerge:
+		return fmt.Sprintf(
+			"Merge pull request #%d from %s/%s",
+			m.pr.Number,
+			m.pr.HeadRepositoryOwner.Login,
+			m.pr.HeadRefName,
+		)
+	case MethodSquash:
+		return fmt.Sprintf("%s (#%d)", m.pr.Title, m.pr.Number)
+	}
+	return ""
+}
+
+func (m *Model) isFieldEnabled(f field) bool {
+	switch f {
+	case titleField, bodyField:
+		return !m.mergeQueue && m.Method() != MethodRebase
+	case methodField:
+		return !m.mergeQueue
+	case deleteBranchField:
+		// Auto-merge and merge queues merge the PR later, so its branch is
+		// only deleted if the repository deletes head branches on merge.
+		return !m.autoMerge && !m.mergeQueue
+	case autoMergeField:
+		return m.settings != nil && m.settings.AutoMergeAllowed
+	case mergeQueueField:
+		return m.settings != nil && m.settings.IsMergeQueueEnabled()
+	}
+	return true
+}
+
+func (m *Model) nextField(dir int) field {
+	f := m.focused
+	for i := 0; i <= int(mergeQueueField); i++ {
+		f = field((int(f) + dir + int(mergeQueueField) + 1) % (int(mergeQueueField) + 1))
+		if m.isFieldEnabled(f) {
+			return f
+		}
+	}
+	return m.focused
+}
+
+func (m *Model) focusField(f field) tea.Cmd {
+	m.focused = f
+	m.titleInput.Blur()
+	m.bodyInput.Blur()
+	switch f {
+	case titleField:
+		return m.titleInput.Focus()
+	case bodyField:
+		return m.bodyInput.Focus()
+	}
+	return nil
+}
+
+func (m *Model) IsReady() bool {
+	return m.settings != nil && m.err == nil
+}
+
+func (m *Model) Method() string {
+	if len(m.methods) == 0 {
+		return ""
+	}
+	return m.methods[m.methodIdx]
+}
+
+func (m *Model) Options() tasks.MergeOptions {
+	opts := tasks.MergeOptions{
+		Method:       m.Method(),
+		DeleteBranch: m.deleteBranch && m.isFieldEnabled(deleteBranchField),
+		AutoMerge:    m.autoMerge,
+		MergeQueue:   m.mergeQueue,
+	}
+	if m.Method() != MethodRebase {
+		opts.CommitTitle = strings.TrimSpace(m.titleInput.Value())
+		opts.CommitBody = strings.TrimSpace(m.bodyInput.Value())
+	}
+	return opts
+}
+
+func (m *Model) SetWidth(width int) {
+	m.width = width
+	m.titleInput.Width = width - 2
+	m.bodyInput.SetWidth(width)
+}
+
+func (m *Model) UpdateProgramContext(ctx *context.ProgramContext) {
+	m.ctx = ctx
+	m.help.Styles = ctx.Styles.Help.BubbleStyles
+	m.titleInput.PlaceholderStyle = lipgloss.NewStyle().Foreground(ctx.Theme.FaintText)
+	m.bodyInput.FocusedStyle.Base = lipgloss.NewStyle()
+	m.bodyInput.FocusedStyle.CursorLine = lipgloss.NewStyle()
+	m.bodyInput.FocusedStyle.Placeholder = lipgloss.NewStyle().Foreground(ctx.Theme.FaintText)
+	m.bodyInput.FocusedStyle.Text = lipgloss.NewStyle().Foreground(ctx.Theme.PrimaryText)
+	m.bodyInput.BlurredStyle = m.bodyInput.FocusedStyle
+	m.bodyInput.BlurredStyle.Text = lipgloss.NewStyle().Foreground(ctx.Theme.SecondaryText)
+}
+
+func (m Model) View() string {
+	var content string
+	switch {
+	case m.err != nil:
+		content = lipgloss.NewStyle().Foreground(m.ctx.Theme.ErrorText).Render(m.err.Error())
+	case m.settings == nil:
+		content = lipgloss.NewStyle().Foreground(m.ctx.Theme.FaintText).Render("Fetching merge settings...")
+	default:
+		rows := []string{
+			m.renderField(methodField, "Method", m.renderMethods()),
+			m.renderField(titleField, "Commit title", m.titleInput.View()),
+			m.renderField(bodyField, "Commit body", m.bodyInput.View()),
+			m.renderCheckbox(deleteBranchField, "Delete branch after merge", m.deleteBranch),
+		}
+		if !m.isFieldEnabled(deleteBranchField) {
+			rows = append(rows, lipgloss.NewStyle().Foreground(m.ctx.Theme.FaintText).Render(m.deleteBranchNote()))
+		}
+		if m.isFieldEnabled(autoMergeField) {
+			rows = append(rows, m.renderCheckbox(autoMergeField, "Enable auto-merge when checks pass", m.autoMerge))
+		}
+		if m.isFieldEnabled(mergeQueueField) {
+			rows = append(rows, m.renderCheckbox(mergeQueueField, "Add to merge queue", m.mergeQueue))
+		}
+		content = lipgloss.JoinVertical(lipgloss.Left, rows...)
+	}
+
+	return lipgloss.NewStyle().
+		BorderTop(true).
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderForeground(m.ctx.Theme.SecondaryBorder).
+		MarginTop(1).
+		Render(
+			lipgloss.JoinVertical(
+				lipgloss.Left,
+				fmt.Sprintf("Merge PR #%d\n", m.pr.GetNumber()),
+				content,
+				lipgloss.NewStyle().
+					MarginTop(1).
+					Render(m.help.ShortHelpView(dialogKeys)),
+			),
+		)
+}
+
+func (m *Model) renderField(f field, label string, value string) string {
+	labelStyle := lipgloss.NewStyle().Foreground(m.ctx.Theme.SecondaryText)
+	if m.focused == f {
+		labelStyle = labelStyle.Foreground(m.ctx.Theme.PrimaryText).Bold(true)
+	}
+	if !m.isFieldEnabled(f) {
+		labelStyle = labelStyle.Foreground(m.ctx.Theme.FaintText).Faint(true)
+		value = lipgloss.NewStyle().Foreground(m.ctx.Theme.FaintText).Render("-")
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, labelStyle.Render(label), value, "")
+}
+
+// deleteBranchNote explains what happens to the PR's branch when it isn't
+// merged right away.
+func (m *Model) deleteBranchNote() string {
+	if m.settings != nil && m.settings.DeleteBranchOnMerge {
+		return "  The repository deletes the branch once the PR is merged"
+	}
+	return "  The branch is kept, the repository doesn't delete branches on merge"
+}
+
+func (m *Model) renderMethods() string {
+	methods := make([]string, 0, len(m.methods))
+	for i, method := range m.methods {
+		style := lipgloss.NewStyle().Foreground(m.ctx.Theme.FaintText)
+		glyph := "○"
+		if i == m.methodIdx {
+			style = style.Foreground(m.ctx.Theme.PrimaryText)
+			glyph = "●"
+		}
+		methods = append(methods, style.Render(fmt.Sprintf("%s %s", glyph, methodLabels[method])))
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, methods...)
+}
+
+func (m *Model) renderCheckbox(f field, label string, checked bool) string {
+	box := "[ ]"
+	if checked {
+		box = "[x]"
+	}
+	style := lipgloss.NewStyle().Foreground(m.ctx.Theme.SecondaryText)
+	if m.focused == f {
+		style = style.Foreground(m.ctx.Theme.PrimaryText).Bold(true)
+	}
+	if !m.isFieldEnabled(f) {
+		box = "[-]"
+		style = lipgloss.NewStyle().Foreground(m.ctx.Theme.FaintText).Faint(true)
+	}
+	return style.Render(fmt.Sprintf("%s %s", box, label))
+}
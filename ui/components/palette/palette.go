@@ -0,0 +1,262 @@
+package palette
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/dlvhdr/gh-dash/v4/ui/context"
+	"github.com/dlvhdr/gh-dash/v4/utils"
+)
+
+var (
+	upKey   = key.NewBinding(key.WithKeys("up", "ctrl+k"), key.WithHelp("↑/ctrl+k", "up"))
+	downKey = key.NewBinding(key.WithKeys("down", "ctrl+j"), key.WithHelp("↓/ctrl+j", "down"))
+	runKey  = key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "run"))
+	// closeKey doesn't include q, which is typed into the search.
+	closeKey = key.NewBinding(
+		key.WithKeys(tea.KeyEsc.String(), tea.KeyCtrlC.String()),
+		key.WithHelp("esc", "close"),
+	)
+)
+
+// Action is a builtin or custom action the palette lists.
+type Action struct {
+	// Key is the key the action is bound to, which is pressed to run it.
+	Key string
+	// KeyHelp is how the keys of the action are shown, like "C/space".
+	KeyHelp string
+	Desc    string
+	// Group is where the action comes from, like "PRs" or "Custom".
+	Group string
+	// Command is the command template of a custom action.
+	Command string
+}
+
+func (a Action) searchText() string {
+	return strings.Join([]string{a.KeyHelp, a.Desc, a.Group, a.Command}, " ")
+}
+
+// ActionChosenMsg is sent when an action is chosen in the palette, which is
+// closed by then.
+type ActionChosenMsg struct {
+	Action Action
+}
+
+// Model is the command palette. It lists the actions it's opened with,
+// filtered by a fuzzy search, and sends the chosen one in an
+// ActionChosenMsg.
+type Model struct {
+	ctx     *context.ProgramContext
+	isOpen  bool
+	input   textinput.Model
+	actions []Action
+	matches []Action
+	cursor  int
+	offset  int
+	width   int
+	height  int
+	help    help.Model
+}
+
+func NewModel(ctx *context.ProgramContext) Model {
+	input := textinput.New()
+	input.Prompt = "> "
+	input.Placeholder = "Search actions..."
+	return Model{
+		ctx:   ctx,
+		input: input,
+		help:  help.New(),
+	}
+}
+
+// Open shows actions, with the search cleared.
+func (m *Model) Open(actions []Action) tea.Cmd {
+	m.isOpen = true
+	m.actions = actions
+	m.cursor = 0
+	m.offset = 0
+	m.input.Reset()
+	m.matches = Filter(actions, "")
+	return m.input.Focus()
+}
+
+func (m *Model) Close() {
+	m.isOpen = false
+	m.input.Blur()
+}
+
+func (m *Model) IsOpen() bool {
+	return m.isOpen
+}
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		var cmd tea.Cmd
+		m.input, cmd = m.input.Update(msg)
+		return m, cmd
+	}
+
+	switch {
+	case key.Matches(keyMsg, closeKey):
+		m.Close()
+
+	case key.Matches(keyMsg, upKey):
+		if m.cursor > 0 {
+			m.cursor--
+		}
+
+	case key.Matches(keyMsg, downKey):
+		if m.cursor < len(m.matches)-1 {
+			m.cursor++
+		}
+
+	case key.Matches(keyMsg, runKey):
+		if m.cursor >= len(m.matches) {
+			return m, nil
+		}
+		action := m.matches[m.cursor]
+		m.Close()
+		return m, func() tea.Msg {
+			return ActionChosenMsg{Action: action}
+		}
+
+	default:
+		var cmd tea.Cmd
+		m.input, cmd = m.input.Update(msg)
+		m.matches = Filter(m.actions, m.input.Value())
+		m.cursor = 0
+		m.offset = 0
+		return m, cmd
+	}
+
+	return m, nil
+}
+
+// Filter returns the actions that match every word of query, best matches
+// first. An empty query returns all actions in order.
+func Filter(actions []Action, query string) []Action {
+	terms := strings.Fields(query)
+	if len(terms) == 0 {
+		return actions
+	}
+
+	type match struct {
+		action Action
+		score  int
+	}
+	var matches []match
+	for _, action := range actions {
+		text := action.searchText()
+		total, ok := 0, true
+		for _, term := range terms {
+			score, _, matched := utils.FuzzyScore(term, text)
+			if !matched {
+				ok = false
+				break
+			}
+			total += score
+		}
+		if ok {
+			matches = append(matches, match{action: action, score: total})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].score > matches[j].score
+	})
+
+	filtered := make([]Action, 0, len(matches))
+	for _, match := range matches {
+		filtered = append(filtered, match.action)
+	}
+	return filtered
+}
+
+func (m *Model) SetSize(width int, height int) {
+	m.width = width
+	m.height = height
+}
+
+func (m *Model) UpdateProgramContext(ctx *context.ProgramContext) {
+	m.ctx = ctx
+	m.help.Styles = ctx.Styles.Help.BubbleStyles
+}
+
+func (m *Model) View() string {
+	title := m.ctx.Styles.Common.MainTextStyle.Render(
+		fmt.Sprintf("Commands (%d/%d)", len(m.matches), len(m.actions)))
+	helpView := m.help.ShortHelpView([]key.Binding{upKey, downKey, runKey, closeKey})
+
+	if len(m.matches) == 0 {
+		return lipgloss.JoinVertical(
+			lipgloss.Left,
+			title,
+			m.input.View(),
+			"",
+			lipgloss.NewStyle().Foreground(m.ctx.Theme.FaintText).Render("No matching actions"),
+			"",
+			helpView,
+		)
+	}
+
+	available := max(m.height-lipgloss.Height(title)-lipgloss.Height(helpView)-3, 1)
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	} else if m.cursor >= m.offset+available {
+		m.offset = m.cursor - available + 1
+	}
+
+	keyWidth := 0
+	for _, action := range m.matches {
+		keyWidth = max(keyWidth, lipgloss.Width(action.KeyHelp))
+	}
+
+	var lines []string
+	for i := m.offset; i < len(m.matches) && i < m.offset+available; i++ {
+		lines = append(lines, m.renderAction(m.matches[i], keyWidth, i == m.cursor))
+	}
+
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		title,
+		m.input.View(),
+		"",
+		lipgloss.JoinVertical(lipgloss.Left, lines...),
+		"",
+		helpView,
+	)
+}
+
+func (m *Model) renderAction(action Action, keyWidth int, isSelected bool) string {
+	faint := lipgloss.NewStyle().Foreground(m.ctx.Theme.FaintText)
+	textStyle := lipgloss.NewStyle().Foreground(m.ctx.Theme.SecondaryText)
+	prefix := "  "
+	if isSelected {
+		textStyle = textStyle.Foreground(m.ctx.Theme.PrimaryText).Bold(true)
+		prefix = "> "
+	}
+
+	desc := action.Desc
+	if action.Command != "" {
+		desc = fmt.Sprintf("%s %s", desc, faint.Render("$ "+action.Command))
+	}
+	return lipgloss.NewStyle().
+		MaxWidth(m.width).
+		MaxHeight(1).
+		Render(fmt.Sprintf("%s%s %s %s",
+			textStyle.Render(prefix),
+			lipgloss.NewStyle().
+				Foreground(m.ctx.Theme.WarningText).
+				Width(keyWidth).
+				Render(action.KeyHelp),
+			textStyle.Render(desc),
+			faint.Render(action.Group),
+		))
+}
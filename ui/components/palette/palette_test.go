@@ -0,0 +1,50 @@
+package palette_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dlvhdr/gh-dash/v4/ui/components/palette"
+)
+
+func TestFilter(t *testing.T) {
+	actions := []palette.Action{
+		{Key: "m", KeyHelp: "m", Desc: "merge", Group: "PRs"},
+		{Key: "v", KeyHelp: "v", Desc: "approve", Group: "PRs"},
+		{Key: "ctrl+t", KeyHelp: "ctrl+t", Desc: "custom command", Group: "Custom", Command: "gh pr view {{.PrNumber}}"},
+		{Key: "/", KeyHelp: "/", Desc: "search", Group: "Universal"},
+	}
+
+	testCases := map[string]struct {
+		query string
+		want  []string
+	}{
+		"empty query keeps the order": {
+			query: " ",
+			want:  []string{"merge", "approve", "custom command", "search"},
+		},
+		"fuzzy description": {
+			query: "apprv",
+			want:  []string{"approve"},
+		},
+		"custom command template": {
+			query: "pr view",
+			want:  []string{"custom command"},
+		},
+		"every word has to match": {
+			query: "merge zzz",
+			want:  []string{},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got := []string{}
+			for _, action := range palette.Filter(actions, tc.query) {
+				got = append(got, action.Desc)
+			}
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
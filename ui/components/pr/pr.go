@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 
 	"github.com/dlvhdr/gh-dash/v4/data"
 	"github.com/dlvhdr/gh-dash/v4/git"
@@ -16,10 +17,16 @@ import (
 )
 
 type PullRequest struct {
-	Ctx     *context.ProgramContext
-	Data    *data.PullRequestData
-	Branch  git.Branch
-	Columns []table.Column
+	Ctx      *context.ProgramContext
+	Data     *data.PullRequestData
+	Branch   git.Branch
+	Columns  []table.Column
+	IsDimmed bool
+	// Files are the PR's changed files, or nil if they weren't fetched yet.
+	Files *data.PullRequestFiles
+	// IsWatchingChecks shows the progress of pending checks instead of the
+	// waiting glyph.
+	IsWatchingChecks bool
 }
 
 func (pr *PullRequest) getTextStyle() lipgloss.Style {
@@ -59,6 +66,10 @@ func (pr *PullRequest) renderState() string {
 	case "OPEN":
 		if pr.Data.IsDraft {
 			return mergeCellStyle.Foreground(pr.Ctx.Theme.FaintText).Render(constants.DraftIcon)
+		} else if pr.Data.IsInMergeQueue {
+			return mergeCellStyle.Foreground(pr.Ctx.Styles.Colors.MergedPR).Render(constants.MergeQueueIcon)
+		} else if pr.Data.AutoMergeRequest != nil {
+			return mergeCellStyle.Foreground(pr.Ctx.Styles.Colors.OpenPR).Render(constants.AutoMergeIcon)
 		} else {
 			return mergeCellStyle.Foreground(pr.Ctx.Styles.Colors.OpenPR).Render(constants.OpenIcon)
 		}
@@ -109,6 +120,32 @@ func (pr *PullRequest) GetStatusChecksRollup() string {
 	return accStatus
 }
 
+// GetChecksProgress returns how many of the checks of the most recent commit
+// have completed.
+func (pr *PullRequest) GetChecksProgress() (completed int, total int) {
+	commits := pr.Data.Commits.Nodes
+	if len(commits) == 0 {
+		return 0, 0
+	}
+
+	for _, statusCheck := range commits[0].Commit.StatusCheckRollup.Contexts.Nodes {
+		var isWaiting bool
+		if statusCheck.Typename == "CheckRun" {
+			isWaiting = isStatusWaiting(string(statusCheck.CheckRun.Status))
+		} else if statusCheck.Typename == "StatusContext" {
+			isWaiting = isStatusWaiting(string(statusCheck.StatusContext.State))
+		} else {
+			continue
+		}
+
+		total++
+		if !isWaiting {
+			completed++
+		}
+	}
+	return completed, total
+}
+
 func (pr *PullRequest) renderCiStatus() string {
 	if pr.Data == nil {
 		return "-"
@@ -122,6 +159,13 @@ func (pr *PullRequest) renderCiStatus() string {
 	}
 
 	if accStatus == "PENDING" {
+		if completed, total := pr.GetChecksProgress(); pr.IsWatchingChecks && total > 0 {
+			progress := fmt.Sprintf("%d/%d", completed, total)
+			if lipgloss.Width(progress) > pr.Ctx.Styles.PrSection.CiCellWidth {
+				progress = fmt.Sprintf("%d%%", completed*100/total)
+			}
+			return ciCellStyle.Foreground(pr.Ctx.Theme.WarningText).Render(progress)
+		}
 		return ciCellStyle.Render(pr.Ctx.Styles.Common.WaitingGlyph)
 	}
 
@@ -129,6 +173,40 @@ func (pr *PullRequest) renderCiStatus() string {
 	return ciCellStyle.Render(constants.FailureIcon)
 }
 
+// ViewedFiles returns the files to count the viewed ones of: every file once
+// they were fetched, or else the first page of them the PR was fetched with.
+func (pr *PullRequest) ViewedFiles() data.PullRequestFiles {
+	if pr.Files != nil {
+		return *pr.Files
+	}
+	return pr.Data.FileStates.Files()
+}
+
+// ViewedCountText returns how many of the files were viewed, with a + when
+// files that weren't fetched may be viewed too.
+func ViewedCountText(files data.PullRequestFiles) string {
+	if files.IsPartial() {
+		return fmt.Sprintf("%d+", files.ViewedCount())
+	}
+	return fmt.Sprintf("%d", files.ViewedCount())
+}
+
+func (pr *PullRequest) renderViewedFiles() string {
+	files := pr.ViewedFiles()
+	if files.TotalCount == 0 {
+		return "-"
+	}
+
+	style := pr.getTextStyle()
+	switch files.ViewedProgress() {
+	case data.NoneViewed:
+		style = style.Foreground(pr.Ctx.Theme.FaintText)
+	case data.AllViewed:
+		style = style.Foreground(pr.Ctx.Theme.SuccessText)
+	}
+	return style.Render(fmt.Sprintf("%s/%d", ViewedCountText(files), files.TotalCount))
+}
+
 func (pr *PullRequest) renderLines(isSelected bool) string {
 	if pr.Data == nil {
 		return "-"
@@ -177,12 +255,17 @@ func keepSameSpacesOnAddDeletions(str string) string {
 }
 
 func (pr *PullRequest) renderTitle() string {
-	return components.RenderIssueTitle(
-		pr.Ctx,
-		pr.Data.State,
-		pr.Data.Title,
-		pr.Data.Number,
-	)
+	return components.RenderNoteTags(pr.Ctx, pr.Data.Url, lipgloss.NewStyle()) +
+		components.RenderIssueTitle(
+			pr.Ctx,
+			pr.Data.State,
+			pr.Data.Title,
+			pr.Data.Number,
+		)
+}
+
+func (pr *PullRequest) renderNote() string {
+	return components.RenderNoteIndicator(pr.Ctx, pr.Data.Url)
 }
 
 func (pr *PullRequest) renderExtendedTitle(isSelected bool) string {
@@ -207,7 +290,10 @@ func (pr *PullRequest) renderExtendedTitle(isSelected bool) string {
 	}
 	width := titleColumn.ComputedWidth - 2
 	top = baseStyle.Foreground(pr.Ctx.Theme.SecondaryText).Width(width).MaxWidth(width).Height(1).MaxHeight(1).Render(top)
-	title = baseStyle.Foreground(pr.Ctx.Theme.PrimaryText).Width(width).MaxWidth(width).Height(1).MaxHeight(1).Render(title)
+	tags := components.RenderNoteTags(pr.Ctx, pr.Data.Url, baseStyle)
+	titleWidth := utils.Max(width-lipgloss.Width(tags), 0)
+	title = baseStyle.Foreground(pr.Ctx.Theme.PrimaryText).Width(titleWidth).MaxWidth(titleWidth).Height(1).MaxHeight(1).Render(title)
+	title = lipgloss.NewStyle().MaxWidth(width).Render(tags + title)
 
 	return baseStyle.Render(lipgloss.JoinVertical(lipgloss.Left, top, title))
 }
@@ -271,6 +357,10 @@ func (pr *PullRequest) RenderState() string {
 	case "OPEN":
 		if pr.Data.IsDraft {
 			return constants.DraftIcon + " Draft"
+		} else if pr.Data.IsInMergeQueue {
+			return constants.MergeQueueIcon + " Queued"
+		} else if pr.Data.AutoMergeRequest != nil {
+			return constants.AutoMergeIcon + " Auto-merge"
 		} else {
 			return constants.OpenIcon + " Open"
 		}
@@ -297,14 +387,27 @@ func (pr *PullRequest) RenderMergeStateStatus() string {
 }
 
 func (pr *PullRequest) ToTableRow(isSelected bool) table.Row {
+	row := pr.toTableRow(isSelected)
+	if pr.IsDimmed {
+		style := lipgloss.NewStyle().Foreground(pr.Ctx.Theme.FaintText)
+		for i, cell := range row {
+			row[i] = style.Render(ansi.Strip(cell))
+		}
+	}
+	return row
+}
+
+func (pr *PullRequest) toTableRow(isSelected bool) table.Row {
 	if !pr.Ctx.Config.Theme.Ui.Table.Compact {
 		return table.Row{
 			pr.renderState(),
 			pr.renderExtendedTitle(isSelected),
+			pr.renderNote(),
 			pr.renderAssignees(),
 			pr.renderBaseName(),
 			pr.renderReviewStatus(),
 			pr.renderCiStatus(),
+			pr.renderViewedFiles(),
 			pr.renderLines(isSelected),
 			pr.renderUpdateAt(),
 		}
@@ -314,11 +417,13 @@ func (pr *PullRequest) ToTableRow(isSelected bool) table.Row {
 		pr.renderState(),
 		pr.renderRepoName(),
 		pr.renderTitle(),
+		pr.renderNote(),
 		pr.renderAuthor(),
 		pr.renderAssignees(),
 		pr.renderBaseName(),
 		pr.renderReviewStatus(),
 		pr.renderCiStatus(),
+		pr.renderViewedFiles(),
 		pr.renderLines(isSelected),
 		pr.renderUpdateAt(),
 	}
@@ -2,53 +2,28 @@ package prsidebar
 
 import (
 	"fmt"
-	"os/exec"
 
 	tea "github.com/charmbracelet/bubbletea"
 
+	"github.com/dlvhdr/gh-dash/v4/data"
 	"github.com/dlvhdr/gh-dash/v4/ui/components/prssection"
 	"github.com/dlvhdr/gh-dash/v4/ui/components/tasks"
-	"github.com/dlvhdr/gh-dash/v4/ui/constants"
 	"github.com/dlvhdr/gh-dash/v4/ui/context"
 )
 
 func (m *Model) approve(comment string) tea.Cmd {
 	pr := m.pr.Data
-	prNumber := pr.GetNumber()
-	taskId := fmt.Sprintf("pr_approve_%d", prNumber)
+	prNumber, prId := pr.GetNumber(), pr.Id
 	task := context.Task{
-		Id:           taskId,
+		Id:           fmt.Sprintf("pr_approve_%d", prNumber),
 		StartText:    fmt.Sprintf("Approving pr #%d", prNumber),
 		FinishedText: fmt.Sprintf("pr #%d has been approved", prNumber),
 		State:        context.TaskStart,
 		Error:        nil,
 	}
 
-	commandArgs := []string{
-		"pr",
-		"review",
-		"-R",
-		pr.GetRepoNameWithOwner(),
-		fmt.Sprint(prNumber),
-		"--approve",
-	}
-	if comment != "" {
-		commandArgs = append(commandArgs, "--body", comment)
-	}
-
-	startCmd := m.ctx.StartTask(task)
-	return tea.Batch(startCmd, func() tea.Msg {
-		c := exec.Command("gh", commandArgs...)
-
-		err := c.Run()
-		return constants.TaskFinishedMsg{
-			SectionId:   m.sectionId,
-			SectionType: prssection.SectionType,
-			TaskId:      taskId,
-			Err:         err,
-			Msg: tasks.UpdatePRMsg{
-				PrNumber: prNumber,
-			},
-		}
+	section := tasks.SectionIdentifer{Id: m.sectionId, Type: prssection.SectionType}
+	return tasks.Mutate(m.ctx, section, task, pr, "addPullRequestReview", func() (tea.Msg, error) {
+		return tasks.UpdatedPRMsg(data.ApprovePullRequest(prId, comment))
 	})
 }
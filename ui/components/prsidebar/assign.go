@@ -2,59 +2,28 @@ package prsidebar
 
 import (
 	"fmt"
-	"os/exec"
 
 	tea "github.com/charmbracelet/bubbletea"
 
 	"github.com/dlvhdr/gh-dash/v4/data"
 	"github.com/dlvhdr/gh-dash/v4/ui/components/prssection"
 	"github.com/dlvhdr/gh-dash/v4/ui/components/tasks"
-	"github.com/dlvhdr/gh-dash/v4/ui/constants"
 	"github.com/dlvhdr/gh-dash/v4/ui/context"
 )
 
 func (m *Model) assign(usernames []string) tea.Cmd {
 	pr := m.pr.Data
-	prNumber := pr.GetNumber()
-	taskId := fmt.Sprintf("pr_assign_%d", prNumber)
+	prNumber, prId := pr.GetNumber(), pr.Id
 	task := context.Task{
-		Id:           taskId,
+		Id:           fmt.Sprintf("pr_assign_%d", prNumber),
 		StartText:    fmt.Sprintf("Assigning pr #%d to %s", prNumber, usernames),
 		FinishedText: fmt.Sprintf("pr #%d has been assigned to %s", prNumber, usernames),
 		State:        context.TaskStart,
 		Error:        nil,
 	}
 
-	commandArgs := []string{
-		"pr",
-		"edit",
-		fmt.Sprint(prNumber),
-		"-R",
-		pr.GetRepoNameWithOwner(),
-	}
-	for _, assignee := range usernames {
-		commandArgs = append(commandArgs, "--add-assignee")
-		commandArgs = append(commandArgs, assignee)
-	}
-
-	startCmd := m.ctx.StartTask(task)
-	return tea.Batch(startCmd, func() tea.Msg {
-		c := exec.Command("gh", commandArgs...)
-
-		err := c.Run()
-		returnedAssignees := data.Assignees{Nodes: []data.Assignee{}}
-		for _, assignee := range usernames {
-			returnedAssignees.Nodes = append(returnedAssignees.Nodes, data.Assignee{Login: assignee})
-		}
-		return constants.TaskFinishedMsg{
-			SectionId:   m.sectionId,
-			SectionType: prssection.SectionType,
-			TaskId:      taskId,
-			Err:         err,
-			Msg: tasks.UpdatePRMsg{
-				PrNumber:       prNumber,
-				AddedAssignees: &returnedAssignees,
-			},
-		}
+	section := tasks.SectionIdentifer{Id: m.sectionId, Type: prssection.SectionType}
+	return tasks.Mutate(m.ctx, section, task, pr, "addAssigneesToAssignable", func() (tea.Msg, error) {
+		return tasks.UpdatedPRMsg(data.UpdatePullRequestAssignees(prId, usernames, nil))
 	})
 }
@@ -1,9 +1,11 @@
 package prsidebar
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+
 	"github.com/dlvhdr/gh-dash/v4/data"
 )
 
@@ -21,18 +23,7 @@ func (sidebar *Model) renderChecks() string {
 	}
 
 	lastCommit := commits[0]
-	for _, node := range lastCommit.Commit.StatusCheckRollup.Contexts.Nodes {
-		if node.Typename == "CheckRun" {
-			checkRun := node.CheckRun
-			renderedStatus := sidebar.renderCheckRunConclusion(checkRun)
-			name := renderCheckRunName(checkRun)
-			checks = append(checks, lipgloss.JoinHorizontal(lipgloss.Top, renderedStatus, " ", name))
-		} else if node.Typename == "StatusContext" {
-			statusContext := node.StatusContext
-			status := sidebar.renderStatusContextConclusion(statusContext)
-			checks = append(checks, lipgloss.JoinHorizontal(lipgloss.Top, status, " ", renderStatusContextName(statusContext)))
-		}
-	}
+	checks = append(checks, sidebar.renderCheckContexts(lastCommit.Commit.StatusCheckRollup.Contexts.Nodes)...)
 
 	if len(checks) == 0 {
 		return lipgloss.JoinVertical(
@@ -54,6 +45,69 @@ func (sidebar *Model) renderChecks() string {
 	)
 }
 
+// renderAllChecks renders every check of the PR's last commit, with how many
+// of them passed, failed or are still running.
+func (m *Model) renderAllChecks(contexts []data.CheckContext) string {
+	if len(contexts) == 0 {
+		return m.renderTabMessage("No checks to display...")
+	}
+
+	var passed, failed, pending int
+	for _, node := range contexts {
+		switch {
+		case data.IsStatusWaiting(checkContextStatus(node)):
+			pending++
+		case data.IsConclusionAFailure(checkContextConclusion(node)):
+			failed++
+		default:
+			passed++
+		}
+	}
+
+	summary := lipgloss.NewStyle().
+		Foreground(m.ctx.Theme.FaintText).
+		Render(fmt.Sprintf("%d passed · %d failed · %d pending", passed, failed, pending))
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		summary,
+		"",
+		lipgloss.NewStyle().
+			Width(m.getIndentedContentWidth()).
+			Render(lipgloss.JoinVertical(lipgloss.Left, m.renderCheckContexts(contexts)...)),
+	)
+}
+
+func (m *Model) renderCheckContexts(contexts []data.CheckContext) []string {
+	var checks []string
+	for _, node := range contexts {
+		if node.Typename == "CheckRun" {
+			checkRun := node.CheckRun
+			renderedStatus := m.renderCheckRunConclusion(checkRun)
+			name := renderCheckRunName(checkRun)
+			checks = append(checks, lipgloss.JoinHorizontal(lipgloss.Top, renderedStatus, " ", name))
+		} else if node.Typename == "StatusContext" {
+			statusContext := node.StatusContext
+			status := m.renderStatusContextConclusion(statusContext)
+			checks = append(checks, lipgloss.JoinHorizontal(lipgloss.Top, status, " ", renderStatusContextName(statusContext)))
+		}
+	}
+	return checks
+}
+
+func checkContextStatus(node data.CheckContext) string {
+	if node.Typename == "CheckRun" {
+		return string(node.CheckRun.Status)
+	}
+	return string(node.StatusContext.State)
+}
+
+func checkContextConclusion(node data.CheckContext) string {
+	if node.Typename == "CheckRun" {
+		return string(node.CheckRun.Conclusion)
+	}
+	return string(node.StatusContext.State)
+}
+
 func (m *Model) renderCheckRunConclusion(checkRun data.CheckRun) string {
 	conclusionStr := string(checkRun.Conclusion)
 	if data.IsStatusWaiting(string(checkRun.Status)) {
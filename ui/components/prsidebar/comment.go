@@ -2,56 +2,28 @@ package prsidebar
 
 import (
 	"fmt"
-	"os/exec"
-	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 
 	"github.com/dlvhdr/gh-dash/v4/data"
 	"github.com/dlvhdr/gh-dash/v4/ui/components/prssection"
 	"github.com/dlvhdr/gh-dash/v4/ui/components/tasks"
-	"github.com/dlvhdr/gh-dash/v4/ui/constants"
 	"github.com/dlvhdr/gh-dash/v4/ui/context"
 )
 
 func (m *Model) comment(body string) tea.Cmd {
 	pr := m.pr.Data
-	prNumber := pr.GetNumber()
-	taskId := fmt.Sprintf("pr_comment_%d", prNumber)
+	prNumber, prId := pr.GetNumber(), pr.Id
 	task := context.Task{
-		Id:           taskId,
+		Id:           fmt.Sprintf("pr_comment_%d", prNumber),
 		StartText:    fmt.Sprintf("Commenting on PR #%d", prNumber),
 		FinishedText: fmt.Sprintf("Commented on PR #%d", prNumber),
 		State:        context.TaskStart,
 		Error:        nil,
 	}
-	startCmd := m.ctx.StartTask(task)
-	return tea.Batch(startCmd, func() tea.Msg {
-		c := exec.Command(
-			"gh",
-			"pr",
-			"comment",
-			fmt.Sprint(prNumber),
-			"-R",
-			pr.GetRepoNameWithOwner(),
-			"-b",
-			body,
-		)
 
-		err := c.Run()
-		return constants.TaskFinishedMsg{
-			SectionId:   m.sectionId,
-			SectionType: prssection.SectionType,
-			TaskId:      taskId,
-			Err:         err,
-			Msg: tasks.UpdatePRMsg{
-				PrNumber: prNumber,
-				NewComment: &data.Comment{
-					Author:    struct{ Login string }{Login: m.ctx.User},
-					Body:      body,
-					UpdatedAt: time.Now(),
-				},
-			},
-		}
+	section := tasks.SectionIdentifer{Id: m.sectionId, Type: prssection.SectionType}
+	return tasks.Mutate(m.ctx, section, task, pr, "addComment", func() (tea.Msg, error) {
+		return tasks.UpdatedPRMsg(data.AddPullRequestComment(prId, body))
 	})
 }
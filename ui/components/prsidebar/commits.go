@@ -0,0 +1,47 @@
+package prsidebar
+
+import (
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/dlvhdr/gh-dash/v4/data"
+	"github.com/dlvhdr/gh-dash/v4/utils"
+)
+
+// renderCommits renders the commits of the PR, oldest first, with the state
+// of each commit's checks.
+func (m *Model) renderCommits(commits []data.PullRequestCommit) string {
+	if len(commits) == 0 {
+		return m.renderTabMessage("No commits...")
+	}
+
+	width := m.getIndentedContentWidth()
+	faint := lipgloss.NewStyle().Foreground(m.ctx.Theme.FaintText)
+	var rows []string
+	for _, commit := range commits {
+		status := m.renderCommitChecksState(commit.ChecksState())
+		oid := faint.Render(commit.AbbreviatedOid)
+		headline := lipgloss.NewStyle().
+			Width(width - lipgloss.Width(status) - lipgloss.Width(oid) - 2).
+			Render(commit.MessageHeadline)
+		rows = append(rows,
+			lipgloss.JoinHorizontal(lipgloss.Top, status, " ", oid, " ", headline),
+			faint.PaddingLeft(lipgloss.Width(status)+1).
+				Render(commit.AuthorName()+" · "+utils.TimeElapsed(commit.CommittedDate)),
+			"",
+		)
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, rows...)
+}
+
+func (m *Model) renderCommitChecksState(state string) string {
+	switch {
+	case state == "":
+		return lipgloss.NewStyle().Foreground(m.ctx.Theme.FaintText).Render("-")
+	case data.IsStatusWaiting(state) || state == "EXPECTED":
+		return m.ctx.Styles.Common.WaitingGlyph
+	case data.IsConclusionAFailure(state) || state == "ERROR":
+		return m.ctx.Styles.Common.FailureGlyph
+	default:
+		return m.ctx.Styles.Common.SuccessGlyph
+	}
+}
@@ -0,0 +1,145 @@
+package prsidebar
+
+import (
+	"errors"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/dlvhdr/gh-dash/v4/data"
+	"github.com/dlvhdr/gh-dash/v4/ui/components/prssection"
+	"github.com/dlvhdr/gh-dash/v4/ui/components/section"
+	"github.com/dlvhdr/gh-dash/v4/ui/components/tasks"
+	"github.com/dlvhdr/gh-dash/v4/ui/constants"
+	"github.com/dlvhdr/gh-dash/v4/ui/context"
+)
+
+// SetIsViewingFiles opens the list of the PR's files, fetching them if they
+// weren't fetched yet.
+func (m *Model) SetIsViewingFiles(isViewingFiles bool) tea.Cmd {
+	if m.pr == nil {
+		return nil
+	}
+
+	var cmd tea.Cmd
+	if !m.isViewingFiles && isViewingFiles {
+		if m.pr.Data.ChangedFiles == 0 {
+			return func() tea.Msg { return constants.ErrMsg{Err: errors.New("the PR has no changed files")} }
+		}
+		files := data.PullRequestFiles{TotalCount: m.pr.Data.ChangedFiles}
+		if m.files != nil {
+			files = *m.files
+		}
+		m.fileList.Open(files)
+		cmd = m.fetchDetails(FilesTab)
+	}
+	m.isViewingFiles = isViewingFiles
+	return cmd
+}
+
+// toggleFileViewed marks the file under the cursor as viewed, or as not
+// viewed if it already is. The section is updated right away and reverted if
+// the change can't be applied.
+func (m *Model) toggleFileViewed() tea.Cmd {
+	file, ok := m.fileList.CurrFile()
+	if !ok || m.files == nil {
+		return nil
+	}
+
+	pr := m.pr.Data
+	prNumber, prId, prUrl := pr.GetNumber(), pr.Id, pr.Url
+	viewed := !file.IsViewed()
+	original := *m.files
+	updated := data.PullRequestFiles{
+		Nodes:      append([]data.PullRequestFile{}, original.Nodes...),
+		TotalCount: original.TotalCount,
+	}
+	for i := range updated.Nodes {
+		if updated.Nodes[i].Path == file.Path {
+			updated.Nodes[i].ViewerViewedState = "UNVIEWED"
+			if viewed {
+				updated.Nodes[i].ViewerViewedState = "VIEWED"
+			}
+		}
+	}
+
+	state, viewedMutation := "viewed", "markFileAsViewed"
+	if !viewed {
+		state, viewedMutation = "not viewed", "unmarkFileAsViewed"
+	}
+	task := context.Task{
+		Id:           fmt.Sprintf("pr_viewed_%d_%s", prNumber, file.Path),
+		StartText:    fmt.Sprintf("Marking %s as %s", file.Path, state),
+		FinishedText: fmt.Sprintf("%s has been marked as %s", file.Path, state),
+		State:        context.TaskStart,
+		Error:        nil,
+	}
+
+	sid := tasks.SectionIdentifer{Id: m.sectionId, Type: prssection.SectionType}
+	return tea.Batch(m.updateFiles(prUrl, updated), tasks.Mutate(m.ctx, sid, task, pr, viewedMutation, func() (tea.Msg, error) {
+		var (
+			updatedPr data.PullRequestData
+			err       error
+		)
+		if viewed {
+			updatedPr, err = data.MarkFileAsViewed(prId, file.Path)
+		} else {
+			updatedPr, err = data.UnmarkFileAsViewed(prId, file.Path)
+		}
+		if err != nil {
+			return tasks.UpdatePRMsg{PrUrl: prUrl, Files: &original}, err
+		}
+		return tasks.UpdatedPRMsg(updatedPr, nil)
+	}))
+}
+
+// updateFiles sets the files of the PR at prUrl in the section, which keeps
+// them for the PR's row and passes them back with SetRow.
+func (m *Model) updateFiles(prUrl string, files data.PullRequestFiles) tea.Cmd {
+	sectionId := m.sectionId
+	return func() tea.Msg {
+		return section.SectionMsg{
+			Id:   sectionId,
+			Type: prssection.SectionType,
+			InternalMsg: tasks.UpdatePRMsg{
+				PrUrl: prUrl,
+				Files: &files,
+			},
+		}
+	}
+}
+
+// renderFiles renders the files changed by the PR with the lines added and
+// deleted in each one.
+func (m *Model) renderFiles() string {
+	if m.files == nil || len(m.files.Nodes) == 0 {
+		return m.renderTabMessage("No changed files...")
+	}
+
+	files := *m.files
+	width := m.getIndentedContentWidth()
+	faint := lipgloss.NewStyle().Foreground(m.ctx.Theme.FaintText)
+	additions := lipgloss.NewStyle().Foreground(m.ctx.Theme.SuccessText)
+	deletions := lipgloss.NewStyle().Foreground(m.ctx.Theme.ErrorText)
+
+	summary := faint.Render(fmt.Sprintf("%d files changed · %d of %d viewed · ",
+		files.TotalCount, files.ViewedCount(), files.TotalCount)) +
+		additions.Render(fmt.Sprintf("+%d", m.pr.Data.Additions)) + " " +
+		deletions.Render(fmt.Sprintf("-%d", m.pr.Data.Deletions))
+
+	rows := []string{lipgloss.NewStyle().Width(width).Render(summary), ""}
+	for _, file := range files.Nodes {
+		viewed := faint.Render("󰈉")
+		if file.IsViewed() {
+			viewed = m.ctx.Styles.Common.SuccessGlyph
+		}
+		stats := additions.Render(fmt.Sprintf("+%d", file.Additions)) + " " +
+			deletions.Render(fmt.Sprintf("-%d", file.Deletions))
+		path := lipgloss.NewStyle().
+			Width(width - lipgloss.Width(viewed) - lipgloss.Width(stats) - 2).
+			Render(file.Path)
+		rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, viewed, " ", path, " ", stats))
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, rows...)
+}
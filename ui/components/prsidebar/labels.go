@@ -0,0 +1,75 @@
+package prsidebar
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/dlvhdr/gh-dash/v4/data"
+	"github.com/dlvhdr/gh-dash/v4/ui/components/prssection"
+	"github.com/dlvhdr/gh-dash/v4/ui/components/section"
+	"github.com/dlvhdr/gh-dash/v4/ui/components/tasks"
+	"github.com/dlvhdr/gh-dash/v4/ui/context"
+)
+
+func (m *Model) GetIsLabeling() bool {
+	return m.isLabeling
+}
+
+func (m *Model) SetIsLabeling(isLabeling bool) tea.Cmd {
+	if m.pr == nil {
+		return nil
+	}
+
+	var cmd tea.Cmd
+	if !m.isLabeling && isLabeling {
+		cmd = m.labelPicker.Open(m.pr.Data.GetRepoNameWithOwner(), m.pr.Data.Labels.Nodes)
+	}
+	m.isLabeling = isLabeling
+	return cmd
+}
+
+// editLabels updates the labels in the section right away and reverts them
+// if the change can't be applied.
+func (m *Model) editLabels() tea.Cmd {
+	added := m.labelPicker.Added()
+	removed := m.labelPicker.Removed()
+	if len(added) == 0 && len(removed) == 0 {
+		return nil
+	}
+
+	pr := m.pr.Data
+	prNumber, prId, prUrl := pr.GetNumber(), pr.Id, pr.Url
+	repo := pr.GetRepoNameWithOwner()
+	sectionId := m.sectionId
+	original := append([]data.Label{}, pr.Labels.Nodes...)
+	updated := m.labelPicker.Apply(pr.Labels.Nodes)
+
+	task := context.Task{
+		Id:           fmt.Sprintf("pr_labels_%d", prNumber),
+		StartText:    fmt.Sprintf("Updating labels of pr #%d", prNumber),
+		FinishedText: fmt.Sprintf("Labels of pr #%d have been updated", prNumber),
+		State:        context.TaskStart,
+		Error:        nil,
+	}
+
+	optimisticCmd := func() tea.Msg {
+		return section.SectionMsg{
+			Id:   sectionId,
+			Type: prssection.SectionType,
+			InternalMsg: tasks.UpdatePRMsg{
+				PrUrl:  prUrl,
+				Labels: &updated,
+			},
+		}
+	}
+
+	sid := tasks.SectionIdentifer{Id: sectionId, Type: prssection.SectionType}
+	return tea.Batch(optimisticCmd, tasks.Mutate(m.ctx, sid, task, pr, tasks.LabelsMutation(added, removed), func() (tea.Msg, error) {
+		updatedPr, err := data.UpdatePullRequestLabels(prId, repo, added, removed)
+		if err != nil {
+			return tasks.UpdatePRMsg{PrUrl: prUrl, Labels: &original}, err
+		}
+		return tasks.UpdatedPRMsg(updatedPr, nil)
+	}))
+}
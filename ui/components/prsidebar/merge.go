@@ -0,0 +1,30 @@
+package prsidebar
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/dlvhdr/gh-dash/v4/ui/components/prssection"
+	"github.com/dlvhdr/gh-dash/v4/ui/components/tasks"
+)
+
+func (m *Model) GetIsMerging() bool {
+	return m.isMerging
+}
+
+func (m *Model) SetIsMerging(isMerging bool) tea.Cmd {
+	if m.pr == nil {
+		return nil
+	}
+
+	var cmd tea.Cmd
+	if !m.isMerging && isMerging {
+		cmd = m.mergeDialog.Open(m.pr.Data)
+	}
+	m.isMerging = isMerging
+	return cmd
+}
+
+func (m *Model) merge(opts tasks.MergeOptions) tea.Cmd {
+	section := tasks.SectionIdentifer{Id: m.sectionId, Type: prssection.SectionType}
+	return tasks.MergePRWithOptions(m.ctx, section, m.pr.Data, opts)
+}
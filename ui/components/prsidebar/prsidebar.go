@@ -8,11 +8,20 @@ import (
 	"github.com/charmbracelet/bubbles/textarea"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/log"
 
 	"github.com/dlvhdr/gh-dash/v4/data"
 	"github.com/dlvhdr/gh-dash/v4/ui/common"
+	"github.com/dlvhdr/gh-dash/v4/ui/components/filelist"
 	"github.com/dlvhdr/gh-dash/v4/ui/components/inputbox"
+	"github.com/dlvhdr/gh-dash/v4/ui/components/labelpicker"
+	"github.com/dlvhdr/gh-dash/v4/ui/components/linked"
+	"github.com/dlvhdr/gh-dash/v4/ui/components/localnote"
+	"github.com/dlvhdr/gh-dash/v4/ui/components/mergedialog"
 	"github.com/dlvhdr/gh-dash/v4/ui/components/pr"
+	"github.com/dlvhdr/gh-dash/v4/ui/components/tasklist"
+	"github.com/dlvhdr/gh-dash/v4/ui/components/timeline"
+	"github.com/dlvhdr/gh-dash/v4/ui/components/usercompletion"
 	"github.com/dlvhdr/gh-dash/v4/ui/context"
 	"github.com/dlvhdr/gh-dash/v4/ui/markdown"
 )
@@ -23,12 +32,30 @@ type Model struct {
 	pr        *pr.PullRequest
 	width     int
 
+	tab     Tab
+	offsets map[Tab]int
+	details map[string]*details
+
 	isCommenting  bool
 	isApproving   bool
 	isAssigning   bool
 	isUnassigning bool
-
-	inputBox inputbox.Model
+	isMerging     bool
+	isLabeling    bool
+
+	isEditingTasks bool
+	isViewingFiles bool
+	// files are the PR's changed files, or nil if they weren't fetched yet.
+	files *data.PullRequestFiles
+
+	isRequestingReview bool
+
+	inputBox    inputbox.Model
+	mergeDialog mergedialog.Model
+	labelPicker labelpicker.Model
+	taskList    tasklist.Model
+	fileList    filelist.Model
+	timeline    timeline.Model
 }
 
 func NewModel(ctx context.ProgramContext) Model {
@@ -38,12 +65,23 @@ func NewModel(ctx context.ProgramContext) Model {
 	return Model{
 		pr: nil,
 
+		tab:     OverviewTab,
+		offsets: map[Tab]int{},
+		details: map[string]*details{},
+
 		isCommenting:  false,
 		isApproving:   false,
 		isAssigning:   false,
 		isUnassigning: false,
-
-		inputBox: inputBox,
+		isMerging:     false,
+		isLabeling:    false,
+
+		inputBox:    inputBox,
+		mergeDialog: mergedialog.NewModel(&ctx),
+		labelPicker: labelpicker.NewModel(&ctx),
+		taskList:    tasklist.NewModel(&ctx),
+		fileList:    filelist.NewModel(&ctx),
+		timeline:    timeline.NewModel(&ctx),
 	}
 }
 
@@ -55,8 +93,116 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	)
 
 	switch msg := msg.(type) {
+	case DetailsFetchedMsg:
+		return m, m.onDetailsFetched(msg)
+
+	case mergedialog.SettingsFetchedMsg:
+		m.mergeDialog, cmd = m.mergeDialog.Update(msg)
+		return m, cmd
+
+	case labelpicker.LabelsFetchedMsg:
+		m.labelPicker, cmd = m.labelPicker.Update(msg)
+		return m, cmd
+
+	case usercompletion.FetchedMsg:
+		if m.pr == nil || msg.Url != m.pr.Data.GetUrl() {
+			return m, nil
+		}
+		if msg.Err != nil {
+			log.Error("Failed fetching user completions", "url", msg.Url, "err", msg.Err)
+			return m, nil
+		}
+		if m.isAssigning || m.isUnassigning || m.isRequestingReview {
+			m.inputBox.SetSuggestions(msg.Suggestions)
+		}
+		return m, nil
+
 	case tea.KeyMsg:
-		if m.isCommenting {
+		if m.isEditingTasks {
+			switch msg.Type {
+
+			case tea.KeyCtrlD:
+				cmd = m.saveTasks()
+				m.isEditingTasks = false
+				return m, cmd
+
+			case tea.KeyEsc, tea.KeyCtrlC:
+				m.isEditingTasks = false
+				return m, nil
+			}
+
+			m.taskList, cmd = m.taskList.Update(msg)
+			cmds = append(cmds, cmd)
+		} else if m.isViewingFiles {
+			switch msg.Type {
+
+			case tea.KeyEnter, tea.KeySpace:
+				return m, m.toggleFileViewed()
+
+			case tea.KeyEsc, tea.KeyCtrlC:
+				m.isViewingFiles = false
+				return m, nil
+			}
+
+			m.fileList, cmd = m.fileList.Update(msg)
+			cmds = append(cmds, cmd)
+		} else if m.isLabeling {
+			switch msg.Type {
+
+			case tea.KeyCtrlD:
+				if !m.labelPicker.IsReady() {
+					return m, nil
+				}
+				cmd = m.editLabels()
+				m.isLabeling = false
+				return m, cmd
+
+			case tea.KeyEsc, tea.KeyCtrlC:
+				m.isLabeling = false
+				return m, nil
+			}
+
+			m.labelPicker, cmd = m.labelPicker.Update(msg)
+			cmds = append(cmds, cmd)
+		} else if m.isRequestingReview {
+			switch msg.Type {
+
+			case tea.KeyCtrlD:
+				reviewers := strings.Fields(m.inputBox.Value())
+				if len(reviewers) > 0 {
+					cmd = m.requestReview(reviewers)
+				}
+				m.inputBox.Blur()
+				m.isRequestingReview = false
+				return m, cmd
+
+			case tea.KeyEsc, tea.KeyCtrlC:
+				m.inputBox.Blur()
+				m.isRequestingReview = false
+				return m, nil
+			}
+
+			m.inputBox, taCmd = m.inputBox.Update(msg)
+			cmds = append(cmds, cmd, taCmd)
+		} else if m.isMerging {
+			switch msg.Type {
+
+			case tea.KeyCtrlD:
+				if !m.mergeDialog.IsReady() {
+					return m, nil
+				}
+				cmd = m.merge(m.mergeDialog.Options())
+				m.isMerging = false
+				return m, cmd
+
+			case tea.KeyEsc, tea.KeyCtrlC:
+				m.isMerging = false
+				return m, nil
+			}
+
+			m.mergeDialog, cmd = m.mergeDialog.Update(msg)
+			cmds = append(cmds, cmd)
+		} else if m.isCommenting {
 			switch msg.Type {
 
 			case tea.KeyCtrlD:
@@ -151,7 +297,37 @@ func (m Model) View() string {
 	s.WriteString("\n")
 
 	s.WriteString(m.renderTitle())
-	s.WriteString("\n")
+	s.WriteString("\n\n")
+	s.WriteString(m.renderTabs())
+	s.WriteString("\n\n")
+	s.WriteString(m.renderTab())
+
+	if m.isCommenting || m.isApproving || m.isAssigning || m.isUnassigning || m.isRequestingReview {
+		s.WriteString(m.inputBox.View())
+	}
+
+	if m.isMerging {
+		s.WriteString(m.mergeDialog.View())
+	}
+
+	if m.isLabeling {
+		s.WriteString(m.labelPicker.View())
+	}
+
+	if m.isEditingTasks {
+		s.WriteString(m.taskList.View())
+	}
+
+	if m.isViewingFiles {
+		s.WriteString(m.fileList.View())
+	}
+
+	return s.String()
+}
+
+func (m *Model) renderOverview() string {
+	s := strings.Builder{}
+
 	s.WriteString(m.renderBranches())
 	s.WriteString("\n\n")
 	s.WriteString(m.renderPills())
@@ -163,6 +339,18 @@ func (m Model) View() string {
 		s.WriteString("\n\n")
 	}
 
+	note := localnote.View(m.ctx, m.getIndentedContentWidth(), m.pr.Data.Url)
+	if note != "" {
+		s.WriteString(note)
+		s.WriteString("\n\n")
+	}
+
+	closingIssues := linked.View(m.ctx, m.getIndentedContentWidth(), " Closes", linked.Issues, m.pr.Data.ClosingIssues)
+	if closingIssues != "" {
+		s.WriteString(closingIssues)
+		s.WriteString("\n\n")
+	}
+
 	s.WriteString(m.renderDescription())
 	s.WriteString("\n\n")
 	s.WriteString(m.renderChecks())
@@ -170,10 +358,6 @@ func (m Model) View() string {
 	s.WriteString("\n\n")
 	s.WriteString(m.renderActivity())
 
-	if m.isCommenting || m.isApproving || m.isAssigning || m.isUnassigning {
-		s.WriteString(m.inputBox.View())
-	}
-
 	return s.String()
 }
 
@@ -275,7 +459,33 @@ func (m *Model) renderPills() string {
 	mergeablePill := m.renderMergeablePill()
 	checksPill := m.renderChecksPill()
 	uptoDatePill := m.renderMergeStateStatusPill()
-	return lipgloss.JoinHorizontal(lipgloss.Top, statusPill, " ", mergeablePill, " ", checksPill, " ", uptoDatePill)
+	pills := lipgloss.JoinHorizontal(lipgloss.Top, statusPill, " ", mergeablePill, " ", checksPill, " ", uptoDatePill)
+	if viewedPill := m.renderViewedFilesPill(); viewedPill != "" {
+		pills = lipgloss.JoinVertical(lipgloss.Left, pills, "", viewedPill)
+	}
+	return pills
+}
+
+func (m *Model) renderViewedFilesPill() string {
+	files := m.pr.ViewedFiles()
+	if files.TotalCount == 0 {
+		return ""
+	}
+
+	s := m.ctx.Styles.PrSidebar.PillStyle
+	t := m.ctx.Theme
+	text := fmt.Sprintf("%s of %d files viewed", pr.ViewedCountText(files), files.TotalCount)
+	if files.ViewedProgress() == data.AllViewed {
+		return s.
+			Background(t.SuccessText).
+			Foreground(t.InvertedText).
+			Render("󰈈 " + text)
+	}
+
+	return s.
+		Background(t.FaintText).
+		Foreground(t.PrimaryText).
+		Render("󰈈 " + text)
 }
 
 func (m *Model) renderLabels() string {
@@ -289,7 +499,11 @@ func (m *Model) renderLabels() string {
 func (m *Model) renderDescription() string {
 	width := m.getIndentedContentWidth()
 	regex := regexp.MustCompile("(?U)<!--(.|[[:space:]])*-->")
-	body := regex.ReplaceAllString(m.pr.Data.Body, "")
+	body := m.pr.Data.Body
+	if m.isEditingTasks {
+		body = m.taskList.HighlightedBody()
+	}
+	body = regex.ReplaceAllString(body, "")
 
 	regex = regexp.MustCompile(`((\n)+|^)([^\r\n]*\|[^\r\n]*(\n)?)+`)
 	body = regex.ReplaceAllString(body, "")
@@ -316,21 +530,37 @@ func (m *Model) SetSectionId(id int) {
 	m.sectionId = id
 }
 
-func (m *Model) SetRow(data *data.PullRequestData) {
+// SetRow shows the PR in the sidebar along with its files if they were
+// fetched. The data of its tabs is fetched with FetchTab once they're shown.
+func (m *Model) SetRow(data *data.PullRequestData, files *data.PullRequestFiles) {
 	if data == nil {
 		m.pr = nil
-	} else {
-		m.pr = &pr.PullRequest{Ctx: m.ctx, Data: data}
+		m.files = nil
+		return
+	}
+
+	if m.pr == nil || m.pr.Data.GetUrl() != data.GetUrl() {
+		m.offsets = map[Tab]int{}
+	}
+	m.pr = &pr.PullRequest{Ctx: m.ctx, Data: data, Files: files}
+	m.files = files
+	if files != nil {
+		m.fileList.SetFiles(*files)
 	}
 }
 
 func (m *Model) SetWidth(width int) {
 	m.width = width
 	m.inputBox.SetWidth(width)
+	m.mergeDialog.SetWidth(width)
+	m.labelPicker.SetWidth(width)
+	m.fileList.SetWidth(width)
+	m.timeline.SetWidth(m.getIndentedContentWidth())
 }
 
 func (m *Model) IsTextInputBoxFocused() bool {
-	return m.isCommenting || m.isAssigning || m.isApproving || m.isUnassigning
+	return m.isCommenting || m.isAssigning || m.isApproving || m.isUnassigning || m.isMerging || m.isLabeling ||
+		m.isRequestingReview || m.isEditingTasks || m.isViewingFiles
 }
 
 func (m *Model) GetIsCommenting() bool {
@@ -340,6 +570,22 @@ func (m *Model) GetIsCommenting() bool {
 func (m *Model) UpdateProgramContext(ctx *context.ProgramContext) {
 	m.ctx = ctx
 	m.inputBox.UpdateProgramContext(ctx)
+	m.mergeDialog.UpdateProgramContext(ctx)
+	m.labelPicker.UpdateProgramContext(ctx)
+	m.taskList.UpdateProgramContext(ctx)
+	m.fileList.UpdateProgramContext(ctx)
+	m.timeline.UpdateProgramContext(ctx)
+}
+
+// CycleActivityFilter shows the next kind of items in the activity tab.
+func (m *Model) CycleActivityFilter() {
+	m.timeline.CycleFilter()
+}
+
+// ToggleBotActivity expands or collapses the items by bots in the activity
+// tab.
+func (m *Model) ToggleBotActivity() {
+	m.timeline.ToggleBots()
 }
 
 func (m *Model) SetIsCommenting(isCommenting bool) tea.Cmd {
@@ -392,7 +638,10 @@ func (m *Model) SetIsAssigning(isAssigning bool) tea.Cmd {
 	}
 
 	if isAssigning {
-		return tea.Sequence(textarea.Blink, m.inputBox.Focus())
+		return tea.Batch(
+			tea.Sequence(textarea.Blink, m.inputBox.Focus()),
+			m.fetchUserCompletions(usercompletion.Options{}),
+		)
 	}
 	return nil
 }
@@ -419,11 +668,18 @@ func (m *Model) SetIsUnassigning(isUnassigning bool) tea.Cmd {
 	m.inputBox.SetValue(strings.Join(m.prAssignees(), "\n"))
 
 	if isUnassigning {
-		return tea.Sequence(textarea.Blink, m.inputBox.Focus())
+		return tea.Batch(
+			tea.Sequence(textarea.Blink, m.inputBox.Focus()),
+			m.fetchUserCompletions(usercompletion.Options{Suggested: m.prAssignees()}),
+		)
 	}
 	return nil
 }
 
+func (m *Model) fetchUserCompletions(opts usercompletion.Options) tea.Cmd {
+	return usercompletion.Fetch(m.pr.Data.GetRepoNameWithOwner(), m.pr.Data.GetUrl(), opts)
+}
+
 func (m *Model) prAssignees() []string {
 	var assignees []string
 	for _, n := range m.pr.Data.Assignees.Nodes {
@@ -0,0 +1,58 @@
+package prsidebar
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/dlvhdr/gh-dash/v4/data"
+	"github.com/dlvhdr/gh-dash/v4/ui/components/prssection"
+	"github.com/dlvhdr/gh-dash/v4/ui/components/tasks"
+	"github.com/dlvhdr/gh-dash/v4/ui/components/usercompletion"
+	"github.com/dlvhdr/gh-dash/v4/ui/context"
+)
+
+func (m *Model) GetIsRequestingReview() bool {
+	return m.isRequestingReview
+}
+
+func (m *Model) SetIsRequestingReview(isRequestingReview bool) tea.Cmd {
+	if m.pr == nil {
+		return nil
+	}
+
+	if !m.isRequestingReview && isRequestingReview {
+		m.inputBox.Reset()
+	}
+	m.isRequestingReview = isRequestingReview
+	m.inputBox.SetPrompt("Request reviews from users or teams (whitespace-separated)...")
+
+	if isRequestingReview {
+		return tea.Batch(
+			tea.Sequence(textarea.Blink, m.inputBox.Focus()),
+			m.fetchUserCompletions(usercompletion.Options{
+				IncludeTeams:     true,
+				SuggestReviewers: true,
+			}),
+		)
+	}
+	return nil
+}
+
+func (m *Model) requestReview(reviewers []string) tea.Cmd {
+	pr := m.pr.Data
+	prNumber, prId := pr.GetNumber(), pr.Id
+	task := context.Task{
+		Id:           fmt.Sprintf("pr_request_review_%d", prNumber),
+		StartText:    fmt.Sprintf("Requesting reviews on pr #%d from %s", prNumber, reviewers),
+		FinishedText: fmt.Sprintf("Reviews on pr #%d have been requested from %s", prNumber, reviewers),
+		State:        context.TaskStart,
+		Error:        nil,
+	}
+
+	section := tasks.SectionIdentifer{Id: m.sectionId, Type: prssection.SectionType}
+	return tasks.Mutate(m.ctx, section, task, pr, "requestReviews", func() (tea.Msg, error) {
+		return tasks.UpdatedPRMsg(data.RequestReviews(prId, reviewers))
+	})
+}
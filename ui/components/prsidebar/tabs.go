@@ -0,0 +1,218 @@
+package prsidebar
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/dlvhdr/gh-dash/v4/data"
+	"github.com/dlvhdr/gh-dash/v4/ui/constants"
+)
+
+type Tab int
+
+const (
+	OverviewTab Tab = iota
+	ActivityTab
+	ChecksTab
+	FilesTab
+	CommitsTab
+	numTabs
+)
+
+var tabTitles = [numTabs]string{
+	OverviewTab: "Overview",
+	ActivityTab: "Activity",
+	ChecksTab:   "Checks",
+	FilesTab:    "Files",
+	CommitsTab:  "Commits",
+}
+
+func (tab Tab) String() string {
+	return tabTitles[tab]
+}
+
+// details holds the data of a PR that's only fetched once a tab that shows it
+// is opened. It's dropped when the PR is updated.
+type details struct {
+	updatedAt time.Time
+	fetching  map[Tab]bool
+	fetched   map[Tab]bool
+	errs      map[Tab]error
+
+	timeline []data.PullRequestTimelineItem
+	checks   []data.CheckContext
+	commits  []data.PullRequestCommit
+}
+
+// DetailsFetchedMsg has the data of tab of the PR at Url, as it was when the
+// PR was last updated at UpdatedAt.
+type DetailsFetchedMsg struct {
+	Url       string
+	UpdatedAt time.Time
+	Tab       Tab
+	Timeline  []data.PullRequestTimelineItem
+	Checks    []data.CheckContext
+	Files     data.PullRequestFiles
+	Commits   []data.PullRequestCommit
+	Err       error
+}
+
+func (m *Model) getDetails() *details {
+	url := m.pr.Data.GetUrl()
+	d, ok := m.details[url]
+	if !ok || !d.updatedAt.Equal(m.pr.Data.UpdatedAt) {
+		d = &details{
+			updatedAt: m.pr.Data.UpdatedAt,
+			fetching:  map[Tab]bool{},
+			fetched:   map[Tab]bool{},
+			errs:      map[Tab]error{},
+		}
+		m.details[url] = d
+	}
+	return d
+}
+
+// needsFiles reports whether every file of the PR wasn't fetched yet. Once
+// they are, the section keeps them and passes them back with SetRow.
+func (m *Model) needsFiles() bool {
+	return m.files == nil && m.pr.Data.ChangedFiles > 0
+}
+
+// isFetched reports whether the data of tab was fetched.
+func (m *Model) isFetched(tab Tab) bool {
+	if tab == FilesTab {
+		return !m.needsFiles()
+	}
+	return m.getDetails().fetched[tab]
+}
+
+// FetchTab fetches the data of the current tab if it wasn't fetched yet.
+func (m *Model) FetchTab() tea.Cmd {
+	return m.fetchDetails(m.tab)
+}
+
+// fetchDetails fetches the data of tab if it wasn't fetched yet.
+func (m *Model) fetchDetails(tab Tab) tea.Cmd {
+	if m.pr == nil || tab == OverviewTab || (tab == FilesTab && !m.needsFiles()) {
+		return nil
+	}
+
+	d := m.getDetails()
+	if d.fetching[tab] || d.fetched[tab] {
+		return nil
+	}
+	d.fetching[tab] = true
+	delete(d.errs, tab)
+
+	url, updatedAt := m.pr.Data.GetUrl(), m.pr.Data.UpdatedAt
+	return func() tea.Msg {
+		msg := DetailsFetchedMsg{Url: url, UpdatedAt: updatedAt, Tab: tab}
+		switch tab {
+		case ActivityTab:
+			msg.Timeline, msg.Err = data.FetchPullRequestTimeline(url)
+		case ChecksTab:
+			msg.Checks, msg.Err = data.FetchPullRequestChecks(url)
+		case FilesTab:
+			msg.Files, msg.Err = data.FetchPullRequestFiles(url)
+		case CommitsTab:
+			msg.Commits, msg.Err = data.FetchPullRequestCommits(url)
+		}
+		return msg
+	}
+}
+
+func (m *Model) onDetailsFetched(msg DetailsFetchedMsg) tea.Cmd {
+	d, ok := m.details[msg.Url]
+	if !ok || !d.updatedAt.Equal(msg.UpdatedAt) {
+		return nil
+	}
+
+	d.fetching[msg.Tab] = false
+	if msg.Err != nil {
+		d.errs[msg.Tab] = msg.Err
+		if msg.Tab == FilesTab && m.isViewingFiles {
+			m.isViewingFiles = false
+			err := fmt.Errorf("failed fetching the PR's files: %w", msg.Err)
+			return func() tea.Msg { return constants.ErrMsg{Err: err} }
+		}
+		return nil
+	}
+	d.fetched[msg.Tab] = true
+	switch msg.Tab {
+	case ActivityTab:
+		d.timeline = msg.Timeline
+	case ChecksTab:
+		d.checks = msg.Checks
+	case FilesTab:
+		return m.updateFiles(msg.Url, msg.Files)
+	case CommitsTab:
+		d.commits = msg.Commits
+	}
+	return nil
+}
+
+// SwitchTab shows the tab delta tabs after the current one, wrapping around.
+// offset is how far the current tab is scrolled, which is restored when it's
+// shown again.
+func (m *Model) SwitchTab(delta int, offset int) tea.Cmd {
+	m.offsets[m.tab] = offset
+	m.tab = (m.tab + Tab(delta%int(numTabs)) + numTabs) % numTabs
+	return m.FetchTab()
+}
+
+// ScrollOffset returns how far the current tab was scrolled when it was last
+// shown.
+func (m *Model) ScrollOffset() int {
+	return m.offsets[m.tab]
+}
+
+func (m *Model) renderTabs() string {
+	tabs := make([]string, 0, numTabs)
+	for tab := OverviewTab; tab < numTabs; tab++ {
+		style := m.ctx.Styles.PrSidebar.Tab
+		if tab == m.tab {
+			style = m.ctx.Styles.PrSidebar.ActiveTab
+		}
+		tabs = append(tabs, style.Render(tab.String()))
+	}
+	return lipgloss.NewStyle().
+		Width(m.getIndentedContentWidth()).
+		Render(lipgloss.JoinHorizontal(lipgloss.Top, tabs...))
+}
+
+// renderTab renders the current tab, or why its data isn't shown yet.
+func (m *Model) renderTab() string {
+	if m.tab == OverviewTab {
+		return m.renderOverview()
+	}
+
+	d := m.getDetails()
+	if err := d.errs[m.tab]; err != nil {
+		return m.renderTabMessage(fmt.Sprintf("Failed fetching %s: %v", m.tab, err))
+	}
+	if !m.isFetched(m.tab) {
+		return m.renderTabMessage("Loading...")
+	}
+
+	switch m.tab {
+	case ActivityTab:
+		return m.timeline.View(d.timeline)
+	case ChecksTab:
+		return m.renderAllChecks(d.checks)
+	case FilesTab:
+		return m.renderFiles()
+	case CommitsTab:
+		return m.renderCommits(d.commits)
+	}
+	return ""
+}
+
+func (m *Model) renderTabMessage(text string) string {
+	return lipgloss.NewStyle().
+		Italic(true).
+		Width(m.getIndentedContentWidth()).
+		Render(text)
+}
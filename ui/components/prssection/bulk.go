@@ -0,0 +1,172 @@
+package prssection
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/dlvhdr/gh-dash/v4/data"
+	"github.com/dlvhdr/gh-dash/v4/ui/components/tasks"
+)
+
+func (m *Model) GetRows() []data.RowData {
+	return prRows(m.getFilteredPrs())
+}
+
+// getSelectedRows returns the selected PRs, including the ones the filter
+// hides.
+func (m *Model) getSelectedRows() []data.RowData {
+	var rows []data.RowData
+	for _, row := range prRows(m.getVisiblePrs()) {
+		if m.IsSelected(row) {
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
+
+func prRows(prs []data.PullRequestData) []data.RowData {
+	rows := make([]data.RowData, 0, len(prs))
+	for _, pr := range prs {
+		pr := pr
+		rows = append(rows, &pr)
+	}
+	return rows
+}
+
+// isBulkAction reports whether action runs on all selected PRs, rather than
+// only the current one.
+func isBulkAction(action string) bool {
+	switch action {
+	case "close", "reopen", "approve", "merge", "labels", "assign", "unassign":
+		return true
+	}
+	return false
+}
+
+// bulk runs the confirmation action on all selected PRs. input is the answer
+// to the bulk prompt.
+func (m *Model) bulk(action string, input string) tea.Cmd {
+	rows := m.getSelectedRows()
+	n := len(rows)
+	confirmed := input == "Y" || input == "y"
+
+	var task tasks.BulkTask
+	switch action {
+	case "close", "reopen":
+		if !confirmed {
+			return nil
+		}
+		isClosed := action == "close"
+		task.StartText = fmt.Sprintf("Closing %d PRs", n)
+		task.FinishedText = fmt.Sprintf("%d PRs have been closed", n)
+		if !isClosed {
+			task.StartText = fmt.Sprintf("Reopening %d PRs", n)
+			task.FinishedText = fmt.Sprintf("%d PRs have been reopened", n)
+		}
+		mutation := data.ReopenPullRequest
+		task.Mutation = "reopenPullRequest"
+		if isClosed {
+			mutation = data.ClosePullRequest
+			task.Mutation = "closePullRequest"
+		}
+		task.Action = func(row data.RowData) (tea.Msg, error) {
+			return tasks.UpdatedPRMsg(mutation(row.(*data.PullRequestData).Id))
+		}
+
+	case "approve":
+		if !confirmed {
+			return nil
+		}
+		task.StartText = fmt.Sprintf("Approving %d PRs", n)
+		task.FinishedText = fmt.Sprintf("%d PRs have been approved", n)
+		task.Mutation = "addPullRequestReview"
+		task.Action = func(row data.RowData) (tea.Msg, error) {
+			return tasks.UpdatedPRMsg(data.ApprovePullRequest(row.(*data.PullRequestData).Id, ""))
+		}
+
+	case "merge":
+		if !confirmed {
+			return nil
+		}
+		task.StartText = fmt.Sprintf("Merging %d PRs", n)
+		task.FinishedText = fmt.Sprintf("%d PRs have been merged", n)
+		task.Mutation = "mergePullRequest"
+		task.Action = mergeWithDefaultMethod()
+
+	case "labels":
+		added, removed := tasks.ParseLabelChanges(input)
+		if len(added) == 0 && len(removed) == 0 {
+			return nil
+		}
+		task.StartText = fmt.Sprintf("Updating labels of %d PRs", n)
+		task.FinishedText = fmt.Sprintf("Labels of %d PRs have been updated", n)
+		task.Mutation = tasks.LabelsMutation(added, removed)
+		task.Action = editLabels(added, removed)
+
+	case "assign", "unassign":
+		logins := strings.Fields(input)
+		if len(logins) == 0 {
+			return nil
+		}
+		added, removed := logins, []string(nil)
+		task.StartText = fmt.Sprintf("Assigning %d PRs", n)
+		task.FinishedText = fmt.Sprintf("%d PRs have been assigned", n)
+		task.Mutation = "addAssigneesToAssignable"
+		if action == "unassign" {
+			task.Mutation = "removeAssigneesFromAssignable"
+			added, removed = nil, logins
+			task.StartText = fmt.Sprintf("Unassigning %d PRs", n)
+			task.FinishedText = fmt.Sprintf("%d PRs have been unassigned", n)
+		}
+		task.Action = func(row data.RowData) (tea.Msg, error) {
+			pr := row.(*data.PullRequestData)
+			return tasks.UpdatedPRMsg(data.UpdatePullRequestAssignees(pr.Id, added, removed))
+		}
+
+	default:
+		return nil
+	}
+
+	task.Id = fmt.Sprintf("pr_bulk_%s_%d", action, m.Id)
+	task.Section = tasks.SectionIdentifer{Id: m.Id, Type: SectionType}
+	task.Rows = rows
+	return tasks.RunBulkTask(m.Ctx, task)
+}
+
+// mergeWithDefaultMethod merges each PR with its repository's default merge
+// method, or adds it to the merge queue if its base branch has one.
+func mergeWithDefaultMethod() tasks.BulkAction {
+	settings := map[string]data.RepositoryMergeSettings{}
+	return func(row data.RowData) (tea.Msg, error) {
+		pr := row.(*data.PullRequestData)
+		key := pr.GetRepoNameWithOwner() + ":" + pr.BaseRefName
+		s, ok := settings[key]
+		if !ok {
+			var err error
+			s, err = data.FetchRepositoryMergeSettings(pr.GetRepoNameWithOwner(), pr.BaseRefName)
+			if err != nil {
+				return nil, err
+			}
+			settings[key] = s
+		}
+
+		if s.IsMergeQueueEnabled() {
+			return tasks.UpdatedPRMsg(data.EnqueuePullRequest(pr.Id))
+		}
+
+		method := s.DefaultMergeMethod()
+		if method == "" {
+			return nil, fmt.Errorf("no merge methods are allowed in %s", pr.GetRepoNameWithOwner())
+		}
+		return tasks.UpdatedPRMsg(data.MergePullRequest(pr.Id, data.MergeOptions{Method: method}))
+	}
+}
+
+func editLabels(added []string, removed []string) tasks.BulkAction {
+	return func(row data.RowData) (tea.Msg, error) {
+		pr := row.(*data.PullRequestData)
+		return tasks.UpdatedPRMsg(data.UpdatePullRequestLabels(pr.Id, pr.GetRepoNameWithOwner(), added, removed))
+	}
+}
@@ -1,28 +1,18 @@
 package prssection
 
 import (
-	"fmt"
-	"os/exec"
-
 	tea "github.com/charmbracelet/bubbletea"
-	"github.com/dlvhdr/gh-dash/v4/ui/constants"
+
+	"github.com/dlvhdr/gh-dash/v4/data"
+	"github.com/dlvhdr/gh-dash/v4/ui/components/diffview"
 )
 
 func (m Model) diff() tea.Cmd {
-	c := exec.Command(
-		"gh",
-		"pr",
-		"diff",
-		fmt.Sprint(m.GetCurrRow().GetNumber()),
-		"-R",
-		m.GetCurrRow().GetRepoNameWithOwner(),
-	)
-	c.Env = m.Ctx.Config.GetFullScreenDiffPagerEnv()
-
-	return tea.ExecProcess(c, func(err error) tea.Msg {
-		if err != nil {
-			return constants.ErrMsg{Err: err}
-		}
+	pr, ok := m.GetCurrRow().(*data.PullRequestData)
+	if !ok {
 		return nil
-	})
+	}
+	return func() tea.Msg {
+		return diffview.OpenMsg{Pr: pr}
+	}
 }
@@ -0,0 +1,121 @@
+package prssection
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/dlvhdr/gh-dash/v4/config"
+	"github.com/dlvhdr/gh-dash/v4/data"
+	"github.com/dlvhdr/gh-dash/v4/ui/components/table"
+)
+
+var draftQualifierRegex = regexp.MustCompile(`^(-?)draft:(true|false)$`)
+
+// ParseDraftFilter returns whether filters only match draft PRs. ok is false
+// when filters don't use the draft: qualifier.
+func ParseDraftFilter(filters string) (isDraft bool, ok bool) {
+	for _, field := range strings.Fields(filters) {
+		matches := draftQualifierRegex.FindStringSubmatch(field)
+		if matches == nil {
+			continue
+		}
+		isDraft = matches[2] == "true"
+		if matches[1] == "-" {
+			isDraft = !isDraft
+		}
+		ok = true
+	}
+	return isDraft, ok
+}
+
+// WithoutDraftFilter returns filters without the draft: qualifier, with the
+// remaining qualifiers separated by single spaces.
+func WithoutDraftFilter(filters string) string {
+	fields := slices.DeleteFunc(strings.Fields(filters), func(field string) bool {
+		return draftQualifierRegex.MatchString(field)
+	})
+	return strings.Join(fields, " ")
+}
+
+func (m *Model) matchesDraftFilter(pr data.PullRequestData) bool {
+	isDraft, ok := ParseDraftFilter(m.GetFilters())
+	return !ok || pr.IsDraft == isDraft
+}
+
+// getVisiblePrs returns the PRs the table's rows are built from, leaving out
+// drafts when the section is configured to hide them.
+func (m *Model) getVisiblePrs() []data.PullRequestData {
+	hideDrafts := m.drafts == config.DraftsHide
+	if !hideDrafts && !m.hidesTriaged() {
+		return m.Prs
+	}
+
+	prs := make([]data.PullRequestData, 0, len(m.Prs))
+	for _, pr := range m.Prs {
+		if hideDrafts && pr.IsDraft {
+			continue
+		}
+		if m.hidesTriaged() && m.Ctx.Triage.IsHidden(pr.Url, pr.Author.Login, pr.UpdatedAt) {
+			continue
+		}
+		prs = append(prs, pr)
+	}
+	return prs
+}
+
+// numHidden returns how many of the fetched PRs aren't shown, as they're
+// drafts the section hides or they were snoozed or muted.
+func (m *Model) numHidden() int {
+	return len(m.Prs) - len(m.getVisiblePrs())
+}
+
+// getFilteredPrs returns the visible PRs whose rows match the table's
+// filter.
+func (m *Model) getFilteredPrs() []data.PullRequestData {
+	return table.Visible(&m.Table, m.getVisiblePrs())
+}
+
+// GetPr returns a copy of the PR with the given url, or nil if the section
+// doesn't have it.
+func (m *Model) GetPr(url string) *data.PullRequestData {
+	for _, pr := range m.Prs {
+		if pr.Url == url {
+			return &pr
+		}
+	}
+	return nil
+}
+
+// AddPr adds pr to the section, keeping the PRs sorted by when they were last
+// updated. It's a no-op if the section's draft: filter doesn't match pr.
+func (m *Model) AddPr(pr data.PullRequestData) {
+	if !m.matchesDraftFilter(pr) || m.GetPr(pr.Url) != nil {
+		return
+	}
+
+	i := slices.IndexFunc(m.Prs, func(curr data.PullRequestData) bool {
+		return curr.UpdatedAt.Before(pr.UpdatedAt)
+	})
+	if i == -1 {
+		i = len(m.Prs)
+	}
+	m.Prs = slices.Insert(m.Prs, i, pr)
+	m.TotalCount++
+	m.UpdateTotalItemsCount(m.TotalCount)
+	m.Table.SetRows(m.BuildRows())
+}
+
+func (m *Model) removePr(i int) {
+	m.Prs = slices.Delete(m.Prs, i, i+1)
+	m.TotalCount--
+	m.UpdateTotalItemsCount(m.TotalCount)
+}
+
+// clampCurrItem moves the selection back onto the last row when the row it
+// was on is no longer shown.
+func (m *Model) clampCurrItem() {
+	for m.Table.GetCurrItem() > 0 && m.Table.GetCurrItem() >= m.NumRows() {
+		m.Table.PrevItem()
+	}
+}
@@ -0,0 +1,54 @@
+package prssection_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dlvhdr/gh-dash/v4/ui/components/prssection"
+)
+
+func TestParseDraftFilter(t *testing.T) {
+	testCases := map[string]struct {
+		filters     string
+		wantIsDraft bool
+		wantOk      bool
+	}{
+		"no draft qualifier": {
+			filters: "is:open author:@me",
+		},
+		"drafts only": {
+			filters:     "is:open draft:true author:@me",
+			wantIsDraft: true,
+			wantOk:      true,
+		},
+		"non-drafts only": {
+			filters: "is:open draft:false",
+			wantOk:  true,
+		},
+		"negated qualifier": {
+			filters: "is:open -draft:true",
+			wantOk:  true,
+		},
+		"qualifier in another value": {
+			filters: "is:open label:draft:true",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			isDraft, ok := prssection.ParseDraftFilter(tc.filters)
+			require.Equal(t, tc.wantOk, ok)
+			require.Equal(t, tc.wantIsDraft, isDraft)
+		})
+	}
+}
+
+func TestWithoutDraftFilter(t *testing.T) {
+	require.Equal(t, "is:open author:@me", prssection.WithoutDraftFilter("is:open  draft:true\nauthor:@me"))
+	require.Equal(
+		t,
+		prssection.WithoutDraftFilter("is:open author:@me draft:false"),
+		prssection.WithoutDraftFilter("is:open -draft:true author:@me"),
+	)
+}
@@ -0,0 +1,47 @@
+package prssection
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/dlvhdr/gh-dash/v4/data"
+	"github.com/dlvhdr/gh-dash/v4/ui/common"
+	"github.com/dlvhdr/gh-dash/v4/ui/components/tasks"
+	"github.com/dlvhdr/gh-dash/v4/ui/constants"
+)
+
+type prEditedMsg struct {
+	pr      *data.PullRequestData
+	content string
+	err     error
+}
+
+// edit opens the PR's title and body in the user's editor.
+func (m *Model) edit() tea.Cmd {
+	row := m.GetCurrRow()
+	if row == nil {
+		return nil
+	}
+	pr := row.(*data.PullRequestData)
+
+	content := common.FormatTitleAndBody(pr.Title, pr.Body)
+	return common.EditInEditor(content, "*.md", func(content string, err error) tea.Msg {
+		return prEditedMsg{pr: pr, content: content, err: err}
+	})
+}
+
+func (m *Model) onPrEdited(msg prEditedMsg) tea.Cmd {
+	if msg.err != nil {
+		return func() tea.Msg { return constants.ErrMsg{Err: msg.err} }
+	}
+
+	title, body, err := common.EditedTitleAndBody(msg.content, msg.pr.Title, msg.pr.Body)
+	if err != nil {
+		return func() tea.Msg { return constants.ErrMsg{Err: err} }
+	}
+	if title == nil && body == nil {
+		return nil
+	}
+
+	sid := tasks.SectionIdentifer{Id: m.Id, Type: SectionType}
+	return tasks.EditPR(m.Ctx, sid, msg.pr, title, body)
+}
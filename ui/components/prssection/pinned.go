@@ -0,0 +1,37 @@
+package prssection
+
+import (
+	"time"
+
+	"github.com/dlvhdr/gh-dash/v4/config"
+	"github.com/dlvhdr/gh-dash/v4/data"
+	"github.com/dlvhdr/gh-dash/v4/ui/context"
+)
+
+// NewPinnedModel returns the section of the pinned PRs, which is always the
+// first one after the search section.
+func NewPinnedModel(ctx *context.ProgramContext, lastUpdated time.Time) Model {
+	m := NewModel(
+		1,
+		ctx,
+		config.PrsSectionConfig{Title: context.PinnedSectionConfig.Title},
+		lastUpdated,
+	)
+	m.isPinned = true
+	return m
+}
+
+// fetchPinnedPrs fetches the pinned PRs, in the order they were pinned in.
+func (m *Model) fetchPinnedPrs() (data.PullRequestsResponse, error) {
+	prs, err := data.FetchPullRequestsByIds(m.Ctx.Triage.PinnedIds(true))
+	if err != nil {
+		return data.PullRequestsResponse{}, err
+	}
+	return data.PullRequestsResponse{Prs: prs, TotalCount: len(prs)}, nil
+}
+
+// hidesTriaged reports whether the section hides snoozed and muted PRs. The
+// search and pinned sections show every PR they have.
+func (m *Model) hidesTriaged() bool {
+	return m.Id != 0 && !m.isPinned
+}
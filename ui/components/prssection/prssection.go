@@ -10,12 +10,14 @@ import (
 	"github.com/dlvhdr/gh-dash/v4/config"
 	"github.com/dlvhdr/gh-dash/v4/data"
 	"github.com/dlvhdr/gh-dash/v4/ui/components/pr"
+	"github.com/dlvhdr/gh-dash/v4/ui/components/search"
 	"github.com/dlvhdr/gh-dash/v4/ui/components/section"
 	"github.com/dlvhdr/gh-dash/v4/ui/components/table"
 	"github.com/dlvhdr/gh-dash/v4/ui/components/tasks"
 	"github.com/dlvhdr/gh-dash/v4/ui/constants"
 	"github.com/dlvhdr/gh-dash/v4/ui/context"
 	"github.com/dlvhdr/gh-dash/v4/ui/keys"
+	"github.com/dlvhdr/gh-dash/v4/ui/triage"
 	"github.com/dlvhdr/gh-dash/v4/utils"
 )
 
@@ -23,7 +25,13 @@ const SectionType = "pr"
 
 type Model struct {
 	section.BaseModel
-	Prs []data.PullRequestData
+	Prs    []data.PullRequestData
+	drafts config.DraftsDisplay
+	// files are the changed files of the PRs that were opened, by URL. The
+	// section's search doesn't include them.
+	files map[string]data.PullRequestFiles
+	// isPinned is set on the section of the pinned PRs.
+	isPinned bool
 }
 
 func NewModel(
@@ -46,6 +54,8 @@ func NewModel(
 		},
 	)
 	m.Prs = []data.PullRequestData{}
+	m.drafts = cfg.Drafts
+	m.files = map[string]data.PullRequestFiles{}
 
 	return m
 }
@@ -67,6 +77,10 @@ func (m Model) Update(msg tea.Msg) (section.Section, tea.Cmd) {
 				return &m, blinkCmd
 
 			case msg.Type == tea.KeyEnter:
+				if !m.SearchBar.ConfirmSubmit() {
+					return &m, nil
+				}
+				m.SearchBar.AddToHistory()
 				m.SearchValue = m.SearchBar.Value()
 				m.SetIsSearching(false)
 				m.ResetRows()
@@ -76,6 +90,11 @@ func (m Model) Update(msg tea.Msg) (section.Section, tea.Cmd) {
 			break
 		}
 
+		if m.IsFilterFocused() {
+			cmd = m.UpdateFilter(msg)
+			break
+		}
+
 		if m.IsPromptConfirmationFocused() {
 			switch {
 
@@ -87,18 +106,22 @@ func (m Model) Update(msg tea.Msg) (section.Section, tea.Cmd) {
 			case msg.Type == tea.KeyEnter:
 				input := m.PromptConfirmationBox.Value()
 				action := m.GetPromptConfirmationAction()
-				pr := m.GetCurrRow()
+				pr, _ := m.GetCurrRow().(*data.PullRequestData)
 				sid := tasks.SectionIdentifer{Id: m.Id, Type: SectionType}
-				if input == "Y" || input == "y" {
+				if action == "save_section" {
+					cmd = m.saveSection(input)
+				} else if m.NumSelected() > 0 && isBulkAction(action) {
+					cmd = m.bulk(action, input)
+				} else if input == "Y" || input == "y" {
 					switch action {
 					case "close":
 						cmd = tasks.ClosePR(m.Ctx, sid, pr)
 					case "reopen":
 						cmd = tasks.ReopenPR(m.Ctx, sid, pr)
 					case "ready":
-						cmd = tasks.PRReady(m.Ctx, sid, pr)
-					case "merge":
-						cmd = tasks.MergePR(m.Ctx, sid, pr)
+						cmd = tasks.SetPRDraft(m.Ctx, sid, pr, false)
+					case "draft":
+						cmd = tasks.SetPRDraft(m.Ctx, sid, pr, true)
 					case "update":
 						cmd = tasks.UpdatePR(m.Ctx, sid, pr)
 					}
@@ -127,43 +150,65 @@ func (m Model) Update(msg tea.Msg) (section.Section, tea.Cmd) {
 		case key.Matches(msg, keys.PRKeys.WatchChecks):
 			cmd = m.watchChecks()
 
+		case key.Matches(msg, keys.PRKeys.Edit):
+			cmd = m.edit()
+
 		}
 
+	case prEditedMsg:
+		cmd = m.onPrEdited(msg)
+
 	case tasks.UpdatePRMsg:
 		for i, currPr := range m.Prs {
-			if currPr.Number == msg.PrNumber {
-				if msg.IsClosed != nil {
-					if *msg.IsClosed {
-						currPr.State = "CLOSED"
-					} else {
-						currPr.State = "OPEN"
-					}
+			if currPr.Url == msg.PrUrl {
+				if msg.Pr != nil {
+					m.dropStaleFiles(currPr, *msg.Pr)
+					currPr = *msg.Pr
 				}
-				if msg.NewComment != nil {
-					currPr.Comments.Nodes = append(currPr.Comments.Nodes, *msg.NewComment)
+				if msg.Labels != nil {
+					currPr.Labels.Nodes = *msg.Labels
 				}
-				if msg.AddedAssignees != nil {
-					currPr.Assignees.Nodes = addAssignees(currPr.Assignees.Nodes, msg.AddedAssignees.Nodes)
+				if msg.Title != nil {
+					currPr.Title = *msg.Title
 				}
-				if msg.RemovedAssignees != nil {
-					currPr.Assignees.Nodes = removeAssignees(currPr.Assignees.Nodes, msg.RemovedAssignees.Nodes)
+				if msg.Body != nil {
+					currPr.Body = *msg.Body
 				}
-				if msg.ReadyForReview != nil && *msg.ReadyForReview {
-					currPr.IsDraft = false
+				if msg.IsDraft != nil {
+					currPr.IsDraft = *msg.IsDraft
 				}
 				if msg.IsMerged != nil && *msg.IsMerged {
 					currPr.State = "MERGED"
 					currPr.Mergeable = ""
 				}
+				if msg.Commits != nil {
+					currPr.Commits = *msg.Commits
+				}
+				if msg.Files != nil {
+					m.files[currPr.Url] = *msg.Files
+				}
 				m.Prs[i] = currPr
 				m.Table.SetIsLoading(false)
+				if !m.matchesDraftFilter(currPr) {
+					m.removePr(i)
+				}
 				m.Table.SetRows(m.BuildRows())
+				m.clampCurrItem()
 				break
 			}
 		}
 
+	case triage.ChangedMsg:
+		m.Table.SetRows(m.BuildRows())
+		m.clampCurrItem()
+
 	case SectionPullRequestsFetchedMsg:
 		if m.LastFetchTaskId == msg.TaskId {
+			for _, pr := range msg.Prs {
+				if curr := m.GetPr(pr.Url); curr != nil {
+					m.dropStaleFiles(*curr, pr)
+				}
+			}
 			if m.PageInfo != nil {
 				m.Prs = append(m.Prs, msg.Prs...)
 			} else {
@@ -173,6 +218,7 @@ func (m Model) Update(msg tea.Msg) (section.Section, tea.Cmd) {
 			m.PageInfo = &msg.PageInfo
 			m.Table.SetIsLoading(false)
 			m.Table.SetRows(m.BuildRows())
+			m.SearchBar.SetCompletions(m.searchCompletions())
 			m.Table.UpdateLastUpdated(time.Now())
 			m.UpdateTotalItemsCount(m.TotalCount)
 		}
@@ -216,7 +262,9 @@ func GetSectionColumns(
 	)
 	stateLayout := config.MergeColumnConfigs(dLayout.State, sLayout.State)
 	ciLayout := config.MergeColumnConfigs(dLayout.Ci, sLayout.Ci)
+	viewedLayout := config.MergeColumnConfigs(dLayout.Viewed, sLayout.Viewed)
 	linesLayout := config.MergeColumnConfigs(dLayout.Lines, sLayout.Lines)
+	notesLayout := config.MergeColumnConfigs(dLayout.Notes, sLayout.Notes)
 
 	if !ctx.Config.Theme.Ui.Table.Compact {
 		return []table.Column{
@@ -230,6 +278,11 @@ func GetSectionColumns(
 				Grow:   utils.BoolPtr(true),
 				Hidden: titleLayout.Hidden,
 			},
+			{
+				Title:  constants.NoteIcon,
+				Width:  notesLayout.Width,
+				Hidden: notesLayout.Hidden,
+			},
 			{
 				Title:  "Assignees",
 				Width:  assigneesLayout.Width,
@@ -251,6 +304,11 @@ func GetSectionColumns(
 				Grow:   new(bool),
 				Hidden: ciLayout.Hidden,
 			},
+			{
+				Title:  "",
+				Width:  viewedLayout.Width,
+				Hidden: viewedLayout.Hidden,
+			},
 			{
 				Title:  "",
 				Width:  linesLayout.Width,
@@ -280,6 +338,11 @@ func GetSectionColumns(
 			Grow:   utils.BoolPtr(true),
 			Hidden: titleLayout.Hidden,
 		},
+		{
+			Title:  constants.NoteIcon,
+			Width:  notesLayout.Width,
+			Hidden: notesLayout.Hidden,
+		},
 		{
 			Title:  "Author",
 			Width:  authorLayout.Width,
@@ -306,6 +369,11 @@ func GetSectionColumns(
 			Grow:   new(bool),
 			Hidden: ciLayout.Hidden,
 		},
+		{
+			Title:  "",
+			Width:  viewedLayout.Width,
+			Hidden: viewedLayout.Hidden,
+		},
 		{
 			Title:  "",
 			Width:  linesLayout.Width,
@@ -321,13 +389,21 @@ func GetSectionColumns(
 
 func (m Model) BuildRows() []table.Row {
 	var rows []table.Row
-	currItem := m.Table.GetCurrItem()
-	for i, currPr := range m.Prs {
+	currItem, _ := m.Table.RowIndex(m.Table.GetCurrItem())
+	for i, currPr := range m.getVisiblePrs() {
 		i := i
-		prModel := pr.PullRequest{Ctx: m.Ctx, Data: &currPr, Columns: m.Table.Columns}
+		prModel := pr.PullRequest{
+			Ctx:      m.Ctx,
+			Data:     &currPr,
+			Files:    m.GetFiles(currPr.Url),
+			Columns:  m.Table.Columns,
+			IsDimmed: currPr.IsDraft && m.drafts == config.DraftsDim,
+			IsWatchingChecks: m.Ctx.Jobs != nil &&
+				m.Ctx.Jobs.IsRunning(watchChecksJobId(&currPr)),
+		}
 		rows = append(
 			rows,
-			prModel.ToTableRow(currItem == i),
+			m.MarkSelectedRow(prModel.ToTableRow(currItem == i), &currPr),
 		)
 	}
 
@@ -339,7 +415,7 @@ func (m Model) BuildRows() []table.Row {
 }
 
 func (m *Model) NumRows() int {
-	return len(m.Prs)
+	return len(m.getFilteredPrs())
 }
 
 type SectionPullRequestsFetchedMsg struct {
@@ -349,11 +425,31 @@ type SectionPullRequestsFetchedMsg struct {
 	TaskId     string
 }
 
+// GetFiles returns the changed files of the PR at prUrl, or nil if they
+// weren't fetched yet.
+func (m *Model) GetFiles(prUrl string) *data.PullRequestFiles {
+	files, ok := m.files[prUrl]
+	if !ok {
+		return nil
+	}
+	return &files
+}
+
+// dropStaleFiles forgets the files of pr if it was updated, as they may have
+// changed along with whether they were viewed.
+func (m *Model) dropStaleFiles(pr data.PullRequestData, updated data.PullRequestData) {
+	if !pr.UpdatedAt.Equal(updated.UpdatedAt) {
+		delete(m.files, pr.Url)
+	}
+}
+
 func (m *Model) GetCurrRow() data.RowData {
-	if len(m.Prs) == 0 {
+	prs := m.getFilteredPrs()
+	currItem := m.Table.GetCurrItem()
+	if currItem >= len(prs) {
 		return nil
 	}
-	pr := m.Prs[m.Table.GetCurrItem()]
+	pr := prs[currItem]
 	return &pr
 }
 
@@ -392,7 +488,13 @@ func (m *Model) FetchNextPageSectionRows() []tea.Cmd {
 		if limit == nil {
 			limit = &m.Ctx.Config.Defaults.PrsLimit
 		}
-		res, err := data.FetchPullRequests(m.GetFilters(), *limit, m.PageInfo)
+		var res data.PullRequestsResponse
+		var err error
+		if m.isPinned {
+			res, err = m.fetchPinnedPrs()
+		} else {
+			res, err = data.FetchPullRequests(m.GetFilters(), *limit, m.PageInfo)
+		}
 		if err != nil {
 			return constants.TaskFinishedMsg{
 				SectionId:   m.Id,
@@ -433,11 +535,16 @@ func (m *Model) ResetRows() {
 func FetchAllSections(
 	ctx context.ProgramContext,
 ) (sections []section.Section, fetchAllCmd tea.Cmd) {
-	fetchPRsCmds := make([]tea.Cmd, 0, len(ctx.Config.PRSections))
-	sections = make([]section.Section, 0, len(ctx.Config.PRSections))
-	for i, sectionConfig := range ctx.Config.PRSections {
+	fetchPRsCmds := make([]tea.Cmd, 0, len(ctx.Config.PRSections)+1)
+	sections = make([]section.Section, 0, len(ctx.Config.PRSections)+1)
+	if ctx.Triage.HasPinned(true) {
+		pinned := NewPinnedModel(&ctx, time.Now())
+		sections = append(sections, &pinned)
+		fetchPRsCmds = append(fetchPRsCmds, pinned.FetchNextPageSectionRows()...)
+	}
+	for _, sectionConfig := range ctx.Config.PRSections {
 		sectionModel := NewModel(
-			i+1,
+			len(sections)+1,
 			&ctx,
 			sectionConfig,
 			time.Now(),
@@ -450,39 +557,6 @@ func FetchAllSections(
 	return sections, tea.Batch(fetchPRsCmds...)
 }
 
-func addAssignees(assignees, addedAssignees []data.Assignee) []data.Assignee {
-	newAssignees := assignees
-	for _, assignee := range addedAssignees {
-		if !assigneesContains(newAssignees, assignee) {
-			newAssignees = append(newAssignees, assignee)
-		}
-	}
-
-	return newAssignees
-}
-
-func removeAssignees(
-	assignees, removedAssignees []data.Assignee,
-) []data.Assignee {
-	newAssignees := []data.Assignee{}
-	for _, assignee := range assignees {
-		if !assigneesContains(removedAssignees, assignee) {
-			newAssignees = append(newAssignees, assignee)
-		}
-	}
-
-	return newAssignees
-}
-
-func assigneesContains(assignees []data.Assignee, assignee data.Assignee) bool {
-	for _, a := range assignees {
-		if assignee == a {
-			return true
-		}
-	}
-	return false
-}
-
 func (m Model) GetItemSingularForm() string {
 	return "PR"
 }
@@ -495,7 +569,8 @@ func (m Model) GetTotalCount() *int {
 	if m.IsLoading() {
 		return nil
 	}
-	return &m.TotalCount
+	count := m.TotalCount - m.numHidden()
+	return &count
 }
 
 func (m Model) IsLoading() bool {
@@ -505,16 +580,37 @@ func (m Model) IsLoading() bool {
 func (m Model) GetPagerContent() string {
 	pagerContent := ""
 	if m.TotalCount > 0 {
+		hidden := m.numHidden()
 		pagerContent = fmt.Sprintf(
 			"%v %v • %v %v/%v • Fetched %v",
 			constants.WaitingIcon,
 			m.LastUpdated().Format("01/02 15:04:05"),
 			m.SingularForm,
 			m.Table.GetCurrItem()+1,
-			m.TotalCount,
+			m.TotalCount-hidden,
 			len(m.Table.Rows),
-		)
+		) + m.GetHiddenPagerContent(hidden) + m.GetFilterPagerContent() + m.GetSelectionPagerContent()
 	}
 	pager := m.Ctx.Styles.ListViewPort.PagerStyle.Render(pagerContent)
 	return pager
 }
+
+// searchCompletions returns the repos, labels and users of the loaded PRs,
+// which the search bar completes qualifiers with.
+func (m *Model) searchCompletions() search.Completions {
+	var completions search.Completions
+	for _, pr := range m.Prs {
+		completions.Repos = append(completions.Repos, pr.GetRepoNameWithOwner())
+		completions.Users = append(completions.Users, pr.Author.Login)
+		for _, assignee := range pr.Assignees.Nodes {
+			completions.Users = append(completions.Users, assignee.Login)
+		}
+		for _, review := range pr.LatestReviews.Nodes {
+			completions.Users = append(completions.Users, review.Author.Login)
+		}
+		for _, label := range pr.Labels.Nodes {
+			completions.Labels = append(completions.Labels, label.Name)
+		}
+	}
+	return completions
+}
@@ -0,0 +1,23 @@
+package prssection
+
+import (
+	"fmt"
+
+	"github.com/dlvhdr/gh-dash/v4/data"
+)
+
+// ShowPr replaces the section's PRs with pr alone, as if a search matched
+// only it, to show a PR that no other section has.
+func (m *Model) ShowPr(pr data.PullRequestData) {
+	m.ResetFilter()
+	m.ResetRows()
+	m.LastFetchTaskId = ""
+	m.SearchValue = fmt.Sprintf("repo:%s %d", pr.GetRepoNameWithOwner(), pr.Number)
+	m.SearchBar.SetValue(m.SearchValue)
+	m.Prs = []data.PullRequestData{pr}
+	m.TotalCount = 1
+	m.PageInfo = &data.PageInfo{}
+	m.UpdateTotalItemsCount(m.TotalCount)
+	m.Table.SetIsLoading(false)
+	m.Table.SetRows(m.BuildRows())
+}
@@ -1,9 +1,10 @@
 package prssection
 
 import (
-	"bytes"
+	stdcontext "context"
 	"fmt"
-	"os/exec"
+	"strings"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/log"
@@ -16,77 +17,142 @@ import (
 	"github.com/dlvhdr/gh-dash/v4/ui/context"
 )
 
+// checksPollInterval is how often the checks of a watched PR are fetched.
+const checksPollInterval = 10 * time.Second
+
+func watchChecksJobId(pr *data.PullRequestData) string {
+	return fmt.Sprintf("pr_watch_checks_%s_%d", pr.GetRepoNameWithOwner(), pr.Number)
+}
+
+type checksWatch struct {
+	jobId   string
+	section tasks.SectionIdentifer
+	pr      *data.PullRequestData
+}
+
+// watchChecks polls the checks of the current PR until they complete, showing
+// their progress in the PR's row, and sends a desktop notification once they
+// do. Watching a PR whose checks are already watched stops the watch.
 func (m *Model) watchChecks() tea.Cmd {
-	pr := m.GetCurrRow()
-	prNumber := pr.GetNumber()
-	title := pr.GetTitle()
-	url := pr.GetUrl()
-	repoNameWithOwner := pr.GetRepoNameWithOwner()
-	taskId := fmt.Sprintf("pr_reopen_%d", prNumber)
+	pr, ok := m.GetCurrRow().(*data.PullRequestData)
+	if !ok {
+		return nil
+	}
+
+	jobId := watchChecksJobId(pr)
+	if m.Ctx.Jobs.Cancel(jobId) {
+		return nil
+	}
+
+	startText := fmt.Sprintf("Watching checks for PR #%d", pr.Number)
+	jobCtx, ok := m.Ctx.Jobs.Start(jobId)
+	if !ok {
+		return nil
+	}
+
 	task := context.Task{
-		Id:           taskId,
-		StartText:    fmt.Sprintf("Watching checks for PR #%d", prNumber),
-		FinishedText: fmt.Sprintf("Watching checks for PR #%d", prNumber),
+		Id:           jobId,
+		StartText:    startText,
+		FinishedText: fmt.Sprintf("Checks for PR #%d have completed", pr.Number),
 		State:        context.TaskStart,
 		Error:        nil,
 	}
 	startCmd := m.Ctx.StartTask(task)
-	return tea.Batch(startCmd, func() tea.Msg {
-		c := exec.Command(
-			"gh",
-			"pr",
-			"checks",
-			"--watch",
-			"--fail-fast",
-			fmt.Sprint(m.GetCurrRow().GetNumber()),
-			"-R",
-			m.GetCurrRow().GetRepoNameWithOwner(),
-		)
-
-		var outb, errb bytes.Buffer
-		c.Stdout = &outb
-		c.Stderr = &errb
+	w := checksWatch{
+		jobId:   jobId,
+		section: tasks.SectionIdentifer{Id: m.Id, Type: SectionType},
+		pr:      pr,
+	}
+	return tea.Batch(startCmd, m.pollChecks(jobCtx, w, 0))
+}
 
-		err := c.Start()
-		go func() {
-			err := c.Wait()
-			if err != nil {
-				log.Debug("Error waiting for watch command to finish", "err", err, "stderr", errb.String(), "stdout", outb.String())
-			}
+func (m *Model) pollChecks(jobCtx stdcontext.Context, w checksWatch, wait time.Duration) tea.Cmd {
+	return func() tea.Msg {
+		select {
+		case <-jobCtx.Done():
+			return m.stopWatchingChecks(w)
+		case <-time.After(wait):
+		}
 
-			// TODO: check for installation of terminal-notifier or alternative as logo isn't supported
-			updatedPr, err := data.FetchPullRequest(url)
-			if err != nil {
-				log.Debug("Error fetching updated PR details", "url", url, "err", err)
+		updatedPr, err := data.FetchPullRequest(w.pr.Url)
+		if jobCtx.Err() != nil {
+			return m.stopWatchingChecks(w)
+		}
+		if err != nil {
+			m.Ctx.Jobs.Finish(w.jobId)
+			return constants.TaskFinishedMsg{
+				TaskId:      w.jobId,
+				SectionId:   w.section.Id,
+				SectionType: w.section.Type,
+				Err:         err,
 			}
+		}
 
+		updateMsg := tasks.UpdatePRMsg{PrUrl: w.pr.Url, Commits: &updatedPr.Commits}
+		state := updatedPr.Commits.ChecksState()
+		if state == "PENDING" || state == "EXPECTED" {
 			renderedPr := prComponent.PullRequest{Ctx: m.Ctx, Data: &updatedPr}
-			checksRollup := " Checks are pending"
-			switch renderedPr.GetStatusChecksRollup() {
-			case "SUCCESS":
-				checksRollup = "✅ Checks have passed"
-			case "FAILURE":
-				checksRollup = "❌ Checks have failed"
+			completed, total := renderedPr.GetChecksProgress()
+			progress := fmt.Sprintf("%d/%d", completed, total)
+			return constants.TaskProgressMsg{
+				TaskId:      w.jobId,
+				SectionId:   w.section.Id,
+				SectionType: w.section.Type,
+				StartText:   fmt.Sprintf("Watching checks for PR #%d (%s)", w.pr.Number, progress),
+				Msg:         updateMsg,
+				Next:        m.pollChecks(jobCtx, w, checksPollInterval),
 			}
+		}
 
-			err = beeep.Notify(
-				fmt.Sprintf("gh-dash: %s", title),
-				fmt.Sprintf("PR #%d in %s\n%s", prNumber, repoNameWithOwner, checksRollup),
-				"",
-			)
-			if err != nil {
-				log.Debug("Error showing system notification", "err", err)
-			}
-		}()
+		m.Ctx.Jobs.Finish(w.jobId)
+		finishedText, notification := checksOutcome(w.pr.Number, state)
+
+		// TODO: check for installation of terminal-notifier or alternative as logo isn't supported
+		err = beeep.Notify(
+			fmt.Sprintf("gh-dash: %s", w.pr.Title),
+			fmt.Sprintf("PR #%d in %s\n%s", w.pr.Number, w.pr.GetRepoNameWithOwner(), notification),
+			"",
+		)
+		if err != nil {
+			log.Debug("Error showing system notification", "err", err)
+		}
 
 		return constants.TaskFinishedMsg{
-			SectionId:   m.Id,
-			SectionType: SectionType,
-			TaskId:      taskId,
-			Err:         err,
-			Msg: tasks.UpdatePRMsg{
-				PrNumber: prNumber,
-			},
+			TaskId:       w.jobId,
+			SectionId:    w.section.Id,
+			SectionType:  w.section.Type,
+			Msg:          updateMsg,
+			FinishedText: finishedText,
 		}
-	})
+	}
+}
+
+// checksOutcome returns the task's finished text and the notification for
+// the checks of PR number once their combined state is no longer pending.
+// Only checks that succeeded passed.
+func checksOutcome(number int, state string) (string, string) {
+	switch state {
+	case "SUCCESS":
+		return fmt.Sprintf("Checks for PR #%d have passed", number), "✅ Checks have passed"
+	case "FAILURE":
+		return fmt.Sprintf("Checks for PR #%d have failed", number), "❌ Checks have failed"
+	case "ERROR":
+		return fmt.Sprintf("Checks for PR #%d have errored", number), "❌ Checks have errored"
+	case "":
+		return fmt.Sprintf("PR #%d has no checks", number), "⚪ The PR has no checks"
+	default:
+		return fmt.Sprintf("Checks for PR #%d have completed as %s", number, strings.ToLower(state)),
+			fmt.Sprintf("⚪ Checks have completed as %s", strings.ToLower(state))
+	}
+}
+
+func (m *Model) stopWatchingChecks(w checksWatch) tea.Msg {
+	m.Ctx.Jobs.Finish(w.jobId)
+	return constants.TaskFinishedMsg{
+		TaskId:       w.jobId,
+		SectionId:    w.section.Id,
+		SectionType:  w.section.Type,
+		Msg:          tasks.UpdatePRMsg{PrUrl: w.pr.Url},
+		FinishedText: fmt.Sprintf("Stopped watching checks for PR #%d", w.pr.Number),
+	}
 }
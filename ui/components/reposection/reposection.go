@@ -445,6 +445,12 @@ type SectionPullRequestsFetchedMsg struct {
 	TaskId     string
 }
 
+// GetRows returns no rows because branches can't be selected for bulk
+// actions.
+func (m *Model) GetRows() []data.RowData {
+	return nil
+}
+
 func (m *Model) getCurrBranch() *branch.Branch {
 	if len(m.repo.Branches) == 0 {
 		return nil
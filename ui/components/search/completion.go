@@ -0,0 +1,168 @@
+package search
+
+import (
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/dlvhdr/gh-dash/v4/utils"
+)
+
+// Completions are the values that qualifiers are completed with, in addition
+// to the repos in repoPaths.
+type Completions struct {
+	Repos  []string
+	Labels []string
+	Users  []string
+}
+
+type suggestion struct {
+	value  string
+	detail string
+}
+
+// word is the token that's being completed, which is the one the cursor is
+// at the end of or in the middle of.
+func (m *Model) word() (token, bool) {
+	pos := m.textInput.Position()
+	for _, t := range tokenize(m.textInput.Value()) {
+		if t.start < pos && pos <= t.end {
+			return t, true
+		}
+	}
+	return token{}, false
+}
+
+func (m *Model) updateSuggestions() {
+	m.suggestions = nil
+	m.matches = nil
+	m.selectedSuggestion = 0
+	if m.qualifiers == nil || !m.textInput.Focused() {
+		return
+	}
+
+	w, ok := m.word()
+	if !ok {
+		return
+	}
+
+	prefix, query := "", strings.TrimPrefix(w.text, "-")
+	if name, value, ok := parseQualifier(w.text); ok {
+		q, known := m.qualifiers.find(name)
+		if !known {
+			return
+		}
+		prefix = w.text[:len(w.text)-len(value)]
+		query = strings.Trim(value, `"`)
+		m.suggestions = m.valueSuggestions(q)
+	} else if strings.HasPrefix(w.text, "-") {
+		prefix = "-"
+		m.suggestions = m.qualifierSuggestions()
+	} else {
+		m.suggestions = m.qualifierSuggestions()
+	}
+
+	values := make([]string, 0, len(m.suggestions))
+	for _, s := range m.suggestions {
+		values = append(values, strings.Trim(s.value, `"`))
+	}
+	for _, match := range utils.FuzzyFind(query, values) {
+		if prefix+m.suggestions[match.Index].value == w.text {
+			continue
+		}
+		m.matches = append(m.matches, match)
+	}
+	m.completionPrefix = prefix
+}
+
+func (m *Model) qualifierSuggestions() []suggestion {
+	suggestions := make([]suggestion, 0, len(m.qualifiers))
+	for _, q := range m.qualifiers {
+		suggestions = append(suggestions, suggestion{value: q.Name + ":", detail: "qualifier"})
+	}
+	return suggestions
+}
+
+func (m *Model) valueSuggestions(q Qualifier) []suggestion {
+	var suggestions []suggestion
+	for _, v := range q.Values {
+		suggestions = append(suggestions, suggestion{value: v})
+	}
+
+	var values []string
+	detail := ""
+	switch q.kind {
+	case userValues:
+		values, detail = append([]string{"@me"}, m.completions.Users...), "user"
+	case ownerValues:
+		for _, repo := range m.repos() {
+			owner, _, _ := strings.Cut(repo, "/")
+			values = append(values, owner)
+		}
+		detail = "owner"
+	case repoValues:
+		values, detail = m.repos(), "repo"
+	case labelValues:
+		values, detail = m.completions.Labels, "label"
+	}
+	values = unique(values)
+	for _, v := range values {
+		if strings.ContainsAny(v, " \t") {
+			v = `"` + v + `"`
+		}
+		suggestions = append(suggestions, suggestion{value: v, detail: detail})
+	}
+	return suggestions
+}
+
+func (m *Model) repos() []string {
+	repos := slices.Clone(m.completions.Repos)
+	for repo := range m.ctx.Config.RepoPaths {
+		if !strings.Contains(repo, "*") {
+			repos = append(repos, repo)
+		}
+	}
+	return unique(repos)
+}
+
+// acceptSuggestion replaces the word being completed with the selected
+// suggestion. Completed values are followed by a space so the next word can
+// be typed right away.
+func (m *Model) acceptSuggestion() {
+	w, ok := m.word()
+	if !ok || len(m.matches) == 0 {
+		return
+	}
+
+	s := m.suggestions[m.matches[m.selectedSuggestion].Index]
+	replacement := m.completionPrefix + s.value
+	runes := []rune(m.textInput.Value())
+	rest := string(runes[w.end:])
+	if !strings.HasSuffix(replacement, ":") && !strings.HasPrefix(rest, " ") {
+		replacement += " "
+	}
+
+	m.textInput.SetValue(string(runes[:w.start]) + replacement + rest)
+	m.textInput.SetCursor(w.start + len([]rune(replacement)))
+	m.updateSuggestions()
+}
+
+func (m *Model) numVisibleMatches() int {
+	return utils.Min(len(m.matches), maxVisibleSuggestions)
+}
+
+func unique(values []string) []string {
+	seen := map[string]bool{}
+	res := make([]string, 0, len(values))
+	for _, v := range values {
+		if v == "" || seen[v] {
+			continue
+		}
+		seen[v] = true
+		res = append(res, v)
+	}
+	sort.SliceStable(res, func(i, j int) bool {
+		return res[i] == "@me" || (res[j] != "@me" && strings.ToLower(res[i]) < strings.ToLower(res[j]))
+	})
+	return res
+}
@@ -0,0 +1,111 @@
+package search
+
+import (
+	"slices"
+	"sync"
+
+	"github.com/charmbracelet/log"
+
+	"github.com/dlvhdr/gh-dash/v4/config"
+)
+
+const (
+	historyFileName = "search_history.yml"
+	maxHistorySize  = 50
+)
+
+// history holds the past searches of every section, keyed by the section's
+// type and title, and is shared by all search bars.
+var history = struct {
+	sync.Mutex
+	loaded  bool
+	entries map[string][]string
+}{}
+
+func loadHistory() {
+	if history.loaded {
+		return
+	}
+	history.loaded = true
+	history.entries = map[string][]string{}
+
+	if err := config.ReadState(historyFileName, &history.entries); err != nil {
+		log.Error("failed to read search history", "err", err)
+	}
+}
+
+func saveHistory() {
+	if err := config.WriteState(historyFileName, history.entries); err != nil {
+		log.Error("failed to save search history", "err", err)
+	}
+}
+
+// getHistory returns the past searches for key, oldest first.
+func getHistory(key string) []string {
+	history.Lock()
+	defer history.Unlock()
+	loadHistory()
+	return slices.Clone(history.entries[key])
+}
+
+// addToHistory makes query the latest search for key.
+func addToHistory(key string, query string) {
+	history.Lock()
+	defer history.Unlock()
+	loadHistory()
+
+	entries := slices.DeleteFunc(history.entries[key], func(e string) bool {
+		return e == query
+	})
+	entries = append(entries, query)
+	if len(entries) > maxHistorySize {
+		entries = entries[len(entries)-maxHistorySize:]
+	}
+	history.entries[key] = entries
+	saveHistory()
+}
+
+// AddToHistory records the current query as the latest search of the
+// section, so it can be recalled with up and down. Searches without a
+// HistoryKey aren't recorded.
+func (m *Model) AddToHistory() {
+	m.historyIndex = -1
+	query := m.textInput.Value()
+	if m.historyKey == "" || query == "" {
+		return
+	}
+	addToHistory(m.historyKey, query)
+}
+
+// recallHistory replaces the query with an older (delta < 0) or newer
+// (delta > 0) search. Going past the newest search brings back what was
+// being typed.
+func (m *Model) recallHistory(delta int) {
+	entries := getHistory(m.historyKey)
+	if len(entries) == 0 {
+		return
+	}
+
+	index := m.historyIndex
+	if index == -1 {
+		if delta > 0 {
+			return
+		}
+		m.draft = m.textInput.Value()
+		index = len(entries)
+	}
+	index += delta
+	if index < 0 {
+		return
+	}
+
+	if index >= len(entries) {
+		m.historyIndex = -1
+		m.textInput.SetValue(m.draft)
+	} else {
+		m.historyIndex = index
+		m.textInput.SetValue(entries[index])
+	}
+	m.textInput.CursorEnd()
+	m.confirmedValue = ""
+}
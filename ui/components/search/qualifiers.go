@@ -0,0 +1,174 @@
+package search
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+)
+
+type valueKind int
+
+const (
+	noValues valueKind = iota
+	userValues
+	ownerValues
+	repoValues
+	labelValues
+)
+
+// Qualifier is a GitHub search qualifier, like is: or label:, along with the
+// values it's completed with.
+type Qualifier struct {
+	Name   string
+	Values []string
+	kind   valueKind
+}
+
+type Qualifiers []Qualifier
+
+var sortValues = []string{
+	"created-desc", "created-asc",
+	"updated-desc", "updated-asc",
+	"comments-desc", "comments-asc",
+	"reactions-desc", "reactions-asc",
+	"interactions-desc", "interactions-asc",
+}
+
+var commonQualifiers = Qualifiers{
+	{Name: "state", Values: []string{"open", "closed"}},
+	{Name: "author", kind: userValues},
+	{Name: "assignee", kind: userValues},
+	{Name: "mentions", kind: userValues},
+	{Name: "commenter", kind: userValues},
+	{Name: "involves", kind: userValues},
+	{Name: "label", kind: labelValues},
+	{Name: "repo", kind: repoValues},
+	{Name: "org", kind: ownerValues},
+	{Name: "user", kind: ownerValues},
+	{Name: "in", Values: []string{"title", "body", "comments"}},
+	{Name: "no", Values: []string{"label", "milestone", "assignee", "project"}},
+	{Name: "archived", Values: []string{"true", "false"}},
+	{Name: "linked", Values: []string{"pr", "issue"}},
+	{Name: "sort", Values: sortValues},
+	{Name: "type", Values: []string{"pr", "issue"}},
+	{Name: "milestone"},
+	{Name: "project"},
+	{Name: "language"},
+	{Name: "team"},
+	{Name: "comments"},
+	{Name: "interactions"},
+	{Name: "reactions"},
+	{Name: "created"},
+	{Name: "updated"},
+	{Name: "closed"},
+	{Name: "author-app"},
+}
+
+// PrQualifiers are the qualifiers that can be used to search PRs.
+var PrQualifiers = append(Qualifiers{
+	{Name: "is", Values: []string{
+		"open", "closed", "merged", "unmerged", "draft", "queued",
+		"locked", "unlocked", "public", "private",
+	}},
+	{Name: "review", Values: []string{"none", "required", "approved", "changes_requested"}},
+	{Name: "review-requested", kind: userValues},
+	{Name: "reviewed-by", kind: userValues},
+	{Name: "user-review-requested", kind: userValues},
+	{Name: "team-review-requested"},
+	{Name: "draft", Values: []string{"true", "false"}},
+	{Name: "status", Values: []string{"pending", "success", "failure"}},
+	{Name: "head"},
+	{Name: "base"},
+	{Name: "merged"},
+}, commonQualifiers...)
+
+// IssueQualifiers are the qualifiers that can be used to search issues.
+var IssueQualifiers = append(Qualifiers{
+	{Name: "is", Values: []string{
+		"open", "closed", "locked", "unlocked", "public", "private",
+		"blocked", "blocking",
+	}},
+	{Name: "reason", Values: []string{"completed", `"not planned"`}},
+}, commonQualifiers...)
+
+// QualifiersFor returns the qualifiers of searches for items of itemType,
+// which is either pr or issue, or nil for other searches.
+func QualifiersFor(itemType string) Qualifiers {
+	switch itemType {
+	case "pr":
+		return PrQualifiers
+	case "issue":
+		return IssueQualifiers
+	}
+	return nil
+}
+
+func (qs Qualifiers) find(name string) (Qualifier, bool) {
+	i := slices.IndexFunc(qs, func(q Qualifier) bool { return q.Name == name })
+	if i == -1 {
+		return Qualifier{}, false
+	}
+	return qs[i], true
+}
+
+var qualifierRegex = regexp.MustCompile(`^-?([a-zA-Z][a-zA-Z-]*):(.*)$`)
+
+// token is a whitespace-separated word of a query. Quoted text is part of a
+// single token. start and end are indexes of runes in the query.
+type token struct {
+	text       string
+	start, end int
+}
+
+func tokenize(query string) []token {
+	var (
+		tokens  []token
+		inQuote bool
+		start   = -1
+	)
+	runes := []rune(query)
+	for i, r := range runes {
+		if r == '"' {
+			inQuote = !inQuote
+		}
+		if (r == ' ' || r == '\t') && !inQuote {
+			if start != -1 {
+				tokens = append(tokens, token{text: string(runes[start:i]), start: start, end: i})
+				start = -1
+			}
+			continue
+		}
+		if start == -1 {
+			start = i
+		}
+	}
+	if start != -1 {
+		tokens = append(tokens, token{text: string(runes[start:]), start: start, end: len(runes)})
+	}
+	return tokens
+}
+
+// parseQualifier splits a token like -label:bug into its qualifier name and
+// value.
+func parseQualifier(text string) (name string, value string, ok bool) {
+	match := qualifierRegex.FindStringSubmatch(text)
+	if match == nil || strings.HasPrefix(match[2], "//") {
+		return "", "", false
+	}
+	return strings.ToLower(match[1]), match[2], true
+}
+
+// Unknown returns the qualifiers used in query that aren't in qs.
+func (qs Qualifiers) Unknown(query string) []string {
+	var unknown []string
+	for _, t := range tokenize(query) {
+		name, _, ok := parseQualifier(t.text)
+		if !ok {
+			continue
+		}
+		if _, known := qs.find(name); !known && !slices.Contains(unknown, name+":") {
+			unknown = append(unknown, name+":")
+		}
+	}
+	return unknown
+}
@@ -0,0 +1,49 @@
+package search_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dlvhdr/gh-dash/v4/ui/components/search"
+)
+
+func TestUnknownQualifiers(t *testing.T) {
+	testCases := map[string]struct {
+		qualifiers search.Qualifiers
+		query      string
+		want       []string
+	}{
+		"known qualifiers": {
+			qualifiers: search.PrQualifiers,
+			query:      "is:open review-requested:@me -label:bug sort:updated-desc",
+			want:       nil,
+		},
+		"unknown qualifier": {
+			qualifiers: search.PrQualifiers,
+			query:      "is:open reviewer:@me",
+			want:       []string{"reviewer:"},
+		},
+		"negated unknown qualifiers are listed once": {
+			qualifiers: search.PrQualifiers,
+			query:      "-foo:bar foo:baz Bar:qux",
+			want:       []string{"foo:", "bar:"},
+		},
+		"qualifier of the other item type": {
+			qualifiers: search.IssueQualifiers,
+			query:      "is:open review:approved",
+			want:       []string{"review:"},
+		},
+		"colons in quotes and urls": {
+			qualifiers: search.IssueQualifiers,
+			query:      `"foo:bar baz" https://github.com label:"area: ui"`,
+			want:       nil,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.want, tc.qualifiers.Unknown(tc.query))
+		})
+	}
+}
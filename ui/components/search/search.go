@@ -2,28 +2,64 @@ package search
 
 import (
 	"fmt"
+	"strings"
 
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 
+	"github.com/dlvhdr/gh-dash/v4/ui/common"
 	"github.com/dlvhdr/gh-dash/v4/ui/context"
+	"github.com/dlvhdr/gh-dash/v4/utils"
 )
 
+const maxVisibleSuggestions = 6
+
 type Model struct {
 	ctx          *context.ProgramContext
 	initialValue string
 	textInput    textinput.Model
+	help         help.Model
+
+	qualifiers         Qualifiers
+	completions        Completions
+	suggestions        []suggestion
+	matches            []utils.FuzzyMatch
+	selectedSuggestion int
+	completionPrefix   string
+	// confirmedValue is the query the user chose to search for even though
+	// it has unknown qualifiers.
+	confirmedValue string
+
+	historyKey string
+	// historyIndex is the index of the past search that's being shown, or
+	// -1 when it's the one being typed, which is kept in draft.
+	historyIndex int
+	draft        string
 }
 
 type SearchOptions struct {
 	Prefix       string
 	InitialValue string
 	Placeholder  string
+	// Qualifiers are completed and highlighted in the query. Searches
+	// without qualifiers are plain text inputs.
+	Qualifiers Qualifiers
+	// HistoryKey identifies the past searches that can be recalled with up
+	// and down. Searches without a key have no history.
+	HistoryKey string
+}
+
+var suggestionKeys = []key.Binding{
+	key.NewBinding(key.WithKeys(tea.KeyTab.String()), key.WithHelp("tab", "complete")),
+	key.NewBinding(key.WithKeys(tea.KeyCtrlN.String(), tea.KeyCtrlP.String()), key.WithHelp("Ctrl+n/p", "next/prev suggestion")),
 }
 
 func NewModel(ctx *context.ProgramContext, opts SearchOptions) Model {
-	prompt := fmt.Sprintf(" %s ", opts.Prefix)
+	prompt := fmt.Sprintf(" %s ", opts.Prefix)
 	ti := textinput.New()
 	ti.Placeholder = opts.Placeholder
 	ti.PlaceholderStyle = lipgloss.NewStyle().Foreground(ctx.Theme.FaintText)
@@ -37,10 +73,16 @@ func NewModel(ctx *context.ProgramContext, opts SearchOptions) Model {
 	ti.SetValue(opts.InitialValue)
 	ti.CursorStart()
 
+	h := help.New()
+	h.Styles = ctx.Styles.Help.BubbleStyles
 	return Model{
 		ctx:          ctx,
 		textInput:    ti,
+		help:         h,
 		initialValue: opts.InitialValue,
+		qualifiers:   opts.Qualifiers,
+		historyKey:   opts.HistoryKey,
+		historyIndex: -1,
 	}
 }
 
@@ -51,7 +93,39 @@ func (m Model) Init() tea.Cmd {
 func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	var cmd tea.Cmd
 
+	keyMsg, isKey := msg.(tea.KeyMsg)
+	if isKey && len(m.matches) > 0 {
+		switch keyMsg.Type {
+		case tea.KeyTab:
+			m.acceptSuggestion()
+			return m, nil
+		case tea.KeyCtrlN:
+			m.selectedSuggestion = (m.selectedSuggestion + 1) % m.numVisibleMatches()
+			return m, nil
+		case tea.KeyCtrlP:
+			n := m.numVisibleMatches()
+			m.selectedSuggestion = (m.selectedSuggestion - 1 + n) % n
+			return m, nil
+		}
+	}
+
+	if isKey && m.historyKey != "" {
+		switch keyMsg.Type {
+		case tea.KeyUp:
+			m.recallHistory(-1)
+			m.updateSuggestions()
+			return m, nil
+		case tea.KeyDown:
+			m.recallHistory(1)
+			m.updateSuggestions()
+			return m, nil
+		}
+	}
+
 	m.textInput, cmd = m.textInput.Update(msg)
+	if isKey {
+		m.updateSuggestions()
+	}
 	return m, cmd
 }
 
@@ -61,7 +135,174 @@ func (m Model) View(ctx context.ProgramContext) string {
 		MaxHeight(3).
 		Border(lipgloss.RoundedBorder()).
 		BorderForeground(m.ctx.Theme.PrimaryBorder).
-		Render(m.textInput.View())
+		Render(m.renderInput())
+}
+
+func (m *Model) renderInput() string {
+	if m.qualifiers == nil || m.textInput.Value() == "" {
+		return m.textInput.View()
+	}
+
+	warning := m.renderWarning()
+	width := m.textInput.Width
+	if warning != "" {
+		width = utils.Max(10, width-lipgloss.Width(warning))
+	}
+	return lipgloss.JoinHorizontal(
+		lipgloss.Top,
+		m.textInput.PromptStyle.Render(m.textInput.Prompt),
+		m.renderValue(width),
+		warning,
+	)
+}
+
+// renderValue renders the query with its qualifiers highlighted, scrolled
+// so that the cursor is visible.
+func (m *Model) renderValue(width int) string {
+	runes := []rune(m.textInput.Value())
+	styles := m.runeStyles(runes)
+	focused := m.textInput.Focused()
+	pos := m.textInput.Position()
+
+	start := 0
+	if focused && pos >= width {
+		start = pos - width + 1
+	}
+
+	var b strings.Builder
+	for i := start; i < len(runes) && i-start < width; i++ {
+		if focused && i == pos {
+			b.WriteString(m.renderCursor(string(runes[i]), styles[i]))
+			continue
+		}
+		b.WriteString(styles[i].Render(string(runes[i])))
+	}
+	if focused && pos == len(runes) && pos-start < width {
+		b.WriteString(m.renderCursor(" ", lipgloss.NewStyle()))
+	}
+
+	if !focused {
+		return ansi.Truncate(b.String(), width, "…")
+	}
+	return b.String()
+}
+
+func (m *Model) renderCursor(char string, style lipgloss.Style) string {
+	c := m.textInput.Cursor
+	c.SetChar(char)
+	c.TextStyle = style.Inline(true)
+	return c.View()
+}
+
+// runeStyles returns the style of each rune of the query, highlighting the
+// names of known qualifiers, unknown qualifiers and boolean operators.
+func (m *Model) runeStyles(runes []rune) []lipgloss.Style {
+	theme := m.ctx.Theme
+	text := lipgloss.NewStyle().Foreground(theme.PrimaryText)
+	qualifier := lipgloss.NewStyle().Foreground(theme.SecondaryText).Bold(true)
+	unknown := lipgloss.NewStyle().Foreground(theme.WarningText).Underline(true)
+	operator := lipgloss.NewStyle().Foreground(theme.SecondaryText).Italic(true)
+	if !m.textInput.Focused() {
+		text, qualifier, unknown, operator = text.Faint(true), qualifier.Faint(true), unknown.Faint(true), operator.Faint(true)
+	}
+
+	styles := make([]lipgloss.Style, len(runes))
+	for i := range styles {
+		styles[i] = text
+	}
+	for _, t := range tokenize(string(runes)) {
+		if t.text == "OR" || t.text == "AND" || t.text == "NOT" {
+			for i := t.start; i < t.end; i++ {
+				styles[i] = operator
+			}
+			continue
+		}
+
+		name, value, ok := parseQualifier(t.text)
+		if !ok {
+			continue
+		}
+		style := qualifier
+		if _, known := m.qualifiers.find(name); !known {
+			style = unknown
+		}
+		nameEnd := t.end - len([]rune(value))
+		for i := t.start; i < nameEnd; i++ {
+			styles[i] = style
+		}
+	}
+	return styles
+}
+
+func (m *Model) renderWarning() string {
+	unknown := m.qualifiers.Unknown(m.textInput.Value())
+	if len(unknown) == 0 {
+		return ""
+	}
+
+	text := fmt.Sprintf(" 󰀪 unknown qualifier %s", strings.Join(unknown, ", "))
+	if len(unknown) > 1 {
+		text = fmt.Sprintf(" 󰀪 unknown qualifiers %s", strings.Join(unknown, ", "))
+	}
+	if m.confirmedValue == m.textInput.Value() {
+		text += " · enter to search anyway"
+	}
+	return lipgloss.NewStyle().Foreground(m.ctx.Theme.WarningText).Render(text)
+}
+
+// SuggestionsView renders the completions for the word being typed, or an
+// empty string when there are none.
+func (m Model) SuggestionsView() string {
+	if len(m.matches) == 0 {
+		return ""
+	}
+
+	rows := make([]string, 0, m.numVisibleMatches()+2)
+	for i, match := range m.matches[:m.numVisibleMatches()] {
+		s := m.suggestions[match.Index]
+		style := lipgloss.NewStyle().Foreground(m.ctx.Theme.SecondaryText)
+		if i == m.selectedSuggestion {
+			style = style.Foreground(m.ctx.Theme.PrimaryText).Bold(true)
+		}
+		matchStyle := style.Underline(true)
+
+		rows = append(rows, lipgloss.JoinHorizontal(
+			lipgloss.Top,
+			style.Render("  "),
+			common.RenderFuzzyMatch(match.Str, match.MatchedIndexes, style, matchStyle),
+			lipgloss.NewStyle().Foreground(m.ctx.Theme.FaintText).Render(" "+s.detail),
+		))
+	}
+	if len(m.matches) > maxVisibleSuggestions {
+		rows = append(rows, lipgloss.NewStyle().
+			Foreground(m.ctx.Theme.FaintText).
+			Render(fmt.Sprintf("  and %d more", len(m.matches)-maxVisibleSuggestions)))
+	}
+	rows = append(rows, "", lipgloss.NewStyle().PaddingLeft(2).Render(m.help.ShortHelpView(suggestionKeys)))
+
+	return lipgloss.NewStyle().
+		Width(m.ctx.MainContentWidth - 4).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(m.ctx.Theme.SecondaryBorder).
+		Render(lipgloss.JoinVertical(lipgloss.Left, rows...))
+}
+
+// ConfirmSubmit reports whether the query can be searched for. The first
+// time a query with unknown qualifiers is submitted, it's not searched for
+// and the warning asks to submit it again instead.
+func (m *Model) ConfirmSubmit() bool {
+	value := m.textInput.Value()
+	if m.qualifiers == nil || len(m.qualifiers.Unknown(value)) == 0 || m.confirmedValue == value {
+		return true
+	}
+	m.confirmedValue = value
+	return false
+}
+
+// SetCompletions sets the repos, labels and users that qualifiers are
+// completed with.
+func (m *Model) SetCompletions(completions Completions) {
+	m.completions = completions
 }
 
 func (m *Model) Focus() {
@@ -74,6 +315,9 @@ func (m *Model) Blur() {
 	m.textInput.TextStyle = m.textInput.TextStyle.Faint(true)
 	m.textInput.CursorStart()
 	m.textInput.Blur()
+	m.updateSuggestions()
+	m.confirmedValue = ""
+	m.historyIndex = -1
 }
 
 func (m *Model) SetValue(val string) {
@@ -84,6 +328,7 @@ func (m *Model) UpdateProgramContext(ctx *context.ProgramContext) {
 	m.textInput.Width = m.getInputWidth(ctx)
 	m.textInput.SetValue(m.textInput.Value())
 	m.textInput.Blur()
+	m.help.Styles = ctx.Styles.Help.BubbleStyles
 }
 
 func (m *Model) getInputWidth(ctx *context.ProgramContext) int {
@@ -0,0 +1,86 @@
+package section
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Filter narrows the loaded rows of a section without fetching them again,
+// unlike Search which changes the section's GitHub query.
+type Filter interface {
+	SetIsFiltering(val bool) tea.Cmd
+	IsFilterFocused() bool
+	IsFiltered() bool
+	ResetFilter()
+}
+
+func (m *BaseModel) IsFilterFocused() bool {
+	return m.IsFiltering
+}
+
+func (m *BaseModel) SetIsFiltering(val bool) tea.Cmd {
+	m.IsFiltering = val
+	if val {
+		m.FilterBar.Focus()
+		return m.FilterBar.Init()
+	}
+
+	m.FilterBar.Blur()
+	return nil
+}
+
+func (m *BaseModel) IsFiltered() bool {
+	return m.Table.IsFiltered()
+}
+
+func (m *BaseModel) ResetFilter() {
+	m.FilterBar.SetValue("")
+	m.Table.SetFilter("")
+}
+
+// UpdateFilter handles a key pressed while the filter is focused. The rows
+// are filtered as the filter is typed, and can be moved through with the
+// arrow keys. Enter keeps the filter and esc clears it.
+func (m *BaseModel) UpdateFilter(msg tea.KeyMsg) tea.Cmd {
+	switch msg.Type {
+	case tea.KeyEsc, tea.KeyCtrlC:
+		m.ResetFilter()
+		return m.SetIsFiltering(false)
+
+	case tea.KeyEnter:
+		return m.SetIsFiltering(false)
+
+	case tea.KeyUp, tea.KeyCtrlP:
+		m.Table.PrevItem()
+		return nil
+
+	case tea.KeyDown, tea.KeyCtrlN:
+		m.Table.NextItem()
+		return nil
+	}
+
+	var cmd tea.Cmd
+	m.FilterBar, cmd = m.FilterBar.Update(msg)
+	m.Table.SetFilter(m.FilterBar.Value())
+	return cmd
+}
+
+// GetHiddenPagerContent returns the number of fetched items the section
+// doesn't show, like drafts it hides or snoozed and muted items, to show in
+// the section's pager. They aren't counted in the pager's total.
+func (m *BaseModel) GetHiddenPagerContent(hidden int) string {
+	if hidden == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" • %d hidden", hidden)
+}
+
+// GetFilterPagerContent returns the number of loaded rows that match the
+// filter to show in the section's pager.
+func (m *BaseModel) GetFilterPagerContent() string {
+	if !m.IsFiltered() {
+		return ""
+	}
+	return fmt.Sprintf(" • %d/%d match filter", m.Table.NumVisibleRows(), len(m.Table.Rows))
+}
@@ -2,6 +2,7 @@ package section
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/charmbracelet/bubbles/spinner"
@@ -27,6 +28,8 @@ type BaseModel struct {
 	SearchBar                 search.Model
 	IsSearching               bool
 	SearchValue               string
+	FilterBar                 search.Model
+	IsFiltering               bool
 	Table                     table.Model
 	Type                      string
 	SingularForm              string
@@ -39,6 +42,11 @@ type BaseModel struct {
 	PromptConfirmationAction  string
 	LastFetchTaskId           string
 	IsSearchSupported         bool
+
+	selected          map[string]bool
+	visualBase        map[string]bool
+	visualAnchor      int
+	isVisualSelecting bool
 }
 
 type NewSectionOptions struct {
@@ -67,12 +75,19 @@ func NewModel(
 		SearchBar: search.NewModel(ctx, search.SearchOptions{
 			Prefix:       fmt.Sprintf("is:%s", options.Type),
 			InitialValue: options.Config.Filters,
+			Qualifiers:   search.QualifiersFor(options.Type),
+			HistoryKey:   fmt.Sprintf("%s:%s", options.Type, options.Config.Title),
+		}),
+		SearchValue: options.Config.Filters,
+		FilterBar: search.NewModel(ctx, search.SearchOptions{
+			Prefix:      "filter:",
+			Placeholder: "type to filter the loaded rows",
 		}),
-		SearchValue:           options.Config.Filters,
 		IsSearching:           false,
 		TotalCount:            0,
 		PageInfo:              nil,
 		PromptConfirmationBox: prompt.NewModel(ctx),
+		selected:              map[string]bool{},
 	}
 	m.Table = table.NewModel(
 		*ctx,
@@ -98,7 +113,9 @@ type Section interface {
 	Component
 	Table
 	Search
+	Filter
 	PromptConfirmation
+	Selection
 	UpdateProgramContext(ctx *context.ProgramContext)
 	MakeSectionCmd(cmd tea.Cmd) tea.Cmd
 	GetPagerContent() string
@@ -109,6 +126,7 @@ type Section interface {
 
 type Identifier interface {
 	GetId() int
+	SetId(id int)
 	GetType() string
 }
 
@@ -119,12 +137,15 @@ type Component interface {
 
 type Table interface {
 	NumRows() int
+	GetRows() []data.RowData
 	GetCurrRow() data.RowData
 	CurrRow() int
 	NextRow() int
 	PrevRow() int
 	FirstItem() int
 	LastItem() int
+	RowAt(y int) (int, bool)
+	SetCurrRow(row int) int
 	FetchNextPageSectionRows() []tea.Cmd
 	BuildRows() []table.Row
 	ResetRows()
@@ -169,6 +190,7 @@ func (m *BaseModel) UpdateProgramContext(ctx *context.ProgramContext) {
 		oldDimensions.Width != newDimensions.Width {
 		m.Table.SyncViewPortContent()
 		m.SearchBar.UpdateProgramContext(ctx)
+		m.FilterBar.UpdateProgramContext(ctx)
 	}
 }
 
@@ -185,6 +207,10 @@ func (m *BaseModel) GetId() int {
 	return m.Id
 }
 
+func (m *BaseModel) SetId(id int) {
+	m.Id = id
+}
+
 func (m *BaseModel) GetType() string {
 	return m.Type
 }
@@ -209,6 +235,15 @@ func (m *BaseModel) LastItem() int {
 	return m.Table.LastItem()
 }
 
+// RowAt returns the row shown at line y of the section.
+func (m *BaseModel) RowAt(y int) (int, bool) {
+	return m.Table.RowAt(y - common.SearchHeight)
+}
+
+func (m *BaseModel) SetCurrRow(row int) int {
+	return m.Table.SetCurrItem(row)
+}
+
 func (m *BaseModel) IsSearchFocused() bool {
 	return m.IsSearching
 }
@@ -255,6 +290,25 @@ func (m *BaseModel) GetPromptConfirmationAction() string {
 	return m.PromptConfirmationAction
 }
 
+// getBulkPrompt returns the prompt for running the confirmation action on the
+// selected rows, or an empty string if the action can't run in bulk.
+func (m *BaseModel) getBulkPrompt() string {
+	items := m.getSelectionNoun()
+	switch m.PromptConfirmationAction {
+	case "close", "reopen", "approve":
+		return fmt.Sprintf("Are you sure you want to %s %s? (Y/n) ", m.PromptConfirmationAction, items)
+	case "merge":
+		return fmt.Sprintf("Are you sure you want to merge %s with their repository's default method? (Y/n) ", items)
+	case "labels":
+		return fmt.Sprintf("Labels to add to %s (prefix with - to remove): ", items)
+	case "assign":
+		return fmt.Sprintf("Users to assign to %s: ", items)
+	case "unassign":
+		return fmt.Sprintf("Users to unassign from %s: ", items)
+	}
+	return ""
+}
+
 type SectionMsg struct {
 	Id          int
 	Type        string
@@ -303,17 +357,35 @@ func (m *BaseModel) GetMainContent() string {
 
 func (m *BaseModel) View() string {
 	search := m.SearchBar.View(*m.Ctx)
+	if m.IsFiltering || m.IsFiltered() {
+		search = m.FilterBar.View(*m.Ctx)
+	}
+	content := m.GetMainContent()
+	if suggestions := m.SearchBar.SuggestionsView(); suggestions != "" {
+		content = overlayLines(suggestions, content)
+	}
 	return m.Ctx.Styles.Section.ContainerStyle.Render(
 		lipgloss.JoinVertical(
 			lipgloss.Left,
 			search,
-			m.GetMainContent(),
+			content,
 		),
 	)
 }
 
+// overlayLines replaces the first lines of content with the lines of top.
+func overlayLines(top string, content string) string {
+	topLines := strings.Split(top, "\n")
+	lines := strings.Split(content, "\n")
+	for i := 0; i < len(topLines) && i < len(lines); i++ {
+		lines[i] = topLines[i]
+	}
+	return strings.Join(lines, "\n")
+}
+
 func (m *BaseModel) ResetRows() {
 	m.Table.Rows = nil
+	m.ClearSelection()
 	m.ResetPageInfo()
 	m.Table.ResetCurrItem()
 }
@@ -334,6 +406,12 @@ func (m *BaseModel) GetPromptConfirmation() string {
 	if m.IsPromptConfirmationShown {
 		var prompt string
 		switch {
+		case m.NumSelected() > 0 && m.getBulkPrompt() != "":
+			prompt = m.getBulkPrompt()
+
+		case m.PromptConfirmationAction == "save_section":
+			prompt = "Title of the new section: "
+
 		case m.PromptConfirmationAction == "close" && m.Ctx.View == config.PRsView:
 			prompt = "Are you sure you want to close this PR? (Y/n) "
 
@@ -343,8 +421,8 @@ func (m *BaseModel) GetPromptConfirmation() string {
 		case m.PromptConfirmationAction == "ready" && m.Ctx.View == config.PRsView:
 			prompt = "Are you sure you want to mark this PR as ready? (Y/n) "
 
-		case m.PromptConfirmationAction == "merge" && m.Ctx.View == config.PRsView:
-			prompt = "Are you sure you want to merge this PR? (Y/n) "
+		case m.PromptConfirmationAction == "draft" && m.Ctx.View == config.PRsView:
+			prompt = "Are you sure you want to convert this PR to draft? (Y/n) "
 
 		case m.PromptConfirmationAction == "update" && m.Ctx.View == config.PRsView:
 			prompt = "Are you sure you want to update this PR? (Y/n) "
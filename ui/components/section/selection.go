@@ -0,0 +1,135 @@
+package section
+
+import (
+	"fmt"
+
+	"github.com/dlvhdr/gh-dash/v4/config"
+	"github.com/dlvhdr/gh-dash/v4/data"
+	"github.com/dlvhdr/gh-dash/v4/ui/components/table"
+	"github.com/dlvhdr/gh-dash/v4/ui/constants"
+)
+
+// Selection marks rows for bulk actions. Rows are identified by their URL so
+// the selection survives rows being added, removed or reordered.
+type Selection interface {
+	ToggleSelection(row data.RowData)
+	ToggleSelectAll(rows []data.RowData)
+	ClearSelection()
+	IsSelected(row data.RowData) bool
+	NumSelected() int
+	StartVisualSelection(rows []data.RowData, from int)
+	UpdateVisualSelection(rows []data.RowData, to int)
+	StopVisualSelection()
+	IsVisualSelecting() bool
+}
+
+func (m *BaseModel) ToggleSelection(row data.RowData) {
+	if m.selected[row.GetUrl()] {
+		delete(m.selected, row.GetUrl())
+	} else {
+		m.selected[row.GetUrl()] = true
+	}
+}
+
+// ToggleSelectAll selects all rows, or clears the selection if all rows are
+// already selected.
+func (m *BaseModel) ToggleSelectAll(rows []data.RowData) {
+	allSelected := true
+	for _, row := range rows {
+		if !m.selected[row.GetUrl()] {
+			allSelected = false
+			break
+		}
+	}
+
+	if allSelected {
+		m.ClearSelection()
+		return
+	}
+	for _, row := range rows {
+		m.selected[row.GetUrl()] = true
+	}
+}
+
+func (m *BaseModel) ClearSelection() {
+	m.selected = map[string]bool{}
+	m.StopVisualSelection()
+}
+
+func (m *BaseModel) IsSelected(row data.RowData) bool {
+	return m.selected[row.GetUrl()]
+}
+
+func (m *BaseModel) NumSelected() int {
+	return len(m.selected)
+}
+
+// StartVisualSelection selects the rows between from and the row passed to
+// UpdateVisualSelection, on top of the rows that are already selected.
+func (m *BaseModel) StartVisualSelection(rows []data.RowData, from int) {
+	m.visualAnchor = from
+	m.visualBase = make(map[string]bool, len(m.selected))
+	for url := range m.selected {
+		m.visualBase[url] = true
+	}
+	m.isVisualSelecting = true
+	m.UpdateVisualSelection(rows, from)
+}
+
+func (m *BaseModel) UpdateVisualSelection(rows []data.RowData, to int) {
+	if !m.isVisualSelecting {
+		return
+	}
+
+	from := m.visualAnchor
+	if from > to {
+		from, to = to, from
+	}
+	m.selected = make(map[string]bool, len(m.visualBase))
+	for url := range m.visualBase {
+		m.selected[url] = true
+	}
+	for i := from; i <= to && i < len(rows); i++ {
+		m.selected[rows[i].GetUrl()] = true
+	}
+}
+
+func (m *BaseModel) StopVisualSelection() {
+	m.isVisualSelecting = false
+	m.visualBase = nil
+}
+
+func (m *BaseModel) IsVisualSelecting() bool {
+	return m.isVisualSelecting
+}
+
+// MarkSelectedRow replaces the first cell of a selected row, the row's state,
+// with the selected icon.
+func (m *BaseModel) MarkSelectedRow(row table.Row, data data.RowData) table.Row {
+	if len(row) == 0 || !m.IsSelected(data) {
+		return row
+	}
+	row[0] = m.Ctx.Styles.Section.SelectedRowMarkerStyle.Render(constants.SelectedIcon)
+	return row
+}
+
+func (m *BaseModel) getSelectionNoun() string {
+	noun := "issues"
+	if m.Ctx.View == config.PRsView {
+		noun = "PRs"
+	}
+	return fmt.Sprintf("%d %s", m.NumSelected(), noun)
+}
+
+// GetSelectionPagerContent returns the number of selected rows to show in
+// the section's pager.
+func (m *BaseModel) GetSelectionPagerContent() string {
+	if m.NumSelected() == 0 {
+		return ""
+	}
+	content := fmt.Sprintf(" • %d selected", m.NumSelected())
+	if m.isVisualSelecting {
+		content += " (visual)"
+	}
+	return content
+}
@@ -8,6 +8,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
+	"github.com/dlvhdr/gh-dash/v4/config"
 	"github.com/dlvhdr/gh-dash/v4/ui/context"
 	"github.com/dlvhdr/gh-dash/v4/ui/keys"
 )
@@ -53,12 +54,20 @@ func (m Model) View() string {
 		return ""
 	}
 
-	height := m.ctx.MainContentHeight
-	style := m.ctx.Styles.Sidebar.Root.
+	height := m.ctx.PreviewHeight
+	style := m.ctx.Styles.Sidebar.Root
+	if m.ctx.PreviewPosition == config.PreviewBottom {
+		height -= m.ctx.Styles.Sidebar.BorderWidth
+		style = style.
+			BorderStyle(lipgloss.NormalBorder()).
+			BorderLeft(false).
+			BorderTop(true)
+	}
+	style = style.
 		Height(height).
-		MaxHeight(height).
-		Width(m.ctx.Config.Defaults.Preview.Width).
-		MaxWidth(m.ctx.Config.Defaults.Preview.Width)
+		MaxHeight(height + m.ctx.Styles.Sidebar.BorderWidth).
+		Width(m.ctx.PreviewWidth).
+		MaxWidth(m.ctx.PreviewWidth)
 
 	if m.data == "" {
 		return style.Align(lipgloss.Center).Render(
@@ -83,7 +92,27 @@ func (m *Model) GetSidebarContentWidth() int {
 	if m.ctx.Config == nil {
 		return 0
 	}
-	return m.ctx.Config.Defaults.Preview.Width - 2*m.ctx.Styles.Sidebar.ContentPadding - m.ctx.Styles.Sidebar.BorderWidth
+	return m.ctx.PreviewWidth - 2*m.ctx.Styles.Sidebar.ContentPadding - m.ctx.Styles.Sidebar.BorderWidth
+}
+
+// ScrollDown scrolls the sidebar down by n lines.
+func (m *Model) ScrollDown(n int) {
+	m.viewport.LineDown(n)
+}
+
+// ScrollUp scrolls the sidebar up by n lines.
+func (m *Model) ScrollUp(n int) {
+	m.viewport.LineUp(n)
+}
+
+// ScrollOffset returns how many lines the sidebar is scrolled down by.
+func (m *Model) ScrollOffset() int {
+	return m.viewport.YOffset
+}
+
+// SetScrollOffset scrolls the sidebar to n lines from the top.
+func (m *Model) SetScrollOffset(n int) {
+	m.viewport.SetYOffset(n)
 }
 
 func (m *Model) ScrollToTop() {
@@ -99,6 +128,9 @@ func (m *Model) UpdateProgramContext(ctx *context.ProgramContext) {
 		return
 	}
 	m.ctx = ctx
-	m.viewport.Height = m.ctx.MainContentHeight - m.ctx.Styles.Sidebar.PagerHeight
+	m.viewport.Height = m.ctx.PreviewHeight - m.ctx.Styles.Sidebar.PagerHeight
+	if m.ctx.PreviewPosition == config.PreviewBottom {
+		m.viewport.Height -= m.ctx.Styles.Sidebar.BorderWidth
+	}
 	m.viewport.Width = m.GetSidebarContentWidth()
 }
@@ -0,0 +1,184 @@
+package table
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/dlvhdr/gh-dash/v4/utils"
+)
+
+const (
+	matchStart = "\x1b[4m"
+	matchEnd   = "\x1b[24m"
+)
+
+// rowMatch is a row that matches the filter, with the matched runes of each
+// of its cells.
+type rowMatch struct {
+	index int
+	cells map[int][]int
+}
+
+// SetFilter shows only the rows that match query, without changing Rows.
+// Each word of query has to fuzzy match one of the row's shown cells. An
+// empty query shows every row.
+func (m *Model) SetFilter(query string) {
+	if query == m.filter {
+		return
+	}
+	m.filter = query
+	m.applyFilter()
+	m.rowsViewport.ResetCurrItem()
+	m.SyncViewPortContent()
+}
+
+func (m *Model) Filter() string {
+	return m.filter
+}
+
+func (m *Model) IsFiltered() bool {
+	return strings.TrimSpace(m.filter) != ""
+}
+
+// NumVisibleRows returns the number of rows that match the filter.
+func (m *Model) NumVisibleRows() int {
+	if !m.IsFiltered() {
+		return len(m.Rows)
+	}
+	return len(m.matches)
+}
+
+// RowIndex returns the index in Rows of the item-th visible row.
+func (m *Model) RowIndex(item int) (int, bool) {
+	if !m.IsFiltered() {
+		return item, item >= 0 && item < len(m.Rows)
+	}
+	if item < 0 || item >= len(m.matches) {
+		return 0, false
+	}
+	return m.matches[item].index, true
+}
+
+// Visible returns the items of the rows that match the table's filter, given
+// the items the rows were built from.
+func Visible[T any](m *Model, items []T) []T {
+	if !m.IsFiltered() {
+		return items
+	}
+
+	visible := make([]T, 0, len(m.matches))
+	for _, match := range m.matches {
+		if match.index < len(items) {
+			visible = append(visible, items[match.index])
+		}
+	}
+	return visible
+}
+
+func (m *Model) applyFilter() {
+	m.matches = nil
+	if !m.IsFiltered() {
+		return
+	}
+
+	terms := strings.Fields(m.filter)
+	for i, row := range m.Rows {
+		if match, ok := m.matchRow(row, terms); ok {
+			match.index = i
+			m.matches = append(m.matches, match)
+		}
+	}
+}
+
+// matchRow matches every term against the row's shown cells, keeping the
+// best scoring cell of each term.
+func (m *Model) matchRow(row Row, terms []string) (rowMatch, bool) {
+	cells := make([]string, len(row))
+	for i, cell := range row {
+		if i < len(m.Columns) && m.Columns[i].Hidden != nil && *m.Columns[i].Hidden {
+			continue
+		}
+		cells[i] = ansi.Strip(cell)
+	}
+
+	match := rowMatch{cells: map[int][]int{}}
+	for _, term := range terms {
+		best, bestScore := -1, 0
+		var bestIndexes []int
+		for i, cell := range cells {
+			score, indexes, ok := utils.FuzzyScore(term, cell)
+			if ok && (best == -1 || score > bestScore) {
+				best, bestScore, bestIndexes = i, score, indexes
+			}
+		}
+		if best == -1 {
+			return rowMatch{}, false
+		}
+		match.cells[best] = append(match.cells[best], bestIndexes...)
+	}
+	return match, true
+}
+
+// highlightMatches underlines the runes of cell at the given indexes, which
+// count the runes of the cell without its escape sequences. Underlining is
+// turned off right after each rune so the cell's own styles are kept.
+func highlightMatches(cell string, indexes []int) string {
+	if len(indexes) == 0 {
+		return cell
+	}
+	matched := make(map[int]bool, len(indexes))
+	for _, i := range indexes {
+		matched[i] = true
+	}
+
+	var b strings.Builder
+	i := 0
+	for len(cell) > 0 {
+		if n := escapeLen(cell); n > 0 {
+			b.WriteString(cell[:n])
+			cell = cell[n:]
+			continue
+		}
+
+		_, size := utf8.DecodeRuneInString(cell)
+		if matched[i] {
+			b.WriteString(matchStart + cell[:size] + matchEnd)
+		} else {
+			b.WriteString(cell[:size])
+		}
+		cell = cell[size:]
+		i++
+	}
+	return b.String()
+}
+
+// escapeLen returns the length of the CSI or OSC escape sequence s starts
+// with, or 0 if it doesn't start with one.
+func escapeLen(s string) int {
+	if len(s) < 2 || s[0] != ansi.ESC {
+		return 0
+	}
+
+	switch s[1] {
+	case '[':
+		for i := 2; i < len(s); i++ {
+			if s[i] >= 0x40 && s[i] <= 0x7e {
+				return i + 1
+			}
+		}
+	case ']':
+		for i := 2; i < len(s); i++ {
+			if s[i] == ansi.BEL {
+				return i + 1
+			}
+			if s[i] == ansi.ESC && i+1 < len(s) && s[i+1] == '\\' {
+				return i + 2
+			}
+		}
+	default:
+		return 2
+	}
+	return len(s)
+}
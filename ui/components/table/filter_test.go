@@ -0,0 +1,72 @@
+package table_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dlvhdr/gh-dash/v4/config"
+	"github.com/dlvhdr/gh-dash/v4/ui/components/table"
+	"github.com/dlvhdr/gh-dash/v4/ui/constants"
+	"github.com/dlvhdr/gh-dash/v4/ui/context"
+)
+
+func TestSetFilter(t *testing.T) {
+	hidden := true
+	columns := []table.Column{{Title: "Title"}, {Title: "Author"}, {Title: "Secret", Hidden: &hidden}}
+	rows := []table.Row{
+		{lipgloss.NewStyle().Bold(true).Render("Fix the flaky test"), "dlvhdr", "bug"},
+		{"Add a bug report template", "octocat", "docs"},
+		{"Bump deps", "dependabot", "bug"},
+	}
+
+	testCases := map[string]struct {
+		filter string
+		want   []int
+	}{
+		"empty filter shows every row": {
+			filter: "",
+			want:   []int{0, 1, 2},
+		},
+		"fuzzy matches a cell": {
+			filter: "flky",
+			want:   []int{0},
+		},
+		"every word has to match": {
+			filter: "bug octo",
+			want:   []int{1},
+		},
+		"hidden columns aren't matched": {
+			filter: "bug",
+			want:   []int{1},
+		},
+		"matches ignore case": {
+			filter: "DEPENDABOT",
+			want:   []int{2},
+		},
+		"no matches": {
+			filter: "xyz",
+			want:   []int{},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.ProgramContext{Config: &config.Config{Theme: &config.ThemeConfig{}}}
+			m := table.NewModel(ctx, constants.Dimensions{Width: 80, Height: 20}, time.Now(), columns, rows, "PR", nil, "", false)
+
+			m.SetFilter(tc.filter)
+
+			require.Equal(t, len(tc.want), m.NumVisibleRows())
+			require.Equal(t, tc.want, table.Visible(&m, []int{0, 1, 2}))
+			for item, want := range tc.want {
+				got, ok := m.RowIndex(item)
+				require.True(t, ok)
+				require.Equal(t, want, got)
+			}
+			require.Equal(t, rows, m.Rows)
+		})
+	}
+}
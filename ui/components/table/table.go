@@ -24,6 +24,8 @@ type Model struct {
 	loadingSpinner spinner.Model
 	dimensions     constants.Dimensions
 	rowsViewport   listviewport.Model
+	filter         string
+	matches        []rowMatch
 }
 
 type Column struct {
@@ -132,6 +134,23 @@ func (m *Model) NextItem() int {
 	return currItem
 }
 
+// RowAt returns the row shown at line y of the table, counting from its
+// header.
+func (m *Model) RowAt(y int) (int, bool) {
+	if m.isLoading {
+		return 0, false
+	}
+	return m.rowsViewport.ItemAt(y - common.TableHeaderHeight)
+}
+
+// SetCurrItem selects the row at item, which has to be shown in the table.
+func (m *Model) SetCurrItem(item int) int {
+	m.rowsViewport.SetCurrItem(item)
+	m.SyncViewPortContent()
+
+	return m.rowsViewport.GetCurrItem()
+}
+
 func (m *Model) FirstItem() int {
 	currItem := m.rowsViewport.FirstItem()
 	m.SyncViewPortContent()
@@ -159,8 +178,8 @@ func (m *Model) cacheColumnWidths() {
 func (m *Model) SyncViewPortContent() {
 	headerColumns := m.renderHeaderColumns()
 	m.cacheColumnWidths()
-	renderedRows := make([]string, 0, len(m.Rows))
-	for i := range m.Rows {
+	renderedRows := make([]string, 0, m.NumVisibleRows())
+	for i := 0; i < m.NumVisibleRows(); i++ {
 		renderedRows = append(renderedRows, m.renderRow(i, headerColumns))
 	}
 
@@ -171,7 +190,8 @@ func (m *Model) SyncViewPortContent() {
 
 func (m *Model) SetRows(rows []Row) {
 	m.Rows = rows
-	m.rowsViewport.SetNumItems(len(m.Rows))
+	m.applyFilter()
+	m.rowsViewport.SetNumItems(m.NumVisibleRows())
 	m.SyncViewPortContent()
 }
 
@@ -270,6 +290,12 @@ func (m *Model) renderBody() string {
 		return bodyStyle.Render(*m.EmptyState)
 	}
 
+	if m.NumVisibleRows() == 0 {
+		return bodyStyle.Render(m.ctx.Styles.Section.EmptyStateStyle.Render(
+			fmt.Sprintf("No loaded rows match \"%s\"", m.filter),
+		))
+	}
+
 	return m.rowsViewport.View()
 }
 
@@ -282,6 +308,12 @@ func (m *Model) renderRow(rowId int, headerColumns []string) string {
 		style = m.ctx.Styles.Table.CellStyle
 	}
 
+	rowIndex, _ := m.RowIndex(rowId)
+	var match rowMatch
+	if m.IsFiltered() {
+		match = m.matches[rowId]
+	}
+
 	renderedColumns := make([]string, 0, len(m.Columns))
 	headerColId := 0
 
@@ -295,7 +327,7 @@ func (m *Model) renderRow(rowId int, headerColumns []string) string {
 		if !m.ctx.Config.Theme.Ui.Table.Compact {
 			colHeight = 2
 		}
-		col := m.Rows[rowId][i]
+		col := highlightMatches(m.Rows[rowIndex][i], match.cells[i])
 		renderedCol := style.
 			Width(colWidth).
 			MaxWidth(colWidth).
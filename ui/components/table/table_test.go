@@ -0,0 +1,80 @@
+package table_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dlvhdr/gh-dash/v4/config"
+	"github.com/dlvhdr/gh-dash/v4/ui/components/table"
+	"github.com/dlvhdr/gh-dash/v4/ui/constants"
+	"github.com/dlvhdr/gh-dash/v4/ui/context"
+)
+
+func TestRowAt(t *testing.T) {
+	rows := make([]table.Row, 20)
+	for i := range rows {
+		rows[i] = table.Row{"row"}
+	}
+
+	testCases := map[string]struct {
+		table   config.TableUIThemeConfig
+		y       int
+		want    int
+		wantHit bool
+	}{
+		"header": {
+			table: config.TableUIThemeConfig{Compact: true},
+			y:     1,
+		},
+		"compact first row": {
+			table:   config.TableUIThemeConfig{Compact: true},
+			y:       2,
+			want:    0,
+			wantHit: true,
+		},
+		"compact row": {
+			table:   config.TableUIThemeConfig{Compact: true},
+			y:       7,
+			want:    5,
+			wantHit: true,
+		},
+		"compact with separators": {
+			table:   config.TableUIThemeConfig{Compact: true, ShowSeparator: true},
+			y:       7,
+			want:    2,
+			wantHit: true,
+		},
+		"second line of a row": {
+			table:   config.TableUIThemeConfig{},
+			y:       5,
+			want:    1,
+			wantHit: true,
+		},
+		"separator below a row": {
+			table:   config.TableUIThemeConfig{ShowSeparator: true},
+			y:       4,
+			want:    0,
+			wantHit: true,
+		},
+		"below the table": {
+			table: config.TableUIThemeConfig{},
+			y:     30,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			cfg := config.Config{Theme: &config.ThemeConfig{Ui: config.UIThemeConfig{Table: tc.table}}}
+			ctx := context.ProgramContext{Config: &cfg}
+			m := table.NewModel(ctx, constants.Dimensions{Width: 80, Height: 20}, time.Now(), []table.Column{{Title: "Title"}}, rows, "PR", nil, "", false)
+
+			got, ok := m.RowAt(tc.y)
+			require.Equal(t, tc.wantHit, ok)
+			if tc.wantHit {
+				require.Equal(t, tc.want, got)
+			}
+		})
+	}
+}
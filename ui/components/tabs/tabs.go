@@ -30,6 +30,37 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 }
 
 func (m Model) View(ctx context.ProgramContext) string {
+	tabs := m.renderTabs(ctx)
+	renderedTabs := lipgloss.NewStyle().
+		Width(ctx.ScreenWidth).
+		MaxWidth(ctx.ScreenWidth).
+		Render(lipgloss.JoinHorizontal(lipgloss.Top, strings.Join(tabs, m.renderSeparator(ctx))))
+
+	return ctx.Styles.Tabs.TabsRow.
+		Width(ctx.ScreenWidth).
+		MaxWidth(ctx.ScreenWidth).
+		Render(renderedTabs)
+}
+
+// SectionAt returns the id of the section whose tab is at column x.
+func (m Model) SectionAt(ctx context.ProgramContext, x int) (int, bool) {
+	start := 0
+	separatorWidth := lipgloss.Width(m.renderSeparator(ctx))
+	for i, tab := range m.renderTabs(ctx) {
+		end := start + lipgloss.Width(tab)
+		if x >= start && x < end {
+			return i, true
+		}
+		start = end + separatorWidth
+	}
+	return 0, false
+}
+
+func (m Model) renderSeparator(ctx context.ProgramContext) string {
+	return ctx.Styles.Tabs.TabSeparator.Render("|")
+}
+
+func (m Model) renderTabs(ctx context.ProgramContext) []string {
 	sectionTitles := make([]string, 0, len(m.sectionsConfigs))
 	for i, section := range m.sectionsConfigs {
 		title := section.Title
@@ -48,16 +79,7 @@ func (m Model) View(ctx context.ProgramContext) string {
 			tabs = append(tabs, ctx.Styles.Tabs.Tab.Render(sectionTitle))
 		}
 	}
-
-	renderedTabs := lipgloss.NewStyle().
-		Width(ctx.ScreenWidth).
-		MaxWidth(ctx.ScreenWidth).
-		Render(lipgloss.JoinHorizontal(lipgloss.Top, strings.Join(tabs, ctx.Styles.Tabs.TabSeparator.Render("|"))))
-
-	return ctx.Styles.Tabs.TabsRow.
-		Width(ctx.ScreenWidth).
-		MaxWidth(ctx.ScreenWidth).
-		Render(renderedTabs)
+	return tabs
 }
 
 func (m *Model) SetCurrSectionId(id int) {
@@ -0,0 +1,146 @@
+package taskhistory
+
+import (
+	"time"
+
+	"github.com/dlvhdr/gh-dash/v4/ui/constants"
+	"github.com/dlvhdr/gh-dash/v4/ui/context"
+)
+
+// maxTasks is the number of tasks the log keeps. Older tasks are dropped
+// once they're done.
+const maxTasks = 100
+
+type entry struct {
+	task      context.Task
+	isCleared bool
+}
+
+// Log keeps the tasks started in this session, oldest first. Tasks started
+// again, e.g. when retried, get a new entry so failed attempts are kept.
+type Log struct {
+	entries []entry
+}
+
+func NewLog() *Log {
+	return &Log{}
+}
+
+func (l *Log) Start(task context.Task) {
+	l.entries = append(l.entries, entry{task: task})
+	if len(l.entries) <= maxTasks {
+		return
+	}
+	for i, e := range l.entries {
+		if e.task.State != context.TaskStart {
+			l.entries = append(l.entries[:i], l.entries[i+1:]...)
+			return
+		}
+	}
+}
+
+// find returns the latest entry of the task with the given id, preferring
+// the one that's still running.
+func (l *Log) find(id string) *entry {
+	var latest *entry
+	for i := len(l.entries) - 1; i >= 0; i-- {
+		e := &l.entries[i]
+		if e.task.Id != id {
+			continue
+		}
+		if e.task.State == context.TaskStart {
+			return e
+		}
+		if latest == nil {
+			latest = e
+		}
+	}
+	return latest
+}
+
+// Get returns the latest task with the given id.
+func (l *Log) Get(id string) (context.Task, bool) {
+	e := l.find(id)
+	if e == nil {
+		return context.Task{}, false
+	}
+	return e.task, true
+}
+
+// SetStartText updates the text of a running task, e.g. to report progress.
+func (l *Log) SetStartText(id string, text string) bool {
+	e := l.find(id)
+	if e == nil {
+		return false
+	}
+	e.task.StartText = text
+	return true
+}
+
+// Finish records the result of a task.
+func (l *Log) Finish(msg constants.TaskFinishedMsg) (context.Task, bool) {
+	e := l.find(msg.TaskId)
+	if e == nil {
+		return context.Task{}, false
+	}
+
+	if msg.Err != nil {
+		e.task.State = context.TaskError
+		e.task.Error = msg.Err
+	} else {
+		e.task.State = context.TaskFinished
+	}
+	now := time.Now()
+	e.task.FinishedTime = &now
+	e.task.Stderr = msg.Stderr
+	if msg.FinishedText != "" {
+		e.task.FinishedText = msg.FinishedText
+	}
+	if msg.Retry != nil {
+		e.task.Retry = msg.Retry
+	}
+	return e.task, true
+}
+
+// Clear hides the finished tasks with the given id from the footer, including
+// earlier attempts of a task that's running again. They're still listed in
+// the task history.
+func (l *Log) Clear(id string) {
+	for i := range l.entries {
+		e := &l.entries[i]
+		if e.task.Id == id && e.task.State != context.TaskStart {
+			e.isCleared = true
+		}
+	}
+}
+
+// Tasks returns all tasks, most recently started first.
+func (l *Log) Tasks() []context.Task {
+	tasks := make([]context.Task, 0, len(l.entries))
+	for i := len(l.entries) - 1; i >= 0; i-- {
+		tasks = append(tasks, l.entries[i].task)
+	}
+	return tasks
+}
+
+// Visible returns the tasks that should be shown in the footer: the running
+// tasks and the finished ones that weren't cleared yet.
+func (l *Log) Visible() []context.Task {
+	var tasks []context.Task
+	for _, e := range l.entries {
+		if !e.isCleared {
+			tasks = append(tasks, e.task)
+		}
+	}
+	return tasks
+}
+
+func (l *Log) NumRunning() int {
+	n := 0
+	for _, e := range l.entries {
+		if e.task.State == context.TaskStart {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,77 @@
+package taskhistory_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dlvhdr/gh-dash/v4/ui/components/taskhistory"
+	"github.com/dlvhdr/gh-dash/v4/ui/constants"
+	"github.com/dlvhdr/gh-dash/v4/ui/context"
+)
+
+func TestLogKeepsRetriedTasks(t *testing.T) {
+	log := taskhistory.NewLog()
+	log.Start(context.Task{Id: "pr_close_1", State: context.TaskStart})
+	log.Start(context.Task{Id: "pr_close_2", State: context.TaskStart})
+	require.Equal(t, 2, log.NumRunning())
+
+	task, ok := log.Finish(constants.TaskFinishedMsg{
+		TaskId: "pr_close_1",
+		Err:    errors.New("exit status 1"),
+		Stderr: "GraphQL: Could not resolve to a PullRequest",
+	})
+	require.True(t, ok)
+	require.Equal(t, context.TaskError, task.State)
+	require.Equal(t, "GraphQL: Could not resolve to a PullRequest", task.Stderr)
+	require.NotNil(t, task.FinishedTime)
+
+	log.Start(context.Task{Id: "pr_close_1", State: context.TaskStart})
+	task, ok = log.Finish(constants.TaskFinishedMsg{TaskId: "pr_close_1"})
+	require.True(t, ok)
+	require.Equal(t, context.TaskFinished, task.State)
+
+	tasks := log.Tasks()
+	require.Len(t, tasks, 3)
+	require.Equal(t, context.TaskFinished, tasks[0].State)
+	require.Equal(t, context.TaskStart, tasks[1].State)
+	require.Equal(t, context.TaskError, tasks[2].State)
+	require.Equal(t, 1, log.NumRunning())
+
+	_, ok = log.Finish(constants.TaskFinishedMsg{TaskId: "unknown"})
+	require.False(t, ok)
+}
+
+func TestLogClear(t *testing.T) {
+	log := taskhistory.NewLog()
+	log.Start(context.Task{Id: "running", State: context.TaskStart})
+	log.Start(context.Task{Id: "done", State: context.TaskStart})
+	log.Finish(constants.TaskFinishedMsg{TaskId: "done"})
+
+	log.Clear("running")
+	log.Clear("done")
+
+	visible := log.Visible()
+	require.Len(t, visible, 1)
+	require.Equal(t, "running", visible[0].Id)
+	require.Len(t, log.Tasks(), 2)
+}
+
+func TestLogClearRetriedTask(t *testing.T) {
+	log := taskhistory.NewLog()
+	log.Start(context.Task{Id: "pr_close_1", State: context.TaskStart})
+	log.Finish(constants.TaskFinishedMsg{TaskId: "pr_close_1", Err: errors.New("exit status 1")})
+	log.Start(context.Task{Id: "pr_close_1", State: context.TaskStart})
+
+	// The failed attempt's clear fires while the retry is running.
+	log.Clear("pr_close_1")
+	visible := log.Visible()
+	require.Len(t, visible, 1)
+	require.Equal(t, context.TaskStart, visible[0].State)
+
+	log.Finish(constants.TaskFinishedMsg{TaskId: "pr_close_1"})
+	log.Clear("pr_close_1")
+	require.Empty(t, log.Visible())
+	require.Len(t, log.Tasks(), 2)
+}
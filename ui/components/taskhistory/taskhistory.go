@@ -0,0 +1,263 @@
+package taskhistory
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/dlvhdr/gh-dash/v4/ui/constants"
+	"github.com/dlvhdr/gh-dash/v4/ui/context"
+)
+
+var (
+	upKey     = key.NewBinding(key.WithKeys("up", "k"), key.WithHelp("↑/k", "up"))
+	downKey   = key.NewBinding(key.WithKeys("down", "j"), key.WithHelp("↓/j", "down"))
+	retryKey  = key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "retry"))
+	cancelKey = key.NewBinding(key.WithKeys("x"), key.WithHelp("x", "cancel"))
+	closeKey  = key.NewBinding(
+		key.WithKeys(tea.KeyEsc.String(), tea.KeyCtrlC.String(), "q"),
+		key.WithHelp("esc", "close"),
+	)
+)
+
+// Model is the task history panel. It lists the tasks in the log with their
+// times, command and error output, and retries the failed ones.
+type Model struct {
+	ctx    *context.ProgramContext
+	log    *Log
+	isOpen bool
+	cursor int
+	offset int
+	width  int
+	height int
+	help   help.Model
+}
+
+func NewModel(ctx *context.ProgramContext, log *Log) Model {
+	return Model{
+		ctx:  ctx,
+		log:  log,
+		help: help.New(),
+	}
+}
+
+func (m *Model) Open() {
+	m.isOpen = true
+	m.cursor = 0
+	m.offset = 0
+}
+
+func (m *Model) Close() {
+	m.isOpen = false
+}
+
+func (m *Model) IsOpen() bool {
+	return m.isOpen
+}
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	tasks := m.log.Tasks()
+	switch {
+	case key.Matches(keyMsg, closeKey):
+		m.Close()
+
+	case key.Matches(keyMsg, upKey):
+		if m.cursor > 0 {
+			m.cursor--
+		}
+
+	case key.Matches(keyMsg, downKey):
+		if m.cursor < len(tasks)-1 {
+			m.cursor++
+		}
+
+	case key.Matches(keyMsg, retryKey):
+		if m.cursor < len(tasks) && canRetry(tasks[m.cursor]) {
+			retry := tasks[m.cursor].Retry
+			// The retried task is added to the top of the list.
+			m.cursor = 0
+			return m, retry()
+		}
+
+	case key.Matches(keyMsg, cancelKey):
+		if m.cursor < len(tasks) && m.canCancel(tasks[m.cursor]) {
+			m.ctx.Jobs.Cancel(tasks[m.cursor].Id)
+		}
+	}
+
+	return m, nil
+}
+
+func canRetry(task context.Task) bool {
+	return task.State == context.TaskError && task.Retry != nil
+}
+
+// canCancel returns whether task is a running background job.
+func (m *Model) canCancel(task context.Task) bool {
+	return task.State == context.TaskStart && m.ctx.Jobs != nil && m.ctx.Jobs.IsRunning(task.Id)
+}
+
+func (m *Model) SetSize(width int, height int) {
+	m.width = width
+	m.height = height
+}
+
+func (m *Model) UpdateProgramContext(ctx *context.ProgramContext) {
+	m.ctx = ctx
+	m.help.Styles = ctx.Styles.Help.BubbleStyles
+}
+
+func (m *Model) View() string {
+	tasks := m.log.Tasks()
+	m.cursor = max(0, min(m.cursor, len(tasks)-1))
+
+	title := m.ctx.Styles.Common.MainTextStyle.Render(fmt.Sprintf("Tasks (%d)", len(tasks)))
+	keys := []key.Binding{upKey, downKey}
+	if m.cursor < len(tasks) && canRetry(tasks[m.cursor]) {
+		keys = append(keys, retryKey)
+	}
+	if m.cursor < len(tasks) && m.canCancel(tasks[m.cursor]) {
+		keys = append(keys, cancelKey)
+	}
+	keys = append(keys, closeKey)
+	helpView := m.help.ShortHelpView(keys)
+
+	if len(tasks) == 0 {
+		return lipgloss.JoinVertical(
+			lipgloss.Left,
+			title,
+			"",
+			lipgloss.NewStyle().Foreground(m.ctx.Theme.FaintText).Render("No tasks have run yet"),
+			"",
+			helpView,
+		)
+	}
+
+	entries := make([]string, len(tasks))
+	for i, task := range tasks {
+		entries[i] = m.renderTask(task, i == m.cursor)
+	}
+
+	available := m.height - lipgloss.Height(title) - lipgloss.Height(helpView) - 2
+	m.scrollToCursor(entries, available)
+
+	var visible []string
+	used := 0
+	for i := m.offset; i < len(entries); i++ {
+		h := lipgloss.Height(entries[i]) + 1
+		if used+h > available && len(visible) > 0 {
+			break
+		}
+		visible = append(visible, entries[i], "")
+		used += h
+	}
+
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		title,
+		"",
+		lipgloss.JoinVertical(lipgloss.Left, visible...),
+		helpView,
+	)
+}
+
+// scrollToCursor moves the offset so the entry under the cursor fits in the
+// available height.
+func (m *Model) scrollToCursor(entries []string, available int) {
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+		return
+	}
+	for m.offset < m.cursor {
+		used := 0
+		for i := m.offset; i <= m.cursor; i++ {
+			used += lipgloss.Height(entries[i]) + 1
+		}
+		if used <= available {
+			return
+		}
+		m.offset++
+	}
+}
+
+func (m *Model) renderTask(task context.Task, isSelected bool) string {
+	faint := lipgloss.NewStyle().Foreground(m.ctx.Theme.FaintText)
+	errStyle := lipgloss.NewStyle().Foreground(m.ctx.Theme.ErrorText)
+	textWidth := max(m.width-2, 0)
+
+	var icon, text string
+	switch task.State {
+	case context.TaskStart:
+		icon = lipgloss.NewStyle().Foreground(m.ctx.Theme.WarningText).Render(constants.WaitingIcon)
+		text = task.StartText
+	case context.TaskError:
+		icon = errStyle.Render(constants.FailureIcon)
+		text = task.StartText
+	case context.TaskFinished:
+		icon = lipgloss.NewStyle().Foreground(m.ctx.Theme.SuccessText).Render(constants.SuccessIcon)
+		text = task.FinishedText
+	}
+
+	textStyle := lipgloss.NewStyle().Foreground(m.ctx.Theme.SecondaryText)
+	prefix := "  "
+	if isSelected {
+		textStyle = textStyle.Foreground(m.ctx.Theme.PrimaryText).Bold(true)
+		prefix = "> "
+	}
+
+	lines := []string{
+		lipgloss.NewStyle().Width(textWidth).Render(icon + " " + textStyle.Render(text)),
+		faint.Render(formatTimes(task, time.Now())),
+	}
+	if task.Command != "" {
+		lines = append(lines, faint.Width(textWidth).Render("$ "+task.Command))
+	} else if task.Mutation != "" {
+		lines = append(lines, faint.Width(textWidth).Render("mutation "+task.Mutation))
+	}
+	if task.State == context.TaskError {
+		details := task.Stderr
+		if details == "" && task.Error != nil {
+			details = task.Error.Error()
+		}
+		lines = append(lines, errStyle.Width(textWidth).Render(details))
+	}
+
+	return lipgloss.JoinHorizontal(
+		lipgloss.Top,
+		textStyle.Render(prefix),
+		lipgloss.JoinVertical(lipgloss.Left, lines...),
+	)
+}
+
+// formatTimes renders when the task started and finished and how long it
+// took, or for how long it's been running.
+func formatTimes(task context.Task, now time.Time) string {
+	const layout = "15:04:05"
+	if task.FinishedTime == nil {
+		return fmt.Sprintf("%s · running for %s",
+			task.StartTime.Format(layout),
+			formatDuration(now.Sub(task.StartTime)),
+		)
+	}
+	return fmt.Sprintf("%s → %s · %s",
+		task.StartTime.Format(layout),
+		task.FinishedTime.Format(layout),
+		formatDuration(task.FinishedTime.Sub(task.StartTime)),
+	)
+}
+
+func formatDuration(d time.Duration) string {
+	if d < time.Second {
+		return d.Round(time.Millisecond).String()
+	}
+	return d.Round(100 * time.Millisecond).String()
+}
@@ -0,0 +1,112 @@
+package tasklist
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/dlvhdr/gh-dash/v4/ui/common"
+	"github.com/dlvhdr/gh-dash/v4/ui/context"
+)
+
+// Model navigates and toggles the task-list items of a markdown body.
+type Model struct {
+	ctx      *context.ProgramContext
+	original string
+	body     string
+	items    []common.TaskListItem
+	cursor   int
+	help     help.Model
+}
+
+var taskListKeys = []key.Binding{
+	key.NewBinding(key.WithKeys("j", "k"), key.WithHelp("j/k", "navigate")),
+	key.NewBinding(key.WithKeys(" ", "x"), key.WithHelp("space/x", "toggle")),
+	key.NewBinding(key.WithKeys(tea.KeyCtrlD.String()), key.WithHelp("Ctrl+d", "save")),
+	key.NewBinding(key.WithKeys(tea.KeyCtrlC.String(), tea.KeyEsc.String()), key.WithHelp("Ctrl+c/esc", "cancel")),
+}
+
+func NewModel(ctx *context.ProgramContext) Model {
+	m := Model{ctx: ctx, help: help.New()}
+	m.UpdateProgramContext(ctx)
+	return m
+}
+
+// Open starts navigating the task-list items of body. It returns false if
+// body has no task-list items.
+func (m *Model) Open(body string) bool {
+	m.original = body
+	m.body = body
+	m.items = common.ParseTaskList(body)
+	m.cursor = 0
+	return len(m.items) > 0
+}
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok || len(m.items) == 0 {
+		return m, nil
+	}
+
+	switch keyMsg.String() {
+	case "j", "down":
+		m.cursor = (m.cursor + 1) % len(m.items)
+	case "k", "up":
+		m.cursor = (m.cursor - 1 + len(m.items)) % len(m.items)
+	case " ", "x", "enter":
+		item := m.items[m.cursor]
+		m.body = common.ToggleTaskListItem(m.body, item)
+		m.items[m.cursor].Checked = !item.Checked
+	}
+
+	return m, nil
+}
+
+// Body returns the body with the toggled items.
+func (m *Model) Body() string {
+	return m.body
+}
+
+func (m *Model) IsChanged() bool {
+	return m.body != m.original
+}
+
+// HighlightedBody returns the body with the selected item highlighted, to be
+// rendered in place of the original body.
+func (m *Model) HighlightedBody() string {
+	if len(m.items) == 0 {
+		return m.body
+	}
+	item := m.items[m.cursor]
+	return common.HighlightTaskListItem(m.body, item, fmt.Sprintf("➜ **%s**", item.Text))
+}
+
+func (m *Model) UpdateProgramContext(ctx *context.ProgramContext) {
+	m.ctx = ctx
+	m.help.Styles = ctx.Styles.Help.BubbleStyles
+}
+
+func (m Model) View() string {
+	done := 0
+	for _, item := range m.items {
+		if item.Checked {
+			done++
+		}
+	}
+
+	return lipgloss.NewStyle().
+		BorderTop(true).
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderForeground(m.ctx.Theme.SecondaryBorder).
+		MarginTop(1).
+		Render(
+			lipgloss.JoinVertical(
+				lipgloss.Left,
+				fmt.Sprintf("Tasks (%d of %d done)\n", done, len(m.items)),
+				m.help.ShortHelpView(taskListKeys),
+			),
+		)
+}
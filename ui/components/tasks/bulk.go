@@ -0,0 +1,137 @@
+package tasks
+
+import (
+	stdcontext "context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/log"
+
+	"github.com/dlvhdr/gh-dash/v4/data"
+	"github.com/dlvhdr/gh-dash/v4/ui/constants"
+	"github.com/dlvhdr/gh-dash/v4/ui/context"
+)
+
+// BulkAction runs an action on a single row of a bulk task. It returns the
+// msg that updates the row in its section.
+type BulkAction func(row data.RowData) (tea.Msg, error)
+
+type BulkTask struct {
+	Id           string
+	Section      SectionIdentifer
+	Rows         []data.RowData
+	StartText    string
+	FinishedText string
+	// Mutation is the GraphQL mutation Action sends for each row, shown in
+	// the task history.
+	Mutation string
+	Action   BulkAction
+}
+
+// RunBulkTask runs the task's action on its rows one after the other,
+// reporting the progress in the footer. Failures don't stop the task, they're
+// summarized once all rows have been handled.
+func RunBulkTask(ctx *context.ProgramContext, task BulkTask) tea.Cmd {
+	if len(task.Rows) == 0 {
+		return nil
+	}
+
+	start := context.Task{
+		Id:           task.Id,
+		StartText:    task.progressText(0),
+		FinishedText: task.FinishedText,
+		State:        context.TaskStart,
+		Error:        nil,
+	}
+	if task.Mutation != "" {
+		start.Mutation = fmt.Sprintf("%s for %d items", task.Mutation, len(task.Rows))
+	}
+	jobCtx, ok := ctx.Jobs.Start(task.Id)
+	if !ok {
+		return func() tea.Msg {
+			return constants.ErrMsg{Err: fmt.Errorf("%s is already running", task.StartText)}
+		}
+	}
+	startCmd := ctx.StartTask(start)
+	return tea.Batch(startCmd, task.step(ctx, jobCtx, 0, nil, nil))
+}
+
+func (task BulkTask) progressText(done int) string {
+	return fmt.Sprintf("%s (%d/%d)", task.StartText, done, len(task.Rows))
+}
+
+func (task BulkTask) step(
+	ctx *context.ProgramContext,
+	jobCtx stdcontext.Context,
+	i int,
+	failed []data.RowData,
+	failures []string,
+) tea.Cmd {
+	return func() tea.Msg {
+		if jobCtx.Err() != nil {
+			ctx.Jobs.Finish(task.Id)
+			return constants.TaskFinishedMsg{
+				TaskId:       task.Id,
+				SectionId:    task.Section.Id,
+				SectionType:  task.Section.Type,
+				FinishedText: fmt.Sprintf("%s: cancelled after %d of %d", task.StartText, i, len(task.Rows)),
+			}
+		}
+
+		row := task.Rows[i]
+		msg, err := task.Action(row)
+		if err != nil {
+			log.Error("Bulk action failed", "task", task.Id, "url", row.GetUrl(), "err", err)
+			failed = append(failed, row)
+			failures = append(failures, fmt.Sprintf("#%d: %v", row.GetNumber(), err))
+		}
+
+		if i == len(task.Rows)-1 {
+			ctx.Jobs.Finish(task.Id)
+			finished := constants.TaskFinishedMsg{
+				TaskId:      task.Id,
+				SectionId:   task.Section.Id,
+				SectionType: task.Section.Type,
+				Msg:         msg,
+			}
+			if len(failures) > 0 {
+				finished.Err = fmt.Errorf("%d of %d failed: %s", len(failures), len(task.Rows), strings.Join(failures, "; "))
+				finished.Stderr = strings.Join(failures, "\n")
+				finished.Retry = func() tea.Cmd {
+					retry := task
+					retry.Rows = failed
+					return RunBulkTask(ctx, retry)
+				}
+			}
+			return finished
+		}
+
+		return constants.TaskProgressMsg{
+			TaskId:      task.Id,
+			SectionId:   task.Section.Id,
+			SectionType: task.Section.Type,
+			StartText:   task.progressText(i + 1),
+			Msg:         msg,
+			Next:        task.step(ctx, jobCtx, i+1, failed, failures),
+		}
+	}
+}
+
+// ParseLabelChanges splits a whitespace or comma separated list of labels
+// into the labels to add and the labels to remove, which are prefixed with -.
+func ParseLabelChanges(input string) (added []string, removed []string) {
+	fields := strings.FieldsFunc(input, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\n' || r == '\t'
+	})
+	for _, field := range fields {
+		if name, ok := strings.CutPrefix(field, "-"); ok {
+			if name != "" {
+				removed = append(removed, name)
+			}
+		} else {
+			added = append(added, strings.TrimPrefix(field, "+"))
+		}
+	}
+	return added, removed
+}
@@ -0,0 +1,38 @@
+package tasks_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dlvhdr/gh-dash/v4/ui/components/tasks"
+)
+
+func TestParseLabelChanges(t *testing.T) {
+	testCases := map[string]struct {
+		input   string
+		added   []string
+		removed []string
+	}{
+		"empty": {
+			input: "  ",
+		},
+		"added": {
+			input: "bug, +docs",
+			added: []string{"bug", "docs"},
+		},
+		"added and removed": {
+			input:   "bug -triage\tenhancement -",
+			added:   []string{"bug", "enhancement"},
+			removed: []string{"triage"},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			added, removed := tasks.ParseLabelChanges(tc.input)
+			require.Equal(t, tc.added, added)
+			require.Equal(t, tc.removed, removed)
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package tasks
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/dlvhdr/gh-dash/v4/data"
+	"github.com/dlvhdr/gh-dash/v4/ui/constants"
+	"github.com/dlvhdr/gh-dash/v4/ui/context"
+)
+
+type NewIssue struct {
+	Repo      string
+	Title     string
+	Body      string
+	Labels    []string
+	Assignees []string
+}
+
+type IssueCreatedMsg struct {
+	Repo string
+	Url  string
+}
+
+func CreateIssue(ctx *context.ProgramContext, section SectionIdentifer, issue NewIssue) tea.Cmd {
+	args := []string{
+		"issue",
+		"create",
+		"-R",
+		issue.Repo,
+		"--title",
+		issue.Title,
+		"--body",
+		issue.Body,
+	}
+	if len(issue.Labels) > 0 {
+		args = append(args, "--label", strings.Join(issue.Labels, ","))
+	}
+	if len(issue.Assignees) > 0 {
+		args = append(args, "--assignee", strings.Join(issue.Assignees, ","))
+	}
+
+	taskId := fmt.Sprintf("create_issue_%s_%s", issue.Repo, issue.Title)
+	task := context.Task{
+		Id:           taskId,
+		StartText:    fmt.Sprintf(`Creating issue "%s" in %s`, issue.Title, issue.Repo),
+		FinishedText: fmt.Sprintf(`Issue "%s" has been created in %s`, issue.Title, issue.Repo),
+		State:        context.TaskStart,
+		Error:        nil,
+		Command:      GhCommand(args),
+	}
+
+	return Start(ctx, task, func() tea.Msg {
+		c := exec.Command("gh", args...)
+		var stdout bytes.Buffer
+		c.Stdout = &stdout
+
+		stderr, err := RunCommand(c)
+		if err != nil && stderr != "" {
+			err = errors.New(stderr)
+		}
+		return constants.TaskFinishedMsg{
+			SectionId:   section.Id,
+			SectionType: section.Type,
+			TaskId:      taskId,
+			Err:         err,
+			Msg: IssueCreatedMsg{
+				Repo: issue.Repo,
+				Url:  strings.TrimSpace(stdout.String()),
+			},
+			Stderr: stderr,
+		}
+	})
+}
+
+type UpdateIssueMsg struct {
+	// IssueUrl identifies the issue to update, since issue numbers repeat
+	// across repositories.
+	IssueUrl string
+	Labels   *[]data.Label
+	Title    *string
+	Body     *string
+	// Issue is the issue as returned by the API after it was updated. It
+	// replaces the issue in the section before the other fields are applied.
+	Issue *data.IssueData
+}
+
+// UpdatedIssueMsg returns the msg refreshing the issue in the section from the
+// issue a mutation returned.
+func UpdatedIssueMsg(issue data.IssueData, err error) (tea.Msg, error) {
+	if err != nil {
+		return nil, err
+	}
+	return UpdateIssueMsg{IssueUrl: issue.Url, Issue: &issue}, nil
+}
+
+// EditIssue sets the title and/or body of the issue, updating the section
+// right away and reverting the change if it fails. Nil values are left
+// unchanged.
+func EditIssue(ctx *context.ProgramContext, section SectionIdentifer, issue *data.IssueData, title *string, body *string) tea.Cmd {
+	issueNumber, issueId, issueUrl := issue.GetNumber(), issue.Id, issue.Url
+	originalTitle, originalBody := issue.Title, issue.Body
+	taskId := fmt.Sprintf("issue_edit_%d", issueNumber)
+	task := context.Task{
+		Id:           taskId,
+		StartText:    fmt.Sprintf("Updating issue #%d", issueNumber),
+		FinishedText: fmt.Sprintf("Issue #%d has been updated", issueNumber),
+		State:        context.TaskStart,
+		Error:        nil,
+	}
+
+	optimisticCmd := func() tea.Msg {
+		return makeSectionMsg(section, UpdateIssueMsg{
+			IssueUrl: issueUrl,
+			Title:    title,
+			Body:     body,
+		})
+	}
+
+	return tea.Batch(optimisticCmd, Mutate(ctx, section, task, issue, "updateIssue", func() (tea.Msg, error) {
+		updated, err := data.UpdateIssue(issueId, title, body)
+		if err != nil {
+			return UpdateIssueMsg{
+				IssueUrl: issueUrl,
+				Title:    &originalTitle,
+				Body:     &originalBody,
+			}, err
+		}
+		return UpdatedIssueMsg(updated, nil)
+	}))
+}
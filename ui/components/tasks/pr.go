@@ -3,12 +3,11 @@ package tasks
 import (
 	"fmt"
 	"os/exec"
-	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
-	"github.com/charmbracelet/log"
 
 	"github.com/dlvhdr/gh-dash/v4/data"
+	"github.com/dlvhdr/gh-dash/v4/ui/components/section"
 	"github.com/dlvhdr/gh-dash/v4/ui/constants"
 	"github.com/dlvhdr/gh-dash/v4/ui/context"
 	"github.com/dlvhdr/gh-dash/v4/utils"
@@ -20,13 +19,19 @@ type SectionIdentifer struct {
 }
 
 type UpdatePRMsg struct {
-	PrNumber         int
-	IsClosed         *bool
-	NewComment       *data.Comment
-	ReadyForReview   *bool
-	IsMerged         *bool
-	AddedAssignees   *data.Assignees
-	RemovedAssignees *data.Assignees
+	// PrUrl identifies the PR to update, since PR numbers repeat across
+	// repositories.
+	PrUrl    string
+	IsDraft  *bool
+	IsMerged *bool
+	Labels   *[]data.Label
+	Title    *string
+	Body     *string
+	Commits  *data.Commits
+	Files    *data.PullRequestFiles
+	// Pr is the PR as returned by the API after it was updated. It replaces
+	// the PR in the section before the other fields are applied.
+	Pr *data.PullRequestData
 }
 
 type UpdateBranchMsg struct {
@@ -35,6 +40,16 @@ type UpdateBranchMsg struct {
 	NewPr     *data.PullRequestData
 }
 
+// makeSectionMsg wraps msg so it's delivered to the section right away,
+// without waiting for a task to finish.
+func makeSectionMsg(sid SectionIdentifer, msg tea.Msg) tea.Msg {
+	return section.SectionMsg{
+		Id:          sid.Id,
+		Type:        sid.Type,
+		InternalMsg: msg,
+	}
+}
+
 func buildTaskId(prefix string, prNumber int) string {
 	return fmt.Sprintf("%s_%d", prefix, prNumber)
 }
@@ -55,20 +70,20 @@ func fireTask(ctx *context.ProgramContext, task GitHubTask) tea.Cmd {
 		FinishedText: task.FinishedText,
 		State:        context.TaskStart,
 		Error:        nil,
+		Command:      GhCommand(task.Args),
 	}
 
-	startCmd := ctx.StartTask(start)
-	return tea.Batch(startCmd, func() tea.Msg {
-		log.Debug("Running task", "cmd", "gh "+strings.Join(task.Args, " "))
+	return Start(ctx, start, func() tea.Msg {
 		c := exec.Command("gh", task.Args...)
 
-		err := c.Run()
+		stderr, err := RunCommand(c)
 		return constants.TaskFinishedMsg{
 			TaskId:      task.Id,
 			SectionId:   task.Section.Id,
 			SectionType: task.Section.Type,
 			Err:         err,
 			Msg:         task.Msg(c, err),
+			Stderr:      stderr,
 		}
 	})
 }
@@ -93,77 +108,116 @@ func OpenBranchPR(ctx *context.ProgramContext, section SectionIdentifer, branch
 	})
 }
 
-func ReopenPR(ctx *context.ProgramContext, section SectionIdentifer, pr data.RowData) tea.Cmd {
-	prNumber := pr.GetNumber()
-	return fireTask(ctx, GitHubTask{
-		Id: buildTaskId("pr_reopen", prNumber),
-		Args: []string{
-			"pr",
-			"reopen",
-			fmt.Sprint(prNumber),
-			"-R",
-			pr.GetRepoNameWithOwner(),
-		},
-		Section:      section,
+// Mutate starts task and runs mutate, which sends the GraphQL mutation for row
+// and returns the msg to deliver to the section once the task has finished.
+func Mutate(
+	ctx *context.ProgramContext,
+	section SectionIdentifer,
+	task context.Task,
+	row data.RowData,
+	mutation string,
+	mutate func() (tea.Msg, error),
+) tea.Cmd {
+	task.Mutation = fmt.Sprintf("%s %s#%d", mutation, row.GetRepoNameWithOwner(), row.GetNumber())
+	return Start(ctx, task, func() tea.Msg {
+		msg, err := mutate()
+		return constants.TaskFinishedMsg{
+			TaskId:      task.Id,
+			SectionId:   section.Id,
+			SectionType: section.Type,
+			Err:         err,
+			Msg:         msg,
+		}
+	})
+}
+
+// UpdatedPRMsg returns the msg refreshing the PR in the section from the PR a
+// mutation returned.
+func UpdatedPRMsg(pr data.PullRequestData, err error) (tea.Msg, error) {
+	if err != nil {
+		return nil, err
+	}
+	return UpdatePRMsg{PrUrl: pr.Url, Pr: &pr}, nil
+}
+
+// mutatePR starts a task running mutation and refreshes the PR in the section
+// from the PR the mutation returned.
+func mutatePR(
+	ctx *context.ProgramContext,
+	section SectionIdentifer,
+	task context.Task,
+	pr *data.PullRequestData,
+	mutationName string,
+	mutation func() (data.PullRequestData, error),
+) tea.Cmd {
+	task.State = context.TaskStart
+	prUrl := pr.Url
+	return Mutate(ctx, section, task, pr, mutationName, func() (tea.Msg, error) {
+		updated, err := mutation()
+		if err != nil {
+			return UpdatePRMsg{PrUrl: prUrl}, err
+		}
+		return UpdatePRMsg{PrUrl: prUrl, Pr: &updated}, nil
+	})
+}
+
+func ReopenPR(ctx *context.ProgramContext, section SectionIdentifer, pr *data.PullRequestData) tea.Cmd {
+	prNumber, prId := pr.GetNumber(), pr.Id
+	return mutatePR(ctx, section, context.Task{
+		Id:           buildTaskId("pr_reopen", prNumber),
 		StartText:    fmt.Sprintf("Reopening PR #%d", prNumber),
 		FinishedText: fmt.Sprintf("PR #%d has been reopened", prNumber),
-		Msg: func(c *exec.Cmd, err error) tea.Msg {
-			return UpdatePRMsg{
-				PrNumber: prNumber,
-				IsClosed: utils.BoolPtr(false),
-			}
-		},
+	}, pr, "reopenPullRequest", func() (data.PullRequestData, error) {
+		return data.ReopenPullRequest(prId)
 	})
 }
 
-func ClosePR(ctx *context.ProgramContext, section SectionIdentifer, pr data.RowData) tea.Cmd {
-	prNumber := pr.GetNumber()
-	return fireTask(ctx, GitHubTask{
-		Id: buildTaskId("pr_close", prNumber),
-		Args: []string{
-			"pr",
-			"close",
-			fmt.Sprint(prNumber),
-			"-R",
-			pr.GetRepoNameWithOwner(),
-		},
-		Section:      section,
+func ClosePR(ctx *context.ProgramContext, section SectionIdentifer, pr *data.PullRequestData) tea.Cmd {
+	prNumber, prId := pr.GetNumber(), pr.Id
+	return mutatePR(ctx, section, context.Task{
+		Id:           buildTaskId("pr_close", prNumber),
 		StartText:    fmt.Sprintf("Closing PR #%d", prNumber),
 		FinishedText: fmt.Sprintf("PR #%d has been closed", prNumber),
-		Msg: func(c *exec.Cmd, err error) tea.Msg {
-			return UpdatePRMsg{
-				PrNumber: prNumber,
-				IsClosed: utils.BoolPtr(true),
-			}
-		},
+	}, pr, "closePullRequest", func() (data.PullRequestData, error) {
+		return data.ClosePullRequest(prId)
 	})
 }
 
-func PRReady(ctx *context.ProgramContext, section SectionIdentifer, pr data.RowData) tea.Cmd {
-	prNumber := pr.GetNumber()
-	return fireTask(ctx, GitHubTask{
-		Id: buildTaskId("pr_ready", prNumber),
-		Args: []string{
-			"pr",
-			"ready",
-			fmt.Sprint(prNumber),
-			"-R",
-			pr.GetRepoNameWithOwner(),
-		},
-		Section:      section,
+func PRReady(ctx *context.ProgramContext, section SectionIdentifer, pr *data.PullRequestData) tea.Cmd {
+	return SetPRDraft(ctx, section, pr, false)
+}
+
+// SetPRDraft marks the PR as ready for review or converts it back to a draft.
+func SetPRDraft(ctx *context.ProgramContext, section SectionIdentifer, pr *data.PullRequestData, isDraft bool) tea.Cmd {
+	prNumber, prId, prUrl := pr.GetNumber(), pr.Id, pr.Url
+	task := context.Task{
+		Id:           buildTaskId("pr_draft", prNumber),
 		StartText:    fmt.Sprintf("Marking PR #%d as ready for review", prNumber),
 		FinishedText: fmt.Sprintf("PR #%d has been marked as ready for review", prNumber),
-		Msg: func(c *exec.Cmd, err error) tea.Msg {
-			return UpdatePRMsg{
-				PrNumber:       prNumber,
-				ReadyForReview: utils.BoolPtr(true),
-			}
-		},
+		State:        context.TaskStart,
+	}
+	mutation, mutationName := data.MarkPullRequestReadyForReview, "markPullRequestReadyForReview"
+	if isDraft {
+		task.StartText = fmt.Sprintf("Converting PR #%d to draft", prNumber)
+		task.FinishedText = fmt.Sprintf("PR #%d has been converted to draft", prNumber)
+		mutation, mutationName = data.ConvertPullRequestToDraft, "convertPullRequestToDraft"
+	}
+
+	return Mutate(ctx, section, task, pr, mutationName, func() (tea.Msg, error) {
+		updated, err := mutation(prId)
+		if err != nil {
+			return nil, err
+		}
+		return UpdatePRMsg{
+			PrUrl:   prUrl,
+			Pr:      &updated,
+			IsDraft: utils.BoolPtr(updated.IsDraft),
+		}, nil
 	})
 }
 
 func MergePR(ctx *context.ProgramContext, section SectionIdentifer, pr data.RowData) tea.Cmd {
-	prNumber := pr.GetNumber()
+	prNumber, prUrl := pr.GetNumber(), pr.GetUrl()
 	c := exec.Command(
 		"gh",
 		"pr",
@@ -195,13 +249,73 @@ func MergePR(ctx *context.ProgramContext, section SectionIdentifer, pr data.RowD
 			TaskId:      taskId,
 			Err:         err,
 			Msg: UpdatePRMsg{
-				PrNumber: prNumber,
+				PrUrl:    prUrl,
 				IsMerged: &isMerged,
 			},
 		}
 	}))
 }
 
+type MergeOptions struct {
+	Method       string
+	CommitTitle  string
+	CommitBody   string
+	DeleteBranch bool
+	AutoMerge    bool
+	MergeQueue   bool
+}
+
+// MergePRWithOptions merges the PR non-interactively using the given options.
+// When MergeQueue is set the PR is added to the base branch's merge queue,
+// and when AutoMerge is set the PR is merged once its requirements are met.
+func MergePRWithOptions(ctx *context.ProgramContext, section SectionIdentifer, pr *data.PullRequestData, opts MergeOptions) tea.Cmd {
+	prNumber, prId := pr.GetNumber(), pr.Id
+	if opts.MergeQueue {
+		return mutatePR(ctx, section, context.Task{
+			Id:           buildTaskId("pr_enqueue", prNumber),
+			StartText:    fmt.Sprintf("Adding PR #%d to the merge queue", prNumber),
+			FinishedText: fmt.Sprintf("PR #%d has been added to the merge queue", prNumber),
+		}, pr, "enqueuePullRequest", func() (data.PullRequestData, error) {
+			return data.EnqueuePullRequest(prId)
+		})
+	}
+
+	mergeOpts := data.MergeOptions{
+		Method:      opts.Method,
+		CommitTitle: opts.CommitTitle,
+		CommitBody:  opts.CommitBody,
+	}
+	if opts.AutoMerge {
+		return mutatePR(ctx, section, context.Task{
+			Id:           buildTaskId("pr_auto_merge", prNumber),
+			StartText:    fmt.Sprintf("Enabling auto-merge for PR #%d", prNumber),
+			FinishedText: fmt.Sprintf("Auto-merge has been enabled for PR #%d", prNumber),
+		}, pr, "enablePullRequestAutoMerge", func() (data.PullRequestData, error) {
+			return data.EnablePullRequestAutoMerge(prId, mergeOpts)
+		})
+	}
+
+	// Like gh, only delete head branches that live in the PR's repository.
+	headRefId := ""
+	if opts.DeleteBranch && pr.HeadRepositoryOwner.Login+"/"+pr.HeadRepository.Name == pr.GetRepoNameWithOwner() {
+		headRefId = pr.HeadRef.Id
+	}
+	return mutatePR(ctx, section, context.Task{
+		Id:           buildTaskId("pr_merge", prNumber),
+		StartText:    fmt.Sprintf("Merging PR #%d", prNumber),
+		FinishedText: fmt.Sprintf("PR #%d has been merged", prNumber),
+	}, pr, "mergePullRequest", func() (data.PullRequestData, error) {
+		merged, err := data.MergePullRequest(prId, mergeOpts)
+		if err != nil || headRefId == "" {
+			return merged, err
+		}
+		if err := data.DeleteRef(headRefId); err != nil {
+			return merged, fmt.Errorf("PR #%d was merged but deleting its branch failed: %w", prNumber, err)
+		}
+		return merged, nil
+	})
+}
+
 func CreatePR(ctx *context.ProgramContext, section SectionIdentifer, branchName string, title string) tea.Cmd {
 	c := exec.Command(
 		"gh",
@@ -239,25 +353,47 @@ func CreatePR(ctx *context.ProgramContext, section SectionIdentifer, branchName
 	}))
 }
 
-func UpdatePR(ctx *context.ProgramContext, section SectionIdentifer, pr data.RowData) tea.Cmd {
-	prNumber := pr.GetNumber()
-	return fireTask(ctx, GitHubTask{
-		Id: buildTaskId("pr_update", prNumber),
-		Args: []string{
-			"pr",
-			"update-branch",
-			fmt.Sprint(prNumber),
-			"-R",
-			pr.GetRepoNameWithOwner(),
-		},
-		Section:      section,
+func UpdatePR(ctx *context.ProgramContext, section SectionIdentifer, pr *data.PullRequestData) tea.Cmd {
+	prNumber, prId := pr.GetNumber(), pr.Id
+	return mutatePR(ctx, section, context.Task{
+		Id:           buildTaskId("pr_update", prNumber),
 		StartText:    fmt.Sprintf("Updating PR #%d", prNumber),
 		FinishedText: fmt.Sprintf("PR #%d has been updated", prNumber),
-		Msg: func(c *exec.Cmd, err error) tea.Msg {
-			return UpdatePRMsg{
-				PrNumber: prNumber,
-				IsClosed: utils.BoolPtr(true),
-			}
-		},
+	}, pr, "updatePullRequestBranch", func() (data.PullRequestData, error) {
+		return data.UpdatePullRequestBranch(prId)
 	})
 }
+
+// EditPR sets the title and/or body of the PR, updating the section right
+// away and reverting the change if it fails. Nil values are left unchanged.
+func EditPR(ctx *context.ProgramContext, section SectionIdentifer, pr *data.PullRequestData, title *string, body *string) tea.Cmd {
+	prNumber, prId, prUrl := pr.GetNumber(), pr.Id, pr.Url
+	originalTitle, originalBody := pr.Title, pr.Body
+	task := context.Task{
+		Id:           buildTaskId("pr_edit", prNumber),
+		StartText:    fmt.Sprintf("Updating PR #%d", prNumber),
+		FinishedText: fmt.Sprintf("PR #%d has been updated", prNumber),
+		State:        context.TaskStart,
+		Error:        nil,
+	}
+
+	optimisticCmd := func() tea.Msg {
+		return makeSectionMsg(section, UpdatePRMsg{
+			PrUrl: prUrl,
+			Title: title,
+			Body:  body,
+		})
+	}
+
+	return tea.Batch(optimisticCmd, Mutate(ctx, section, task, pr, "updatePullRequest", func() (tea.Msg, error) {
+		updated, err := data.UpdatePullRequest(prId, title, body)
+		if err != nil {
+			return UpdatePRMsg{
+				PrUrl: prUrl,
+				Title: &originalTitle,
+				Body:  &originalBody,
+			}, err
+		}
+		return UpdatePRMsg{PrUrl: prUrl, Pr: &updated}, nil
+	}))
+}
@@ -0,0 +1,49 @@
+package tasks
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/dlvhdr/gh-dash/v4/config"
+	"github.com/dlvhdr/gh-dash/v4/ui/constants"
+	"github.com/dlvhdr/gh-dash/v4/ui/context"
+)
+
+type SectionSavedMsg struct {
+	View   config.ViewType
+	Config config.SectionConfig
+}
+
+// SaveSection adds a section with sectionConfig to the view's sections in
+// the configuration file.
+func SaveSection(ctx *context.ProgramContext, section SectionIdentifer, view config.ViewType, sectionConfig config.SectionConfig) tea.Cmd {
+	taskId := fmt.Sprintf("save_section_%s_%s", view, sectionConfig.Title)
+	task := context.Task{
+		Id:           taskId,
+		StartText:    fmt.Sprintf(`Saving section "%s"`, sectionConfig.Title),
+		FinishedText: fmt.Sprintf(`Section "%s" has been saved`, sectionConfig.Title),
+		State:        context.TaskStart,
+		Error:        nil,
+	}
+
+	configPath := ctx.ConfigPath
+	return Start(ctx, task, func() tea.Msg {
+		var err error
+		if view == config.PRsView {
+			err = config.AddPrsSection(configPath, sectionConfig)
+		} else {
+			err = config.AddIssuesSection(configPath, sectionConfig)
+		}
+		return constants.TaskFinishedMsg{
+			SectionId:   section.Id,
+			SectionType: section.Type,
+			TaskId:      taskId,
+			Err:         err,
+			Msg: SectionSavedMsg{
+				View:   view,
+				Config: sectionConfig,
+			},
+		}
+	})
+}
@@ -0,0 +1,49 @@
+package tasks
+
+import (
+	"bytes"
+	"os/exec"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/log"
+
+	"github.com/dlvhdr/gh-dash/v4/ui/context"
+)
+
+// Start starts task and runs cmd, which returns the task's TaskFinishedMsg.
+// Retrying the task from the task history starts it again with the same cmd.
+func Start(ctx *context.ProgramContext, task context.Task, cmd tea.Cmd) tea.Cmd {
+	task.Retry = func() tea.Cmd {
+		return Start(ctx, task, cmd)
+	}
+	return tea.Batch(ctx.StartTask(task), cmd)
+}
+
+// GhCommand returns the gh command line shown in the task history for args.
+func GhCommand(args []string) string {
+	return "gh " + strings.Join(args, " ")
+}
+
+// RunCommand runs c and returns its stderr along with its error.
+func RunCommand(c *exec.Cmd) (string, error) {
+	log.Debug("Running task", "cmd", strings.Join(c.Args, " "))
+	var stderr bytes.Buffer
+	c.Stderr = &stderr
+
+	err := c.Run()
+	return strings.TrimSpace(stderr.String()), err
+}
+
+// LabelsMutation returns the name of the GraphQL mutations that add and
+// remove labels, for the task history.
+func LabelsMutation(added []string, removed []string) string {
+	switch {
+	case len(removed) == 0:
+		return "addLabelsToLabelable"
+	case len(added) == 0:
+		return "removeLabelsFromLabelable"
+	default:
+		return "removeLabelsFromLabelable+addLabelsToLabelable"
+	}
+}
@@ -0,0 +1,330 @@
+package timeline
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/glamour"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/dlvhdr/gh-dash/v4/data"
+	"github.com/dlvhdr/gh-dash/v4/ui/context"
+	"github.com/dlvhdr/gh-dash/v4/ui/markdown"
+	"github.com/dlvhdr/gh-dash/v4/utils"
+)
+
+// Filter is which items of a timeline are shown.
+type Filter int
+
+const (
+	AllItems Filter = iota
+	CommentsOnly
+	EventsOnly
+	numFilters
+)
+
+func (f Filter) String() string {
+	switch f {
+	case CommentsOnly:
+		return "comments"
+	case EventsOnly:
+		return "events"
+	}
+	return "all activity"
+}
+
+func (f Filter) matches(item data.PullRequestTimelineItem) bool {
+	switch f {
+	case CommentsOnly:
+		return item.IsComment()
+	case EventsOnly:
+		return !item.IsComment()
+	}
+	return true
+}
+
+var tableRegex = regexp.MustCompile(`((\n)+|^)([^\r\n]*\|[^\r\n]*(\n)?)+`)
+
+// Model renders the timeline of a PR or an issue. Consecutive items by bots
+// are collapsed into a single line until they're shown with ToggleBots.
+type Model struct {
+	ctx      *context.ProgramContext
+	width    int
+	filter   Filter
+	showBots bool
+}
+
+func NewModel(ctx *context.ProgramContext) Model {
+	return Model{ctx: ctx}
+}
+
+// CycleFilter shows the next kind of items: all of them, only comments and
+// reviews, or only events.
+func (m *Model) CycleFilter() {
+	m.filter = (m.filter + 1) % numFilters
+}
+
+// ToggleBots expands or collapses the items by bots.
+func (m *Model) ToggleBots() {
+	m.showBots = !m.showBots
+}
+
+func (m *Model) SetWidth(width int) {
+	m.width = width
+}
+
+func (m *Model) UpdateProgramContext(ctx *context.ProgramContext) {
+	m.ctx = ctx
+}
+
+// View renders the items that match the filter, oldest first.
+func (m Model) View(items []data.PullRequestTimelineItem) string {
+	markdownRenderer := markdown.GetMarkdownRenderer(m.width - 2)
+
+	var rendered []string
+	var bots []data.PullRequestTimelineItem
+	flushBots := func() {
+		if len(bots) > 0 {
+			rendered = append(rendered, m.renderCollapsedBots(bots))
+			bots = nil
+		}
+	}
+
+	for _, item := range items {
+		if !m.filter.matches(item) {
+			continue
+		}
+		if !m.showBots && item.Actor().IsBot() {
+			bots = append(bots, item)
+			continue
+		}
+		flushBots()
+		rendered = append(rendered, m.renderItem(item, markdownRenderer))
+	}
+	flushBots()
+
+	body := lipgloss.NewStyle().Italic(true).Render("No activity...")
+	if len(rendered) > 0 {
+		body = lipgloss.JoinVertical(lipgloss.Left, rendered...)
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, m.renderHeader(), body)
+}
+
+func (m *Model) renderHeader() string {
+	bots := "bots collapsed"
+	if m.showBots {
+		bots = "bots shown"
+	}
+	return lipgloss.NewStyle().
+		Foreground(m.ctx.Theme.FaintText).
+		Width(m.width).
+		MarginBottom(1).
+		Render(fmt.Sprintf("Showing %s · %s", m.filter, bots))
+}
+
+func (m *Model) renderCollapsedBots(items []data.PullRequestTimelineItem) string {
+	var logins []string
+	for _, item := range items {
+		login := item.Actor().GetLogin()
+		if !slices.Contains(logins, login) {
+			logins = append(logins, login)
+		}
+	}
+
+	noun := "items"
+	if len(items) == 1 {
+		noun = "item"
+	}
+	return m.faint().
+		Width(m.width).
+		MarginBottom(1).
+		Render(fmt.Sprintf("⋯ %d %s by %s", len(items), noun, strings.Join(logins, ", ")))
+}
+
+func (m *Model) renderItem(item data.PullRequestTimelineItem, markdownRenderer glamour.TermRenderer) string {
+	switch item.Typename {
+	case "IssueComment":
+		c := item.IssueComment
+		return m.renderComment(c.Author.GetLogin(), "", c.CreatedAt, c.Body, markdownRenderer)
+
+	case "PullRequestReview":
+		return m.renderReview(item.PullRequestReview, markdownRenderer)
+
+	case "PullRequestCommit":
+		c := item.PullRequestCommit.Commit
+		author := c.Author.Name
+		if c.Author.User != nil {
+			author = c.Author.User.Login
+		}
+		return m.renderEvent("", author,
+			fmt.Sprintf("pushed %s %s", m.faint().Render(c.AbbreviatedOid), c.MessageHeadline),
+			c.CommittedDate)
+
+	case "HeadRefForcePushedEvent":
+		e := item.HeadRefForcePushedEvent
+		before, after := "", ""
+		if e.BeforeCommit != nil {
+			before = e.BeforeCommit.AbbreviatedOid
+		}
+		if e.AfterCommit != nil {
+			after = e.AfterCommit.AbbreviatedOid
+		}
+		return m.renderEvent("", e.Actor.GetLogin(),
+			fmt.Sprintf("force-pushed %s → %s", before, after), e.CreatedAt)
+
+	case "ReviewRequestedEvent":
+		e := item.ReviewRequestedEvent
+		return m.renderEvent("󰈈", e.Actor.GetLogin(),
+			"requested a review from "+e.ReviewerName(), e.CreatedAt)
+
+	case "ReviewRequestRemovedEvent":
+		e := item.ReviewRequestRemovedEvent
+		return m.renderEvent("󰈉", e.Actor.GetLogin(),
+			"removed the review request for "+e.ReviewerName(), e.CreatedAt)
+
+	case "LabeledEvent":
+		e := item.LabeledEvent
+		return m.renderEvent("󰌕", e.Actor.GetLogin(), "added the "+m.renderLabel(e.Label.Name, e.Label.Color)+" label", e.CreatedAt)
+
+	case "UnlabeledEvent":
+		e := item.UnlabeledEvent
+		return m.renderEvent("󰌕", e.Actor.GetLogin(), "removed the "+m.renderLabel(e.Label.Name, e.Label.Color)+" label", e.CreatedAt)
+
+	case "AssignedEvent":
+		e := item.AssignedEvent
+		text := "assigned " + e.AssigneeLogin()
+		if e.AssigneeLogin() == e.Actor.GetLogin() {
+			text = "self-assigned this"
+		}
+		return m.renderEvent("", e.Actor.GetLogin(), text, e.CreatedAt)
+
+	case "UnassignedEvent":
+		e := item.UnassignedEvent
+		text := "unassigned " + e.AssigneeLogin()
+		if e.AssigneeLogin() == e.Actor.GetLogin() {
+			text = "removed their assignment"
+		}
+		return m.renderEvent("", e.Actor.GetLogin(), text, e.CreatedAt)
+
+	case "CrossReferencedEvent":
+		e := item.CrossReferencedEvent
+		number, title, repo := e.Source.Issue.Number, e.Source.Issue.Title, e.Source.Issue.Repository.NameWithOwner
+		if e.Source.Typename == "PullRequest" {
+			number, title, repo = e.Source.PullRequest.Number, e.Source.PullRequest.Title, e.Source.PullRequest.Repository.NameWithOwner
+		}
+		verb := "mentioned this in"
+		if e.WillCloseTarget {
+			verb = "linked a PR that will close this:"
+		}
+		return m.renderEvent("", e.Actor.GetLogin(),
+			fmt.Sprintf("%s %s#%d %s", verb, repo, number, title), e.CreatedAt)
+
+	case "MergedEvent":
+		e := item.MergedEvent
+		return m.renderEvent("", e.Actor.GetLogin(), "merged this into "+e.MergeRefName, e.CreatedAt)
+
+	case "ClosedEvent":
+		e := item.ClosedEvent
+		return m.renderEvent("", e.Actor.GetLogin(), "closed this", e.CreatedAt)
+
+	case "ReopenedEvent":
+		e := item.ReopenedEvent
+		return m.renderEvent("", e.Actor.GetLogin(), "reopened this", e.CreatedAt)
+
+	case "ReadyForReviewEvent":
+		e := item.ReadyForReviewEvent
+		return m.renderEvent("", e.Actor.GetLogin(), "marked this as ready for review", e.CreatedAt)
+
+	case "ConvertToDraftEvent":
+		e := item.ConvertToDraftEvent
+		return m.renderEvent("", e.Actor.GetLogin(), "converted this to a draft", e.CreatedAt)
+
+	case "RenamedTitleEvent":
+		e := item.RenamedTitleEvent
+		return m.renderEvent("", e.Actor.GetLogin(),
+			fmt.Sprintf("changed the title from %q to %q", e.PreviousTitle, e.CurrentTitle), e.CreatedAt)
+	}
+	return ""
+}
+
+func (m *Model) faint() lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(m.ctx.Theme.FaintText)
+}
+
+func (m *Model) renderLabel(name string, color string) string {
+	return lipgloss.NewStyle().Foreground(lipgloss.Color("#" + color)).Render(name)
+}
+
+// renderEvent renders a single line saying what actor did, like GitHub does
+// between the comments of a timeline.
+func (m *Model) renderEvent(glyph string, actor string, text string, at time.Time) string {
+	if glyph == "" {
+		glyph = "•"
+	}
+	return lipgloss.NewStyle().
+		Width(m.width).
+		MarginBottom(1).
+		Render(fmt.Sprintf("%s %s %s %s",
+			m.faint().Render(glyph),
+			m.ctx.Styles.Common.MainTextStyle.Render(actor),
+			text,
+			m.faint().Render(utils.TimeElapsed(at)),
+		))
+}
+
+func (m *Model) renderComment(author string, action string, at time.Time, body string, markdownRenderer glamour.TermRenderer) string {
+	parts := []string{m.ctx.Styles.Common.MainTextStyle.Render(author)}
+	if action != "" {
+		parts = append(parts, action)
+	}
+	parts = append(parts, m.faint().Render(utils.TimeElapsed(at)))
+	header := lipgloss.NewStyle().
+		Width(m.width).
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(m.ctx.Theme.FaintBorder).
+		Render(strings.Join(parts, " "))
+
+	body = strings.TrimSpace(tableRegex.ReplaceAllString(body, ""))
+	if body == "" {
+		return lipgloss.JoinVertical(lipgloss.Left, header, "")
+	}
+	rendered, err := markdownRenderer.Render(body)
+	if err != nil {
+		rendered = body
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, header, rendered)
+}
+
+func (m *Model) renderReview(review data.TimelineReview, markdownRenderer glamour.TermRenderer) string {
+	action := "reviewed"
+	switch review.State {
+	case "APPROVED":
+		action = m.ctx.Styles.Common.SuccessGlyph + " approved"
+	case "CHANGES_REQUESTED":
+		action = m.ctx.Styles.Common.FailureGlyph + " requested changes"
+	case "COMMENTED":
+		action = m.faint().Render("󰈈") + " reviewed"
+	case "PENDING":
+		action = m.ctx.Styles.Common.WaitingGlyph + " started a review"
+	case "DISMISSED":
+		action = m.faint().Render("󰅖") + " reviewed (dismissed)"
+	}
+
+	s := strings.Builder{}
+	s.WriteString(review.Body)
+	for _, c := range review.Comments.Nodes {
+		location := c.Path
+		if c.Line != nil {
+			location = fmt.Sprintf("%s#L%d", c.Path, *c.Line)
+		}
+		s.WriteString(fmt.Sprintf("\n\n`%s`\n\n%s", location, c.Body))
+	}
+	if hidden := review.Comments.TotalCount - len(review.Comments.Nodes); hidden > 0 {
+		s.WriteString(fmt.Sprintf("\n\n_…and %d more comments_", hidden))
+	}
+
+	return m.renderComment(review.Author.GetLogin(), action, review.CreatedAt, s.String(), markdownRenderer)
+}
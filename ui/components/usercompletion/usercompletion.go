@@ -0,0 +1,83 @@
+package usercompletion
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/log"
+
+	"github.com/dlvhdr/gh-dash/v4/data"
+	"github.com/dlvhdr/gh-dash/v4/ui/components/inputbox"
+)
+
+// FetchedMsg holds the completions fetched for the item identified by Url.
+type FetchedMsg struct {
+	Url         string
+	Suggestions []inputbox.Suggestion
+	Err         error
+}
+
+type Options struct {
+	// IncludeTeams adds the teams of the repository's organization, which
+	// can be requested as reviewers but not assigned.
+	IncludeTeams bool
+	// SuggestReviewers marks GitHub's suggested reviewers for the PR.
+	SuggestReviewers bool
+	// Suggested is a list of logins to mark as suggested.
+	Suggested []string
+}
+
+// Fetch returns a command that fetches the users (and optionally teams) that
+// can be assigned to or requested to review the item at url.
+func Fetch(repo string, url string, opts Options) tea.Cmd {
+	return func() tea.Msg {
+		users, err := data.FetchAssignableUsers(repo)
+		if err != nil {
+			return FetchedMsg{Url: url, Err: err}
+		}
+
+		suggested := map[string]bool{}
+		for _, login := range opts.Suggested {
+			suggested[login] = true
+		}
+		if opts.SuggestReviewers {
+			reviewers, err := data.FetchSuggestedReviewers(url)
+			if err != nil {
+				log.Error("Failed fetching suggested reviewers", "url", url, "err", err)
+			}
+			for _, r := range reviewers {
+				if !r.IsAuthor {
+					suggested[r.Reviewer.Login] = true
+				}
+			}
+		}
+
+		suggestions := make([]inputbox.Suggestion, 0, len(users))
+		for _, u := range users {
+			suggestions = append(suggestions, inputbox.Suggestion{
+				Value:     u.Login,
+				Detail:    u.Name,
+				Suggested: suggested[u.Login],
+			})
+		}
+
+		if opts.IncludeTeams {
+			// Listing teams requires the read:org scope, so don't fail the
+			// whole completion if it's missing
+			teams, err := data.FetchOrganizationTeams(repo)
+			if err != nil {
+				log.Error("Failed fetching organization teams", "repo", repo, "err", err)
+			}
+			owner, _, _ := strings.Cut(repo, "/")
+			for _, t := range teams {
+				suggestions = append(suggestions, inputbox.Suggestion{
+					Value:  fmt.Sprintf("%s/%s", owner, t.Slug),
+					Detail: t.Name,
+				})
+			}
+		}
+
+		return FetchedMsg{Url: url, Suggestions: suggestions}
+	}
+}
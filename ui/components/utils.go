@@ -7,6 +7,7 @@ import (
 
 	"github.com/charmbracelet/lipgloss"
 
+	"github.com/dlvhdr/gh-dash/v4/ui/constants"
 	"github.com/dlvhdr/gh-dash/v4/ui/context"
 )
 
@@ -28,6 +29,27 @@ func GetIssueTextStyle(
 	return lipgloss.NewStyle().Foreground(ctx.Theme.PrimaryText)
 }
 
+// RenderNoteTags renders the local tags of the item at url with baseStyle,
+// followed by a space, or nothing if it has none. They're rendered in the
+// title cell so the table's filter matches them.
+func RenderNoteTags(ctx *context.ProgramContext, url string, baseStyle lipgloss.Style) string {
+	note, ok := ctx.Notes.Get(url)
+	if !ok || len(note.Tags) == 0 {
+		return ""
+	}
+	return baseStyle.Foreground(ctx.Theme.WarningText).Render(note.FormattedTags() + " ")
+}
+
+// RenderNoteIndicator renders the glyph of the notes column when the item at
+// url has a local note.
+func RenderNoteIndicator(ctx *context.ProgramContext, url string) string {
+	note, ok := ctx.Notes.Get(url)
+	if !ok || note.Text == "" {
+		return ""
+	}
+	return lipgloss.NewStyle().Foreground(ctx.Theme.SecondaryText).Render(constants.NoteIcon)
+}
+
 func RenderIssueTitle(
 	ctx *context.ProgramContext,
 	state string,
@@ -0,0 +1,60 @@
+package whichkey
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/dlvhdr/gh-dash/v4/ui/context"
+	"github.com/dlvhdr/gh-dash/v4/ui/keys"
+)
+
+// maxRows is the most rows of hints shown, so the popup doesn't cover the
+// whole section.
+const maxRows = 8
+
+// View renders the keys that continue the pending keys and what they do, in
+// as many columns as fit in width, under a title with the pending keys.
+func View(ctx *context.ProgramContext, width int, pending string, hints []keys.Hint) string {
+	keyStyle := lipgloss.NewStyle().Foreground(ctx.Theme.WarningText).Bold(true)
+	faint := lipgloss.NewStyle().Foreground(ctx.Theme.FaintText)
+	style := lipgloss.NewStyle().
+		Width(width).
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderTop(true).
+		BorderForeground(ctx.Theme.PrimaryBorder).
+		PaddingLeft(1)
+	innerWidth := width - style.GetHorizontalFrameSize()
+
+	entries := make([]string, 0, len(hints))
+	entryWidth := 0
+	for _, hint := range hints {
+		entry := keyStyle.Render(hint.Key) + faint.Render(" → ") + hint.Desc
+		entries = append(entries, entry)
+		entryWidth = max(entryWidth, lipgloss.Width(entry))
+	}
+
+	title := keyStyle.Render(pending) + faint.Render(" …")
+	if len(entries) == 0 {
+		return style.Render(title + faint.Render("  no bindings continue these keys"))
+	}
+
+	const gap = 3
+	numCols := max(1, (innerWidth+gap)/(entryWidth+gap))
+	numRows := min(maxRows, (len(entries)+numCols-1)/numCols)
+	cols := make([]string, 0, numCols)
+	for c := 0; c < numCols && c*numRows < len(entries); c++ {
+		end := min(len(entries), (c+1)*numRows)
+		col := lipgloss.NewStyle().
+			Width(entryWidth + gap).
+			MaxWidth(innerWidth).
+			Render(strings.Join(entries[c*numRows:end], "\n"))
+		cols = append(cols, col)
+	}
+
+	return style.Render(lipgloss.JoinVertical(
+		lipgloss.Left,
+		title,
+		lipgloss.JoinHorizontal(lipgloss.Top, cols...),
+	))
+}
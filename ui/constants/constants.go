@@ -36,4 +36,10 @@ const (
 	MergedIcon  = ""
 	OpenIcon    = ""
 	ClosedIcon  = ""
+
+	AutoMergeIcon  = "󰅐"
+	MergeQueueIcon = "󰉹"
+
+	SelectedIcon = "󰄲"
+	NoteIcon     = "󰎚"
 )
@@ -8,6 +8,25 @@ type TaskFinishedMsg struct {
 	SectionType string
 	Err         error
 	Msg         tea.Msg
+	// Stderr is the error output of the task's command.
+	Stderr string
+	// Retry replaces the task's retry, e.g. to only retry the items that failed.
+	Retry func() tea.Cmd
+	// FinishedText replaces the task's finished text, for tasks whose outcome
+	// isn't known when they start.
+	FinishedText string
+}
+
+// TaskProgressMsg reports the progress of a task that handles several items.
+// Msg is delivered to the section like a TaskFinishedMsg's Msg, and Next
+// continues the task.
+type TaskProgressMsg struct {
+	TaskId      string
+	SectionId   int
+	SectionType string
+	StartText   string
+	Msg         tea.Msg
+	Next        tea.Cmd
 }
 
 type ClearTaskMsg struct {
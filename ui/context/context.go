@@ -6,7 +6,10 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 
 	"github.com/dlvhdr/gh-dash/v4/config"
+	"github.com/dlvhdr/gh-dash/v4/ui/jobs"
+	"github.com/dlvhdr/gh-dash/v4/ui/notes"
 	"github.com/dlvhdr/gh-dash/v4/ui/theme"
+	"github.com/dlvhdr/gh-dash/v4/ui/triage"
 	"github.com/dlvhdr/gh-dash/v4/utils"
 )
 
@@ -26,6 +29,15 @@ type Task struct {
 	Error        error
 	StartTime    time.Time
 	FinishedTime *time.Time
+	// Command is the command the task runs, shown in the task history.
+	Command string
+	// Mutation is the GraphQL mutation the task sends instead of running a
+	// command, and the item it's sent for, like "closeIssue dlvhdr/gh-dash#1".
+	Mutation string
+	// Stderr is the error output of the task's command, if it failed.
+	Stderr string
+	// Retry starts the task again. It's nil for tasks that can't be retried.
+	Retry func() tea.Cmd
 }
 
 type ProgramContext struct {
@@ -36,15 +48,27 @@ type ProgramContext struct {
 	ScreenWidth       int
 	MainContentWidth  int
 	MainContentHeight int
-	Config            *config.Config
-	ConfigPath        string
-	View              config.ViewType
-	Error             error
-	StartTask         func(task Task) tea.Cmd
-	Theme             theme.Theme
-	Styles            Styles
+	// PreviewPosition is where the preview pane is shown, right of or below
+	// the sections, and PreviewWidth and PreviewHeight are its size.
+	PreviewPosition config.PreviewPosition
+	PreviewWidth    int
+	PreviewHeight   int
+	Config          *config.Config
+	ConfigPath      string
+	View            config.ViewType
+	Error           error
+	StartTask       func(task Task) tea.Cmd
+	Jobs            *jobs.Manager
+	Triage          *triage.Store
+	Notes           *notes.Store
+	Theme           theme.Theme
+	Styles          Styles
 }
 
+// PinnedSectionConfig is the config of the section of pinned PRs or issues,
+// which comes right after the search section when any are pinned.
+var PinnedSectionConfig = config.SectionConfig{Title: " Pinned"}
+
 func (ctx *ProgramContext) GetViewSectionsConfig() []config.SectionConfig {
 	var configs []config.SectionConfig
 	switch ctx.View {
@@ -57,10 +81,16 @@ func (ctx *ProgramContext) GetViewSectionsConfig() []config.SectionConfig {
 			Type:    &t,
 		}.ToSectionConfig())
 	case config.PRsView:
+		if ctx.Triage.HasPinned(true) {
+			configs = append(configs, PinnedSectionConfig)
+		}
 		for _, cfg := range ctx.Config.PRSections {
 			configs = append(configs, cfg.ToSectionConfig())
 		}
 	case config.IssuesView:
+		if ctx.Triage.HasPinned(false) {
+			configs = append(configs, PinnedSectionConfig)
+		}
 		for _, cfg := range ctx.Config.IssuesSections {
 			configs = append(configs, cfg.ToSectionConfig())
 		}
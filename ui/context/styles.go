@@ -22,6 +22,8 @@ type Styles struct {
 
 	PrSidebar struct {
 		PillStyle lipgloss.Style
+		Tab       lipgloss.Style
+		ActiveTab lipgloss.Style
 	}
 	Help struct {
 		Text         lipgloss.Style
@@ -41,6 +43,8 @@ type Styles struct {
 		SpinnerStyle     lipgloss.Style
 		EmptyStateStyle  lipgloss.Style
 		KeyStyle         lipgloss.Style
+
+		SelectedRowMarkerStyle lipgloss.Style
 	}
 	PrSection struct {
 		CiCellWidth        int
@@ -107,6 +111,14 @@ func InitStyles(theme theme.Theme) Styles {
 		Foreground(theme.InvertedText).
 		PaddingLeft(1).
 		PaddingRight(1)
+	s.PrSidebar.Tab = lipgloss.NewStyle().
+		Faint(true).
+		Padding(0, 1)
+	s.PrSidebar.ActiveTab = s.PrSidebar.Tab.
+		Faint(false).
+		Bold(true).
+		Background(theme.SelectedBackground).
+		Foreground(theme.PrimaryText)
 
 	s.Help.Text = lipgloss.NewStyle().Foreground(theme.SecondaryText)
 	s.Help.KeyText = lipgloss.NewStyle().Foreground(theme.PrimaryText)
@@ -142,6 +154,9 @@ func InitStyles(theme theme.Theme) Styles {
 		Foreground(theme.PrimaryText).
 		Background(theme.SelectedBackground).
 		Padding(0, 1)
+	s.Section.SelectedRowMarkerStyle = lipgloss.NewStyle().
+		Bold(true).
+		Foreground(theme.WarningText)
 
 	s.PrSection.CiCellWidth = lipgloss.Width(" CI ")
 	s.PrSection.LinesCellWidth = lipgloss.Width(" 123450 / -123450 ")
@@ -0,0 +1,67 @@
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// footerPromptKind is what the prompt in the footer asks for.
+type footerPromptKind int
+
+const (
+	noFooterPrompt footerPromptKind = iota
+	goToPrompt
+	snoozePrompt
+	notePrompt
+	tagsPrompt
+)
+
+var footerPromptTexts = map[footerPromptKind]string{
+	goToPrompt:   "Go to (URL, owner/repo#123 or #123): ",
+	snoozePrompt: "Snooze until (4h, 3d, 2w, 2024-12-31, or empty until it's updated): ",
+	notePrompt:   "Private note (empty to delete): ",
+	tagsPrompt:   "Local tags (space separated): ",
+}
+
+func (m *Model) isFooterPromptOpen() bool {
+	return m.footerPromptKind != noFooterPrompt
+}
+
+// openFooterPrompt shows a prompt of kind in the footer.
+func (m *Model) openFooterPrompt(kind footerPromptKind) tea.Cmd {
+	m.footerPromptKind = kind
+	m.footerPrompt.Reset()
+	m.footerPrompt.SetPrompt(footerPromptTexts[kind])
+	return m.footerPrompt.Focus()
+}
+
+// updateFooterPrompt handles a key pressed while the footer prompt is open.
+// Enter submits the answer and esc cancels it.
+func (m *Model) updateFooterPrompt(msg tea.KeyMsg) tea.Cmd {
+	switch msg.Type {
+	case tea.KeyEsc, tea.KeyCtrlC:
+		m.footerPromptKind = noFooterPrompt
+		m.promptItem = nil
+		m.footerPrompt.Blur()
+		return nil
+
+	case tea.KeyEnter:
+		kind := m.footerPromptKind
+		m.footerPromptKind = noFooterPrompt
+		m.footerPrompt.Blur()
+		switch kind {
+		case goToPrompt:
+			return m.goTo(m.footerPrompt.Value())
+		case snoozePrompt:
+			return m.snooze(m.footerPrompt.Value())
+		case notePrompt:
+			return m.setNote(m.footerPrompt.Value())
+		case tagsPrompt:
+			return m.setTags(m.footerPrompt.Value())
+		}
+		return nil
+	}
+
+	var cmd tea.Cmd
+	m.footerPrompt, cmd = m.footerPrompt.Update(msg)
+	return cmd
+}
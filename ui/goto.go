@@ -0,0 +1,124 @@
+package ui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/cli/go-gh/v2/pkg/repository"
+
+	"github.com/dlvhdr/gh-dash/v4/config"
+	"github.com/dlvhdr/gh-dash/v4/data"
+	"github.com/dlvhdr/gh-dash/v4/git"
+	"github.com/dlvhdr/gh-dash/v4/ui/components/issuessection"
+	"github.com/dlvhdr/gh-dash/v4/ui/components/prssection"
+)
+
+// itemFetchedMsg has the PR or issue to show, which no section had when it
+// was asked for. zoom shows it in the zoomed preview pane.
+type itemFetchedMsg struct {
+	pr    *data.PullRequestData
+	issue *data.IssueData
+	zoom  bool
+	err   error
+}
+
+// fetchItem fetches the PR or issue at url.
+func fetchItem(url string, zoom bool) tea.Cmd {
+	return func() tea.Msg {
+		if data.IsPullRequestUrl(url) {
+			pr, err := data.FetchPullRequest(url)
+			return itemFetchedMsg{pr: &pr, zoom: zoom, err: err}
+		}
+		issue, err := data.FetchIssue(url)
+		return itemFetchedMsg{issue: &issue, zoom: zoom, err: err}
+	}
+}
+
+// goTo shows the PR or issue ref points to in the zoomed preview pane, in
+// the view it belongs to. ref is a URL, an owner/repo#123 reference or a #123
+// reference to an item of the current repository.
+func (m *Model) goTo(ref string) tea.Cmd {
+	itemRef, err := data.ParseItemRef(ref, m.getCurrentRepoName())
+	if err != nil {
+		return m.notifyErr(err.Error())
+	}
+
+	notifyCmd := m.notify(fmt.Sprintf("Fetching %s", itemRef))
+	fetchCmd := func() tea.Msg {
+		url, err := data.FetchItemUrl(itemRef)
+		if err != nil {
+			return itemFetchedMsg{err: err}
+		}
+		return fetchItem(url, true)()
+	}
+	return tea.Batch(notifyCmd, fetchCmd)
+}
+
+// getCurrentRepoName returns the owner/repo name of the repository the
+// dashboard was started in, or of the current row if it wasn't started in
+// one.
+func (m *Model) getCurrentRepoName() string {
+	if m.ctx.RepoUrl != nil {
+		return git.GetRepoShortName(*m.ctx.RepoUrl)
+	}
+	if repo, err := repository.Current(); err == nil {
+		return repo.Owner + "/" + repo.Name
+	}
+	if row := m.getCurrRowData(); row != nil {
+		return row.GetRepoNameWithOwner()
+	}
+	return ""
+}
+
+// onItemFetched switches to the view of the fetched item and selects it in
+// the section that has it, or shows it in the search section if none of them
+// do, since the sections may have been fetched while the item was.
+func (m *Model) onItemFetched(msg itemFetchedMsg) tea.Cmd {
+	if msg.err != nil {
+		return m.notifyErr(fmt.Sprintf("Failed fetching the item: %v", msg.err))
+	}
+
+	view, url := config.IssuesView, ""
+	if msg.pr != nil {
+		view, url = config.PRsView, msg.pr.GetUrl()
+	} else {
+		url = msg.issue.GetUrl()
+	}
+
+	var switchCmd tea.Cmd
+	if m.ctx.View != view {
+		switchCmd = m.switchView(view)
+	}
+
+	if !m.selectRowByUrl(url) {
+		switch search := m.getSectionAt(0).(type) {
+		case *prssection.Model:
+			search.ShowPr(*msg.pr)
+		case *issuessection.Model:
+			search.ShowIssue(*msg.issue)
+		}
+		m.setCurrSectionId(0)
+	}
+
+	if msg.zoom {
+		m.sidebar.IsOpen = true
+		m.isPreviewZoomed = true
+		m.syncMainContentSize()
+	}
+	return tea.Batch(switchCmd, m.onViewedRowChanged())
+}
+
+// selectRowByUrl selects the row with url in the first section of the
+// current view that has it. It reports whether any section had it.
+func (m *Model) selectRowByUrl(url string) bool {
+	for _, s := range m.getCurrentViewSections() {
+		for i, row := range s.GetRows() {
+			if row.GetUrl() == url {
+				m.setCurrSectionId(s.GetId())
+				s.SetCurrRow(i)
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,85 @@
+// Package jobs tracks the long-running jobs started from the dashboard, like
+// watching a PR's checks, so they can be cancelled, either one at a time or
+// all at once when the dashboard quits.
+package jobs
+
+import (
+	"context"
+	"sync"
+)
+
+type Job struct {
+	Id     string
+	cancel context.CancelFunc
+}
+
+// Manager keeps the running jobs. It's safe to use from the commands the
+// jobs run in.
+type Manager struct {
+	mu   sync.Mutex
+	jobs map[string]*Job
+}
+
+func NewManager() *Manager {
+	return &Manager{jobs: map[string]*Job{}}
+}
+
+// Start registers a job and returns the context it should run with, which is
+// done once the job is cancelled. It returns false if a job with the same id
+// is already running.
+func (m *Manager) Start(id string) (context.Context, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.jobs[id]; ok {
+		return nil, false
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	m.jobs[id] = &Job{
+		Id:     id,
+		cancel: cancel,
+	}
+	return ctx, true
+}
+
+// Finish removes a job once it's done, whether it was cancelled or not.
+func (m *Manager) Finish(id string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if job, ok := m.jobs[id]; ok {
+		job.cancel()
+		delete(m.jobs, id)
+	}
+}
+
+// Cancel asks a running job to stop. The job is removed once it calls
+// Finish. It returns false if there's no such job.
+func (m *Manager) Cancel(id string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	job, ok := m.jobs[id]
+	if ok {
+		job.cancel()
+	}
+	return ok
+}
+
+// CancelAll asks every running job to stop, like Cancel.
+func (m *Manager) CancelAll() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, job := range m.jobs {
+		job.cancel()
+	}
+}
+
+func (m *Manager) IsRunning(id string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	_, ok := m.jobs[id]
+	return ok
+}
@@ -0,0 +1,40 @@
+package jobs_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dlvhdr/gh-dash/v4/ui/jobs"
+)
+
+func TestManager(t *testing.T) {
+	m := jobs.NewManager()
+
+	ctx, ok := m.Start("watch_1")
+	require.True(t, ok)
+	require.True(t, m.IsRunning("watch_1"))
+
+	_, ok = m.Start("watch_1")
+	require.False(t, ok, "a job can't run twice")
+
+	require.True(t, m.Cancel("watch_1"))
+	<-ctx.Done()
+	require.True(t, m.IsRunning("watch_1"), "cancelled jobs run until they finish")
+
+	m.Finish("watch_1")
+	require.False(t, m.IsRunning("watch_1"))
+	require.False(t, m.Cancel("watch_1"))
+}
+
+func TestManagerCancelAll(t *testing.T) {
+	m := jobs.NewManager()
+
+	ctx1, _ := m.Start("watch_1")
+	ctx2, _ := m.Start("watch_2")
+	m.CancelAll()
+	<-ctx1.Done()
+	<-ctx2.Done()
+	require.True(t, m.IsRunning("watch_1"), "cancelled jobs run until they finish")
+	require.True(t, m.IsRunning("watch_2"), "cancelled jobs run until they finish")
+}
@@ -10,15 +10,24 @@ import (
 )
 
 type IssueKeyMap struct {
-	Assign   key.Binding
-	Unassign key.Binding
-	Comment  key.Binding
-	Close    key.Binding
-	Reopen   key.Binding
-	ViewPRs  key.Binding
+	New          key.Binding
+	Assign       key.Binding
+	Unassign     key.Binding
+	Comment      key.Binding
+	Edit         key.Binding
+	TaskList     key.Binding
+	Labels       key.Binding
+	Close        key.Binding
+	Reopen       key.Binding
+	ViewPRs      key.Binding
+	JumpToLinked key.Binding
 }
 
 var IssueKeys = IssueKeyMap{
+	New: key.NewBinding(
+		key.WithKeys("n"),
+		key.WithHelp("n", "new issue"),
+	),
 	Assign: key.NewBinding(
 		key.WithKeys("a"),
 		key.WithHelp("a", "assign"),
@@ -31,6 +40,18 @@ var IssueKeys = IssueKeyMap{
 		key.WithKeys("c"),
 		key.WithHelp("c", "comment"),
 	),
+	Edit: key.NewBinding(
+		key.WithKeys("e"),
+		key.WithHelp("e", "edit title and body"),
+	),
+	TaskList: key.NewBinding(
+		key.WithKeys("t"),
+		key.WithHelp("t", "toggle tasks"),
+	),
+	Labels: key.NewBinding(
+		key.WithKeys("L"),
+		key.WithHelp("L", "edit labels"),
+	),
 	Close: key.NewBinding(
 		key.WithKeys("x"),
 		key.WithHelp("x", "close"),
@@ -43,16 +64,25 @@ var IssueKeys = IssueKeyMap{
 		key.WithKeys("s"),
 		key.WithHelp("s", "switch to PRs"),
 	),
+	JumpToLinked: key.NewBinding(
+		key.WithKeys("J"),
+		key.WithHelp("J", "jump to linked PR"),
+	),
 }
 
 func IssueFullHelp() []key.Binding {
 	return []key.Binding{
+		IssueKeys.New,
 		IssueKeys.Assign,
 		IssueKeys.Unassign,
 		IssueKeys.Comment,
+		IssueKeys.Edit,
+		IssueKeys.TaskList,
+		IssueKeys.Labels,
 		IssueKeys.Close,
 		IssueKeys.Reopen,
 		IssueKeys.ViewPRs,
+		IssueKeys.JumpToLinked,
 	}
 }
 
@@ -67,18 +97,28 @@ func rebindIssueKeys(keys []config.Keybinding) error {
 		var key *key.Binding
 
 		switch issueKey.Builtin {
+		case "new":
+			key = &IssueKeys.New
 		case "assign":
 			key = &IssueKeys.Assign
 		case "unassign":
 			key = &IssueKeys.Unassign
 		case "comment":
 			key = &IssueKeys.Comment
+		case "edit":
+			key = &IssueKeys.Edit
+		case "taskList":
+			key = &IssueKeys.TaskList
+		case "labels":
+			key = &IssueKeys.Labels
 		case "close":
 			key = &IssueKeys.Close
 		case "reopen":
 			key = &IssueKeys.Reopen
 		case "viewPrs":
 			key = &IssueKeys.ViewPRs
+		case "jumpToLinked":
+			key = &IssueKeys.JumpToLinked
 		default:
 			return fmt.Errorf("unknown built-in issue key: '%s'", issueKey.Builtin)
 		}
@@ -0,0 +1,23 @@
+package keys
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// KeyMsg returns the key press that k names, the way bindings name keys, like
+// "j", "ctrl+g", "enter" or "alt+x".
+func KeyMsg(k string) tea.KeyMsg {
+	alt := false
+	if rest, ok := strings.CutPrefix(k, "alt+"); ok && rest != "" {
+		alt, k = true, rest
+	}
+
+	for t := tea.KeyType(-100); t <= 127; t++ {
+		if t != tea.KeyRunes && t.String() == k {
+			return tea.KeyMsg{Type: t, Alt: alt}
+		}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k), Alt: alt}
+}
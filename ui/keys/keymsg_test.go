@@ -0,0 +1,17 @@
+package keys_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dlvhdr/gh-dash/v4/ui/keys"
+)
+
+func TestKeyMsg(t *testing.T) {
+	for _, k := range []string{"j", "G", "#", "ctrl+g", "enter", "tab", " ", "up", "alt+x", "alt+enter", "f5"} {
+		t.Run(k, func(t *testing.T) {
+			require.Equal(t, k, keys.KeyMsg(k).String())
+		})
+	}
+}
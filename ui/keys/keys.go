@@ -11,24 +11,45 @@ import (
 )
 
 type KeyMap struct {
-	viewType      config.ViewType
-	Up            key.Binding
-	Down          key.Binding
-	FirstLine     key.Binding
-	LastLine      key.Binding
-	TogglePreview key.Binding
-	OpenGithub    key.Binding
-	Refresh       key.Binding
-	RefreshAll    key.Binding
-	PageDown      key.Binding
-	PageUp        key.Binding
-	NextSection   key.Binding
-	PrevSection   key.Binding
-	Search        key.Binding
-	CopyUrl       key.Binding
-	CopyNumber    key.Binding
-	Help          key.Binding
-	Quit          key.Binding
+	viewType        config.ViewType
+	Up              key.Binding
+	Down            key.Binding
+	FirstLine       key.Binding
+	LastLine        key.Binding
+	SwitchToPrs     key.Binding
+	SwitchToIssues  key.Binding
+	SwitchToRepo    key.Binding
+	TogglePreview   key.Binding
+	GrowPreview     key.Binding
+	ShrinkPreview   key.Binding
+	ZoomPreview     key.Binding
+	ActivityFilter  key.Binding
+	ToggleBots      key.Binding
+	OpenGithub      key.Binding
+	Refresh         key.Binding
+	RefreshAll      key.Binding
+	PageDown        key.Binding
+	PageUp          key.Binding
+	NextSection     key.Binding
+	PrevSection     key.Binding
+	Search          key.Binding
+	SaveSection     key.Binding
+	Filter          key.Binding
+	CopyUrl         key.Binding
+	CopyNumber      key.Binding
+	ToggleSelection key.Binding
+	SelectAll       key.Binding
+	VisualSelect    key.Binding
+	Pin             key.Binding
+	Snooze          key.Binding
+	Mute            key.Binding
+	Note            key.Binding
+	Tags            key.Binding
+	GoTo            key.Binding
+	CommandPalette  key.Binding
+	TaskHistory     key.Binding
+	Help            key.Binding
+	Quit            key.Binding
 }
 
 func CreateKeyMapForView(viewType config.ViewType) help.KeyMap {
@@ -66,6 +87,9 @@ func (k KeyMap) NavigationKeys() []key.Binding {
 		k.NextSection,
 		k.FirstLine,
 		k.LastLine,
+		k.SwitchToPrs,
+		k.SwitchToIssues,
+		k.SwitchToRepo,
 		k.PageDown,
 		k.PageUp,
 	}
@@ -76,10 +100,28 @@ func (k KeyMap) AppKeys() []key.Binding {
 		k.Refresh,
 		k.RefreshAll,
 		k.TogglePreview,
+		k.GrowPreview,
+		k.ShrinkPreview,
+		k.ZoomPreview,
+		k.ActivityFilter,
+		k.ToggleBots,
 		k.OpenGithub,
 		k.CopyNumber,
 		k.CopyUrl,
 		k.Search,
+		k.SaveSection,
+		k.Filter,
+		k.ToggleSelection,
+		k.SelectAll,
+		k.VisualSelect,
+		k.Pin,
+		k.Snooze,
+		k.Mute,
+		k.Note,
+		k.Tags,
+		k.GoTo,
+		k.CommandPalette,
+		k.TaskHistory,
 	}
 }
 
@@ -104,10 +146,42 @@ var Keys = &KeyMap{
 		key.WithKeys("G", "end"),
 		key.WithHelp("G/end", "last item"),
 	),
+	SwitchToPrs: key.NewBinding(
+		key.WithKeys("g p"),
+		key.WithHelp("g p", "go to PRs"),
+	),
+	SwitchToIssues: key.NewBinding(
+		key.WithKeys("g i"),
+		key.WithHelp("g i", "go to issues"),
+	),
+	SwitchToRepo: key.NewBinding(
+		key.WithKeys("g r"),
+		key.WithHelp("g r", "go to repo"),
+	),
 	TogglePreview: key.NewBinding(
 		key.WithKeys("p"),
 		key.WithHelp("p", "open in Preview"),
 	),
+	GrowPreview: key.NewBinding(
+		key.WithKeys("+"),
+		key.WithHelp("+", "grow preview"),
+	),
+	ShrinkPreview: key.NewBinding(
+		key.WithKeys("-"),
+		key.WithHelp("-", "shrink preview"),
+	),
+	ZoomPreview: key.NewBinding(
+		key.WithKeys("z"),
+		key.WithHelp("z", "zoom preview"),
+	),
+	ActivityFilter: key.NewBinding(
+		key.WithKeys("E"),
+		key.WithHelp("E", "filter activity"),
+	),
+	ToggleBots: key.NewBinding(
+		key.WithKeys("B"),
+		key.WithHelp("B", "show/hide bot activity"),
+	),
 	OpenGithub: key.NewBinding(
 		key.WithKeys("o"),
 		key.WithHelp("o", "open in GitHub"),
@@ -140,6 +214,14 @@ var Keys = &KeyMap{
 		key.WithKeys("/"),
 		key.WithHelp("/", "search"),
 	),
+	SaveSection: key.NewBinding(
+		key.WithKeys("S"),
+		key.WithHelp("S", "save search as section"),
+	),
+	Filter: key.NewBinding(
+		key.WithKeys("ctrl+f"),
+		key.WithHelp("ctrl+f", "filter loaded rows"),
+	),
 	CopyNumber: key.NewBinding(
 		key.WithKeys("y"),
 		key.WithHelp("y", "copy number"),
@@ -148,6 +230,50 @@ var Keys = &KeyMap{
 		key.WithKeys("Y"),
 		key.WithHelp("Y", "copy url"),
 	),
+	ToggleSelection: key.NewBinding(
+		key.WithKeys("tab"),
+		key.WithHelp("tab", "select"),
+	),
+	SelectAll: key.NewBinding(
+		key.WithKeys("ctrl+a"),
+		key.WithHelp("Ctrl+a", "select all"),
+	),
+	VisualSelect: key.NewBinding(
+		key.WithKeys("V"),
+		key.WithHelp("V", "visual select"),
+	),
+	Pin: key.NewBinding(
+		key.WithKeys("b"),
+		key.WithHelp("b", "pin/unpin"),
+	),
+	Snooze: key.NewBinding(
+		key.WithKeys("Z"),
+		key.WithHelp("Z", "snooze/unsnooze"),
+	),
+	Mute: key.NewBinding(
+		key.WithKeys("M"),
+		key.WithHelp("M", "mute/unmute"),
+	),
+	Note: key.NewBinding(
+		key.WithKeys("N"),
+		key.WithHelp("N", "edit private note"),
+	),
+	Tags: key.NewBinding(
+		key.WithKeys("#"),
+		key.WithHelp("#", "edit local tags"),
+	),
+	GoTo: key.NewBinding(
+		key.WithKeys("ctrl+g"),
+		key.WithHelp("Ctrl+g", "go to PR/issue"),
+	),
+	CommandPalette: key.NewBinding(
+		key.WithKeys("ctrl+p"),
+		key.WithHelp("Ctrl+p", "command palette"),
+	),
+	TaskHistory: key.NewBinding(
+		key.WithKeys("T"),
+		key.WithHelp("T", "task history"),
+	),
 	Help: key.NewBinding(
 		key.WithKeys("?"),
 		key.WithHelp("?", "help"),
@@ -198,8 +324,24 @@ func rebindUniversal(universal []config.Keybinding) error {
 			key = &Keys.FirstLine
 		case "lastLine":
 			key = &Keys.LastLine
+		case "switchToPrs":
+			key = &Keys.SwitchToPrs
+		case "switchToIssues":
+			key = &Keys.SwitchToIssues
+		case "switchToRepo":
+			key = &Keys.SwitchToRepo
 		case "togglePreview":
 			key = &Keys.TogglePreview
+		case "growPreview":
+			key = &Keys.GrowPreview
+		case "shrinkPreview":
+			key = &Keys.ShrinkPreview
+		case "zoomPreview":
+			key = &Keys.ZoomPreview
+		case "activityFilter":
+			key = &Keys.ActivityFilter
+		case "toggleBots":
+			key = &Keys.ToggleBots
 		case "openGithub":
 			key = &Keys.OpenGithub
 		case "refresh":
@@ -216,10 +358,36 @@ func rebindUniversal(universal []config.Keybinding) error {
 			key = &Keys.PrevSection
 		case "search":
 			key = &Keys.Search
+		case "saveSection":
+			key = &Keys.SaveSection
+		case "filter":
+			key = &Keys.Filter
 		case "copyurl":
 			key = &Keys.CopyUrl
 		case "copyNumber":
 			key = &Keys.CopyNumber
+		case "toggleSelection":
+			key = &Keys.ToggleSelection
+		case "selectAll":
+			key = &Keys.SelectAll
+		case "visualSelect":
+			key = &Keys.VisualSelect
+		case "pin":
+			key = &Keys.Pin
+		case "snooze":
+			key = &Keys.Snooze
+		case "mute":
+			key = &Keys.Mute
+		case "note":
+			key = &Keys.Note
+		case "tags":
+			key = &Keys.Tags
+		case "goTo":
+			key = &Keys.GoTo
+		case "commandPalette":
+			key = &Keys.CommandPalette
+		case "taskHistory":
+			key = &Keys.TaskHistory
 		case "help":
 			key = &Keys.Help
 		case "quit":
@@ -10,19 +10,27 @@ import (
 )
 
 type PRKeyMap struct {
-	Approve     key.Binding
-	Assign      key.Binding
-	Unassign    key.Binding
-	Comment     key.Binding
-	Diff        key.Binding
-	Checkout    key.Binding
-	Close       key.Binding
-	Ready       key.Binding
-	Reopen      key.Binding
-	Merge       key.Binding
-	Update      key.Binding
-	WatchChecks key.Binding
-	ViewIssues  key.Binding
+	Approve       key.Binding
+	RequestReview key.Binding
+	Assign        key.Binding
+	Unassign      key.Binding
+	Comment       key.Binding
+	Edit          key.Binding
+	TaskList      key.Binding
+	Labels        key.Binding
+	Diff          key.Binding
+	ViewedFiles   key.Binding
+	Checkout      key.Binding
+	Close         key.Binding
+	Ready         key.Binding
+	Reopen        key.Binding
+	Merge         key.Binding
+	Update        key.Binding
+	WatchChecks   key.Binding
+	ViewIssues    key.Binding
+	JumpToLinked  key.Binding
+	NextTab       key.Binding
+	PrevTab       key.Binding
 }
 
 var PRKeys = PRKeyMap{
@@ -30,6 +38,10 @@ var PRKeys = PRKeyMap{
 		key.WithKeys("v"),
 		key.WithHelp("v", "approve"),
 	),
+	RequestReview: key.NewBinding(
+		key.WithKeys("ctrl+r"),
+		key.WithHelp("ctrl+r", "request review"),
+	),
 	Assign: key.NewBinding(
 		key.WithKeys("a"),
 		key.WithHelp("a", "assign"),
@@ -42,10 +54,26 @@ var PRKeys = PRKeyMap{
 		key.WithKeys("c"),
 		key.WithHelp("c", "comment"),
 	),
+	Edit: key.NewBinding(
+		key.WithKeys("e"),
+		key.WithHelp("e", "edit title and body"),
+	),
+	TaskList: key.NewBinding(
+		key.WithKeys("t"),
+		key.WithHelp("t", "toggle tasks"),
+	),
+	Labels: key.NewBinding(
+		key.WithKeys("L"),
+		key.WithHelp("L", "edit labels"),
+	),
 	Diff: key.NewBinding(
 		key.WithKeys("d"),
 		key.WithHelp("d", "diff"),
 	),
+	ViewedFiles: key.NewBinding(
+		key.WithKeys("f"),
+		key.WithHelp("f", "mark files viewed"),
+	),
 	Checkout: key.NewBinding(
 		key.WithKeys("C", " "),
 		key.WithHelp("C/Space", "checkout"),
@@ -60,7 +88,7 @@ var PRKeys = PRKeyMap{
 	),
 	Ready: key.NewBinding(
 		key.WithKeys("W"),
-		key.WithHelp("W", "ready for review"),
+		key.WithHelp("W", "ready for review/draft"),
 	),
 	Merge: key.NewBinding(
 		key.WithKeys("m"),
@@ -72,21 +100,38 @@ var PRKeys = PRKeyMap{
 	),
 	WatchChecks: key.NewBinding(
 		key.WithKeys("w"),
-		key.WithHelp("w", "watch checks"),
+		key.WithHelp("w", "watch checks/stop watching"),
 	),
 	ViewIssues: key.NewBinding(
 		key.WithKeys("s"),
 		key.WithHelp("s", "switch to issues"),
 	),
+	JumpToLinked: key.NewBinding(
+		key.WithKeys("J"),
+		key.WithHelp("J", "jump to linked issue"),
+	),
+	NextTab: key.NewBinding(
+		key.WithKeys("]"),
+		key.WithHelp("]", "next preview tab"),
+	),
+	PrevTab: key.NewBinding(
+		key.WithKeys("["),
+		key.WithHelp("[", "previous preview tab"),
+	),
 }
 
 func PRFullHelp() []key.Binding {
 	return []key.Binding{
 		PRKeys.Approve,
+		PRKeys.RequestReview,
 		PRKeys.Assign,
 		PRKeys.Unassign,
 		PRKeys.Comment,
+		PRKeys.Edit,
+		PRKeys.TaskList,
+		PRKeys.Labels,
 		PRKeys.Diff,
+		PRKeys.ViewedFiles,
 		PRKeys.Checkout,
 		PRKeys.Close,
 		PRKeys.Ready,
@@ -95,6 +140,9 @@ func PRFullHelp() []key.Binding {
 		PRKeys.Update,
 		PRKeys.WatchChecks,
 		PRKeys.ViewIssues,
+		PRKeys.JumpToLinked,
+		PRKeys.NextTab,
+		PRKeys.PrevTab,
 	}
 }
 
@@ -111,14 +159,24 @@ func rebindPRKeys(keys []config.Keybinding) error {
 		switch prKey.Builtin {
 		case "approve":
 			key = &PRKeys.Approve
+		case "requestReview":
+			key = &PRKeys.RequestReview
 		case "assign":
 			key = &PRKeys.Assign
 		case "unassign":
 			key = &PRKeys.Unassign
 		case "comment":
 			key = &PRKeys.Comment
+		case "edit":
+			key = &PRKeys.Edit
+		case "taskList":
+			key = &PRKeys.TaskList
+		case "labels":
+			key = &PRKeys.Labels
 		case "diff":
 			key = &PRKeys.Diff
+		case "viewedFiles":
+			key = &PRKeys.ViewedFiles
 		case "checkout":
 			key = &PRKeys.Checkout
 		case "close":
@@ -135,6 +193,12 @@ func rebindPRKeys(keys []config.Keybinding) error {
 			key = &PRKeys.WatchChecks
 		case "viewIssues":
 			key = &PRKeys.ViewIssues
+		case "jumpToLinked":
+			key = &PRKeys.JumpToLinked
+		case "nextTab":
+			key = &PRKeys.NextTab
+		case "prevTab":
+			key = &PRKeys.PrevTab
 		default:
 			return fmt.Errorf("unknown built-in pr key: '%s'", prKey.Builtin)
 		}
@@ -0,0 +1,246 @@
+package keys
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// maxCount is the largest count that can be typed before a key.
+const maxCount = 999
+
+// KeyPress is a key press to handle, with the count typed before it, or 0 if
+// there's none.
+type KeyPress struct {
+	Msg   tea.KeyMsg
+	Count int
+}
+
+// Hint is a key that continues a pending sequence, shown while it's pending.
+type Hint struct {
+	Key  string
+	Desc string
+}
+
+// Sequence tracks the keys of a multi-key binding, like "g p", as they're
+// pressed, and the count typed before them, like the 5 of "5j".
+type Sequence struct {
+	keys  []tea.KeyMsg
+	count int
+	id    int
+}
+
+// IsPending reports whether keys or a count were pressed that don't complete
+// a binding yet.
+func (s *Sequence) IsPending() bool {
+	return len(s.keys) > 0 || s.count > 0
+}
+
+// Id changes every time the pending keys change, so a timeout started for
+// some pending keys can tell whether they're still pending.
+func (s *Sequence) Id() int {
+	return s.id
+}
+
+// Count is the count typed before the pending keys, or 0 if there's none.
+func (s *Sequence) Count() int {
+	return s.count
+}
+
+// Pending returns the names of the pending keys.
+func (s *Sequence) Pending() []string {
+	names := make([]string, 0, len(s.keys))
+	for _, msg := range s.keys {
+		names = append(names, keyName(msg.String()))
+	}
+	return names
+}
+
+// String returns the count and keys pressed so far, like "5 g".
+func (s *Sequence) String() string {
+	pending := s.Pending()
+	if s.count > 0 {
+		pending = append([]string{fmt.Sprint(s.count)}, pending...)
+	}
+	return strings.Join(pending, " ")
+}
+
+func (s *Sequence) Reset() {
+	s.keys = nil
+	s.count = 0
+	s.id++
+}
+
+// Press records msg and returns the key presses to handle now. A key that
+// isn't part of a longer sequence is returned right away. The keys of a
+// sequence are returned as a single key press once they complete one of the
+// bindings, like "g p". Keys that can't complete any binding are dropped.
+func (s *Sequence) Press(msg tea.KeyMsg, bindings []key.Binding) []KeyPress {
+	name := keyName(msg.String())
+	if len(s.keys) == 0 {
+		if digit, ok := countDigit(name, s.count); ok && !hasPrefix(bindings, []string{name}, 1) {
+			s.count = min(s.count*10+digit, maxCount)
+			s.id++
+			return nil
+		}
+	}
+
+	if s.IsPending() && (msg.Type == tea.KeyEsc || msg.Type == tea.KeyCtrlC) {
+		s.Reset()
+		return nil
+	}
+
+	names := append(s.Pending(), name)
+	if hasPrefix(bindings, names, len(names)+1) {
+		s.keys = append(s.keys, msg)
+		s.id++
+		return nil
+	}
+
+	if len(s.keys) > 0 && !isBound(bindings, names) {
+		if !isBound(bindings, s.Pending()) {
+			s.Reset()
+			return nil
+		}
+		return append(s.resolve(), s.Press(msg, bindings)...)
+	}
+
+	s.keys = append(s.keys, msg)
+	return s.resolve()
+}
+
+// WaitsForTimeout reports whether the pending keys complete a binding that is
+// also the start of longer ones, like "g" when "g p" is bound too. That
+// binding runs when no other key is pressed before the timeout.
+func (s *Sequence) WaitsForTimeout(bindings []key.Binding) bool {
+	return len(s.keys) > 0 && isBound(bindings, s.Pending())
+}
+
+// Timeout returns the pending binding to run if the keys pressed when the
+// timeout with the given id started are still pending.
+func (s *Sequence) Timeout(id int, bindings []key.Binding) []KeyPress {
+	if id != s.id || !s.WaitsForTimeout(bindings) {
+		return nil
+	}
+	return s.resolve()
+}
+
+// Hints returns the keys that continue the pending keys and what they do. A
+// key that only starts longer sequences is described by how many actions it
+// leads to.
+func (s *Sequence) Hints(bindings []key.Binding) []Hint {
+	pending := s.Pending()
+	var hints []Hint
+	groups := map[string]int{}
+	index := map[string]int{}
+	for _, binding := range bindings {
+		if !binding.Enabled() {
+			continue
+		}
+		for _, k := range binding.Keys() {
+			seq := SplitSequence(k)
+			if len(seq) <= len(pending) || !startsWith(seq, pending) {
+				continue
+			}
+			next := seq[len(pending)]
+			i, ok := index[next]
+			if !ok {
+				i = len(hints)
+				index[next] = i
+				hints = append(hints, Hint{Key: next})
+			}
+			if len(seq) == len(pending)+1 {
+				hints[i].Desc = binding.Help().Desc
+			} else {
+				groups[next]++
+			}
+			break
+		}
+	}
+
+	for i, hint := range hints {
+		if hint.Desc == "" {
+			hints[i].Desc = fmt.Sprintf("+%d actions", groups[hint.Key])
+		}
+	}
+	return hints
+}
+
+func (s *Sequence) resolve() []KeyPress {
+	defer s.Reset()
+	switch len(s.keys) {
+	case 0:
+		return nil
+	case 1:
+		return []KeyPress{{Msg: s.keys[0], Count: s.count}}
+	default:
+		return []KeyPress{{Msg: KeyMsg(strings.Join(s.Pending(), " ")), Count: s.count}}
+	}
+}
+
+// SplitSequence returns the names of the keys of a sequence like "g p". The
+// space key is named "space".
+func SplitSequence(k string) []string {
+	if k == " " {
+		return []string{"space"}
+	}
+	return strings.Fields(k)
+}
+
+func keyName(k string) string {
+	if k == " " {
+		return "space"
+	}
+	return k
+}
+
+func countDigit(name string, count int) (int, bool) {
+	if len(name) != 1 || name[0] < '0' || name[0] > '9' || (name == "0" && count == 0) {
+		return 0, false
+	}
+	return int(name[0] - '0'), true
+}
+
+// hasPrefix reports whether any binding has a sequence of at least minLen
+// keys that starts with names.
+func hasPrefix(bindings []key.Binding, names []string, minLen int) bool {
+	for _, binding := range bindings {
+		if !binding.Enabled() {
+			continue
+		}
+		for _, k := range binding.Keys() {
+			if seq := SplitSequence(k); len(seq) >= minLen && startsWith(seq, names) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func isBound(bindings []key.Binding, names []string) bool {
+	for _, binding := range bindings {
+		if !binding.Enabled() {
+			continue
+		}
+		for _, k := range binding.Keys() {
+			if seq := SplitSequence(k); len(seq) == len(names) && startsWith(seq, names) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func startsWith(seq, prefix []string) bool {
+	if len(seq) < len(prefix) {
+		return false
+	}
+	for i := range prefix {
+		if seq[i] != prefix[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,151 @@
+package keys_test
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dlvhdr/gh-dash/v4/ui/keys"
+)
+
+func TestSequencePress(t *testing.T) {
+	bindings := []key.Binding{
+		key.NewBinding(key.WithKeys("j", "down"), key.WithHelp("j", "down")),
+		key.NewBinding(key.WithKeys("g"), key.WithHelp("g", "first item")),
+		key.NewBinding(key.WithKeys("g p"), key.WithHelp("g p", "PRs view")),
+		key.NewBinding(key.WithKeys(`\ m s`), key.WithHelp(`\ m s`, "squash merge")),
+		key.NewBinding(key.WithKeys(" "), key.WithHelp("space", "checkout")),
+		key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "quit")),
+	}
+
+	testCases := map[string]struct {
+		keys      []string
+		want      []keys.KeyPress
+		pending   string
+		isTimeout bool
+	}{
+		"single key": {
+			keys: []string{"j"},
+			want: []keys.KeyPress{{Msg: keys.KeyMsg("j")}},
+		},
+		"unbound single key": {
+			keys: []string{"x"},
+			want: []keys.KeyPress{{Msg: keys.KeyMsg("x")}},
+		},
+		"space": {
+			keys: []string{" "},
+			want: []keys.KeyPress{{Msg: keys.KeyMsg(" ")}},
+		},
+		"count": {
+			keys: []string{"1", "2", "j"},
+			want: []keys.KeyPress{{Msg: keys.KeyMsg("j"), Count: 12}},
+		},
+		"zero continues a count": {
+			keys:    []string{"1", "0"},
+			pending: "10",
+		},
+		"sequence": {
+			keys: []string{`\`, "m", "s"},
+			want: []keys.KeyPress{{Msg: keys.KeyMsg(`\ m s`)}},
+		},
+		"pending sequence": {
+			keys:    []string{"3", `\`, "m"},
+			pending: `3 \ m`,
+		},
+		"count before a sequence": {
+			keys: []string{"3", "g", "p"},
+			want: []keys.KeyPress{{Msg: keys.KeyMsg("g p"), Count: 3}},
+		},
+		"binding that starts a sequence waits": {
+			keys:      []string{"g"},
+			pending:   "g",
+			isTimeout: true,
+		},
+		"binding that starts a sequence runs before another key": {
+			keys: []string{"g", "j"},
+			want: []keys.KeyPress{{Msg: keys.KeyMsg("g")}, {Msg: keys.KeyMsg("j")}},
+		},
+		"keys that complete no binding are dropped": {
+			keys: []string{`\`, "x", "j"},
+			want: []keys.KeyPress{{Msg: keys.KeyMsg("j")}},
+		},
+		"esc cancels": {
+			keys: []string{"5", `\`, "esc", "j"},
+			want: []keys.KeyPress{{Msg: keys.KeyMsg("j")}},
+		},
+		"esc without pending keys": {
+			keys: []string{"esc"},
+			want: []keys.KeyPress{{Msg: keys.KeyMsg("esc")}},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var s keys.Sequence
+			var got []keys.KeyPress
+			for _, k := range tc.keys {
+				got = append(got, s.Press(keys.KeyMsg(k), bindings)...)
+			}
+			require.Equal(t, tc.want, got)
+			require.Equal(t, tc.pending, s.String())
+			require.Equal(t, tc.pending != "", s.IsPending())
+			require.Equal(t, tc.isTimeout, s.WaitsForTimeout(bindings))
+		})
+	}
+}
+
+func TestSequenceTimeout(t *testing.T) {
+	bindings := []key.Binding{
+		key.NewBinding(key.WithKeys("g"), key.WithHelp("g", "first item")),
+		key.NewBinding(key.WithKeys("g p"), key.WithHelp("g p", "PRs view")),
+		key.NewBinding(key.WithKeys("g i"), key.WithHelp("g i", "Issues view")),
+		key.NewBinding(key.WithKeys("g r a"), key.WithHelp("g r a", "approve")),
+		key.NewBinding(key.WithKeys("g r c"), key.WithHelp("g r c", "comment")),
+	}
+
+	var s keys.Sequence
+	require.Empty(t, s.Press(keys.KeyMsg("g"), bindings))
+	require.Equal(t, []keys.Hint{
+		{Key: "p", Desc: "PRs view"},
+		{Key: "i", Desc: "Issues view"},
+		{Key: "r", Desc: "+2 actions"},
+	}, s.Hints(bindings))
+
+	id := s.Id()
+	require.Empty(t, s.Timeout(id-1, bindings), "stale timeouts are ignored")
+	require.Equal(t, []keys.KeyPress{{Msg: keys.KeyMsg("g")}}, s.Timeout(id, bindings))
+	require.False(t, s.IsPending())
+}
+
+func TestBuiltinSequences(t *testing.T) {
+	bindings := keys.Keys.NavigationKeys()
+
+	testCases := map[string]struct {
+		keys []string
+		want key.Binding
+	}{
+		"g p": {keys: []string{"g", "p"}, want: keys.Keys.SwitchToPrs},
+		"g i": {keys: []string{"g", "i"}, want: keys.Keys.SwitchToIssues},
+		"g r": {keys: []string{"g", "r"}, want: keys.Keys.SwitchToRepo},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var s keys.Sequence
+			var got []keys.KeyPress
+			for _, k := range tc.keys {
+				got = append(got, s.Press(keys.KeyMsg(k), bindings)...)
+			}
+			require.Len(t, got, 1)
+			require.True(t, key.Matches(got[0].Msg, tc.want))
+		})
+	}
+
+	var s keys.Sequence
+	require.Empty(t, s.Press(keys.KeyMsg("g"), bindings))
+	require.True(t, s.WaitsForTimeout(bindings))
+	got := s.Timeout(s.Id(), bindings)
+	require.Len(t, got, 1)
+	require.True(t, key.Matches(got[0].Msg, keys.Keys.FirstLine))
+}
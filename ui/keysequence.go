@@ -0,0 +1,130 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/dlvhdr/gh-dash/v4/config"
+	"github.com/dlvhdr/gh-dash/v4/ui/components/whichkey"
+	"github.com/dlvhdr/gh-dash/v4/ui/keys"
+)
+
+// keySequenceTimeout is how long the dashboard waits for the next key of a
+// sequence before it runs a binding that is also the start of longer ones.
+const keySequenceTimeout = time.Second
+
+type keySequenceTimeoutMsg struct {
+	id int
+}
+
+// sequenceBindings returns the bindings of the current view whose keys can
+// be pressed as a sequence, including custom keybindings.
+func (m *Model) sequenceBindings() []key.Binding {
+	bindings := append(m.keys.NavigationKeys(), m.keys.AppKeys()...)
+	bindings = append(bindings, m.keys.QuitAndHelpKeys()...)
+
+	customBindings := append([]config.Keybinding{}, m.ctx.Config.Keybindings.Universal...)
+	switch m.ctx.View {
+	case config.PRsView:
+		bindings = append(bindings, keys.PRFullHelp()...)
+		customBindings = append(customBindings, m.ctx.Config.Keybindings.Prs...)
+	case config.IssuesView:
+		bindings = append(bindings, keys.IssueFullHelp()...)
+		customBindings = append(customBindings, m.ctx.Config.Keybindings.Issues...)
+	case config.RepoView:
+		bindings = append(bindings, keys.BranchFullHelp()...)
+		customBindings = append(customBindings, m.ctx.Config.Keybindings.Branches...)
+	}
+
+	for _, kb := range customBindings {
+		if kb.Builtin == "" && kb.Command != "" {
+			bindings = append(bindings, key.NewBinding(
+				key.WithKeys(kb.Key),
+				key.WithHelp(kb.Key, kb.Command),
+			))
+		}
+	}
+	return bindings
+}
+
+// countHints returns the keys that use a count typed before them, like
+// "5j", and what they do with it.
+func (m *Model) countHints() []keys.Hint {
+	count := m.keySequence.Count()
+	return []keys.Hint{
+		{Key: m.keys.Down.Help().Key, Desc: fmt.Sprintf("move down %d rows", count)},
+		{Key: m.keys.Up.Help().Key, Desc: fmt.Sprintf("move up %d rows", count)},
+		{Key: m.keys.FirstLine.Help().Key, Desc: fmt.Sprintf("go to row %d", count)},
+		{Key: m.keys.LastLine.Help().Key, Desc: fmt.Sprintf("go to row %d", count)},
+		{Key: m.keys.NextSection.Help().Key, Desc: fmt.Sprintf("move %d sections right", count)},
+		{Key: m.keys.PrevSection.Help().Key, Desc: fmt.Sprintf("move %d sections left", count)},
+	}
+}
+
+// pressKey adds msg to the pending key sequence and handles the keys that
+// complete a binding.
+func (m Model) pressKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	bindings := m.sequenceBindings()
+	presses := m.keySequence.Press(msg, bindings)
+
+	var timeoutCmd tea.Cmd
+	if m.keySequence.WaitsForTimeout(bindings) {
+		id := m.keySequence.Id()
+		timeoutCmd = tea.Tick(keySequenceTimeout, func(time.Time) tea.Msg {
+			return keySequenceTimeoutMsg{id: id}
+		})
+	}
+
+	model, cmd := m.dispatchKeyPresses(presses)
+	return model, tea.Batch(cmd, timeoutCmd)
+}
+
+func (m Model) dispatchKeyPresses(presses []keys.KeyPress) (tea.Model, tea.Cmd) {
+	var cmds []tea.Cmd
+	for _, press := range presses {
+		m.isDispatchingKey = true
+		m.keyCount = press.Count
+		model, cmd := m.Update(press.Msg)
+		m = model.(Model)
+		cmds = append(cmds, cmd)
+	}
+	m.isDispatchingKey = false
+	m.keyCount = 0
+	return m, tea.Batch(cmds...)
+}
+
+// repeatCount is how many times to repeat the key being handled, which is the
+// count typed before it, or 1 if there's none.
+func (m *Model) repeatCount() int {
+	return max(1, m.keyCount)
+}
+
+// renderWhichKey shows the keys that continue the pending key sequence over
+// the bottom of content, the sections and preview pane.
+func (m *Model) renderWhichKey(content string) string {
+	if !m.keySequence.IsPending() {
+		return content
+	}
+
+	hints := m.countHints()
+	if len(m.keySequence.Pending()) > 0 {
+		hints = m.keySequence.Hints(m.sequenceBindings())
+	}
+	popup := strings.Split(whichkey.View(
+		&m.ctx,
+		m.ctx.ScreenWidth,
+		m.keySequence.String(),
+		hints,
+	), "\n")
+
+	lines := strings.Split(content, "\n")
+	if len(popup) > len(lines) {
+		popup = popup[:len(lines)]
+	}
+	lines = append(lines[:len(lines)-len(popup)], popup...)
+	return strings.Join(lines, "\n")
+}
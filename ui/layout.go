@@ -0,0 +1,163 @@
+package ui
+
+import (
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/log"
+
+	"github.com/dlvhdr/gh-dash/v4/config"
+	"github.com/dlvhdr/gh-dash/v4/ui/common"
+	"github.com/dlvhdr/gh-dash/v4/utils"
+)
+
+const (
+	previewStateFileName = "preview.yml"
+
+	// autoPreviewBottomWidth is the screen width below which the preview
+	// pane is shown below the sections when its position is auto.
+	autoPreviewBottomWidth = 100
+
+	previewWidthStep  = 5
+	previewHeightStep = 2
+	minPreviewWidth   = 20
+	minPreviewHeight  = 5
+	minSectionsWidth  = 20
+)
+
+var minSectionsHeight = common.SearchHeight + common.TableHeaderHeight + 3
+
+// previewSize is the size of the preview pane, which can be changed at
+// runtime and is kept across runs. The configured size it was changed from
+// is kept as well, so that changing the configuration takes precedence.
+type previewSize struct {
+	Width        int `yaml:"width"`
+	Height       int `yaml:"height"`
+	ConfigWidth  int `yaml:"configWidth"`
+	ConfigHeight int `yaml:"configHeight"`
+}
+
+func loadPreviewSize(cfg config.PreviewConfig) previewSize {
+	size := previewSize{
+		Width:        cfg.Width,
+		Height:       cfg.Height,
+		ConfigWidth:  cfg.Width,
+		ConfigHeight: cfg.Height,
+	}
+
+	var saved previewSize
+	if err := config.ReadState(previewStateFileName, &saved); err != nil {
+		log.Error("failed to read the preview pane's size", "err", err)
+		return size
+	}
+	if saved.ConfigWidth == cfg.Width && saved.Width > 0 {
+		size.Width = saved.Width
+	}
+	if saved.ConfigHeight == cfg.Height && saved.Height > 0 {
+		size.Height = saved.Height
+	}
+	return size
+}
+
+func (m *Model) savePreviewSize() {
+	if err := config.WriteState(previewStateFileName, m.previewSize); err != nil {
+		log.Error("failed to save the preview pane's size", "err", err)
+	}
+}
+
+// getPreviewPosition returns whether the preview pane is shown right of or
+// below the sections, which for the auto position depends on the screen's
+// width.
+func (m *Model) getPreviewPosition() config.PreviewPosition {
+	if m.ctx.Config == nil {
+		return config.PreviewRight
+	}
+
+	switch m.ctx.Config.Defaults.Preview.Position {
+	case config.PreviewBottom:
+		return config.PreviewBottom
+	case config.PreviewAuto:
+		if m.ctx.ScreenWidth < autoPreviewBottomWidth {
+			return config.PreviewBottom
+		}
+	}
+	return config.PreviewRight
+}
+
+// syncMainContentSize splits the screen between the sections and the preview
+// pane. When the preview pane is zoomed, it takes the place of the sections,
+// which keep their size.
+func (m *Model) syncMainContentSize() {
+	m.ctx.PreviewPosition = m.getPreviewPosition()
+	m.ctx.MainContentWidth = m.ctx.ScreenWidth
+	m.ctx.MainContentHeight = m.contentHeight
+
+	if m.ctx.PreviewPosition == config.PreviewBottom {
+		m.ctx.PreviewWidth = m.ctx.ScreenWidth
+		m.ctx.PreviewHeight = utils.Max(
+			utils.Min(m.previewSize.Height, m.contentHeight-minSectionsHeight),
+			utils.Min(minPreviewHeight, m.contentHeight),
+		)
+		if m.sidebar.IsOpen {
+			m.ctx.MainContentHeight -= m.ctx.PreviewHeight
+		}
+	} else {
+		m.ctx.PreviewWidth = utils.Max(
+			utils.Min(m.previewSize.Width, m.ctx.ScreenWidth-minSectionsWidth),
+			utils.Min(minPreviewWidth, m.ctx.ScreenWidth),
+		)
+		m.ctx.PreviewHeight = m.contentHeight
+		if m.sidebar.IsOpen {
+			m.ctx.MainContentWidth -= m.ctx.PreviewWidth
+		}
+	}
+
+	if m.isPreviewZoomed {
+		m.ctx.PreviewWidth = m.ctx.ScreenWidth
+		m.ctx.PreviewHeight = m.contentHeight
+	}
+}
+
+// resizePreview grows the preview pane by delta steps, or shrinks it when
+// delta is negative, along the side it's split from the sections.
+func (m *Model) resizePreview(delta int) {
+	if !m.sidebar.IsOpen {
+		return
+	}
+
+	if m.ctx.PreviewPosition == config.PreviewBottom {
+		m.previewSize.Height = utils.Max(
+			utils.Min(m.ctx.PreviewHeight+delta*previewHeightStep, m.contentHeight-minSectionsHeight),
+			minPreviewHeight,
+		)
+	} else {
+		m.previewSize.Width = utils.Max(
+			utils.Min(m.ctx.PreviewWidth+delta*previewWidthStep, m.ctx.ScreenWidth-minSectionsWidth),
+			minPreviewWidth,
+		)
+	}
+	m.savePreviewSize()
+	m.syncMainContentSize()
+}
+
+// toggleZoomPreview shows the preview pane in place of the sections, or puts
+// it back next to them.
+func (m *Model) toggleZoomPreview() {
+	m.isPreviewZoomed = !m.isPreviewZoomed
+	if m.isPreviewZoomed {
+		m.sidebar.IsOpen = true
+	}
+	m.syncMainContentSize()
+}
+
+// renderMainContent renders the current section and the preview pane.
+func (m *Model) renderMainContent(section string) string {
+	switch {
+	case !m.sidebar.IsOpen:
+		return section
+	case m.isPreviewZoomed:
+		return m.sidebar.View()
+	case m.ctx.PreviewPosition == config.PreviewBottom:
+		return lipgloss.JoinVertical(lipgloss.Left, section, m.sidebar.View())
+	default:
+		return lipgloss.JoinHorizontal(lipgloss.Top, section, m.sidebar.View())
+	}
+}
@@ -0,0 +1,40 @@
+package ui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/dlvhdr/gh-dash/v4/config"
+	"github.com/dlvhdr/gh-dash/v4/data"
+)
+
+// jumpToLinkedItem shows the item linked to the current row in the other
+// view: an issue the PR closes, or a PR that closes the issue. When there
+// are several, the first open one is shown.
+func (m *Model) jumpToLinkedItem() tea.Cmd {
+	var item data.LinkedItem
+	var ok bool
+	var view config.ViewType
+	switch row := m.getCurrRowData().(type) {
+	case *data.PullRequestData:
+		item, ok = row.ClosingIssues.Preferred()
+		view = config.IssuesView
+	case *data.IssueData:
+		item, ok = row.ClosingPrs.Preferred()
+		view = config.PRsView
+	default:
+		return nil
+	}
+	if !ok {
+		return m.notify("Nothing is linked to this " + m.getCurrSection().GetItemSingularForm())
+	}
+
+	switchCmd := m.switchView(view)
+	if m.selectRowByUrl(item.Url) {
+		return tea.Batch(switchCmd, m.onViewedRowChanged())
+	}
+
+	notifyCmd := m.notify(fmt.Sprintf("Fetching %s#%d", item.Repository.NameWithOwner, item.Number))
+	return tea.Batch(switchCmd, notifyCmd, fetchItem(item.Url, false))
+}
@@ -8,6 +8,9 @@ import (
 	"os"
 	"os/exec"
 	"reflect"
+	"regexp"
+	"sort"
+	"strings"
 	"text/template"
 	"time"
 
@@ -18,7 +21,10 @@ import (
 	"github.com/dlvhdr/gh-dash/v4/config"
 	"github.com/dlvhdr/gh-dash/v4/data"
 	"github.com/dlvhdr/gh-dash/v4/ui/common"
+	"github.com/dlvhdr/gh-dash/v4/ui/components/issuessection"
+	"github.com/dlvhdr/gh-dash/v4/ui/components/prssection"
 	"github.com/dlvhdr/gh-dash/v4/ui/components/section"
+	"github.com/dlvhdr/gh-dash/v4/ui/components/tasks"
 	"github.com/dlvhdr/gh-dash/v4/ui/constants"
 	"github.com/dlvhdr/gh-dash/v4/ui/context"
 	"github.com/dlvhdr/gh-dash/v4/ui/markdown"
@@ -40,6 +46,38 @@ func (m *Model) getCurrRowData() data.RowData {
 	return section.GetCurrRow()
 }
 
+// rowItem is the PR or issue of the current row, which the local triage
+// state and notes are keyed by.
+type rowItem struct {
+	ref       string
+	url       string
+	id        string
+	author    string
+	updatedAt time.Time
+}
+
+func (m *Model) getCurrRowItem() *rowItem {
+	switch row := m.getCurrRowData().(type) {
+	case *data.PullRequestData:
+		return &rowItem{
+			ref:       fmt.Sprintf("%s#%d", row.GetRepoNameWithOwner(), row.Number),
+			url:       row.Url,
+			id:        row.Id,
+			author:    row.Author.Login,
+			updatedAt: row.UpdatedAt,
+		}
+	case *data.IssueData:
+		return &rowItem{
+			ref:       fmt.Sprintf("%s#%d", row.GetRepoNameWithOwner(), row.Number),
+			url:       row.Url,
+			id:        row.Id,
+			author:    row.Author.Login,
+			updatedAt: row.UpdatedAt,
+		}
+	}
+	return nil
+}
+
 func (m *Model) getSectionAt(id int) section.Section {
 	sections := m.getCurrentViewSections()
 	if len(sections) <= id {
@@ -273,3 +311,104 @@ func (m *Model) notifyErr(text string) tea.Cmd {
 
 	return tea.Sequence(startCmd, finishCmd)
 }
+
+var repoFilterRegex = regexp.MustCompile(`(?:^|\s)repo:(\S+)`)
+
+// getDefaultNewIssueRepo returns the repository new issues are created in by
+// default: the first `repo:` filter of the current section, or else the first
+// repository in repoPaths.
+func (m *Model) getDefaultNewIssueRepo() string {
+	if currSection := m.getCurrSection(); currSection != nil {
+		if match := repoFilterRegex.FindStringSubmatch(currSection.GetFilters()); match != nil {
+			return match[1]
+		}
+	}
+
+	repos := m.getRepoSuggestions()
+	if len(repos) > 0 {
+		return repos[0]
+	}
+	return ""
+}
+
+// getRepoSuggestions returns the repositories that are referenced by the
+// sections' filters and repoPaths.
+func (m *Model) getRepoSuggestions() []string {
+	seen := map[string]bool{}
+	repos := []string{}
+	add := func(repo string) {
+		if seen[repo] || strings.Contains(repo, "*") {
+			return
+		}
+		seen[repo] = true
+		repos = append(repos, repo)
+	}
+
+	paths := make([]string, 0, len(m.ctx.Config.RepoPaths))
+	for repo := range m.ctx.Config.RepoPaths {
+		paths = append(paths, repo)
+	}
+	sort.Strings(paths)
+	for _, repo := range paths {
+		add(repo)
+	}
+
+	for _, s := range m.getCurrentViewSections() {
+		for _, match := range repoFilterRegex.FindAllStringSubmatch(s.GetFilters(), -1) {
+			add(match[1])
+		}
+	}
+
+	return repos
+}
+
+func (m *Model) refreshIssueSections() []tea.Cmd {
+	if m.ctx.View != config.IssuesView {
+		return nil
+	}
+
+	var cmds []tea.Cmd
+	for _, s := range m.issues {
+		s.ResetRows()
+		cmds = append(cmds, s.FetchNextPageSectionRows()...)
+	}
+	return cmds
+}
+
+// addSavedSection adds a section saved from a search to the sections of its
+// view, and switches to it when it's in the current view.
+func (m *Model) addSavedSection(msg tasks.SectionSavedMsg) tea.Cmd {
+	var s section.Section
+	if msg.View == config.PRsView {
+		sectionConfig := config.PrsSectionConfig{
+			Title:   msg.Config.Title,
+			Filters: msg.Config.Filters,
+			Limit:   msg.Config.Limit,
+		}
+		m.ctx.Config.PRSections = append(m.ctx.Config.PRSections, sectionConfig)
+		if len(m.prs) > 0 {
+			sectionModel := prssection.NewModel(len(m.prs), &m.ctx, sectionConfig, time.Now())
+			m.prs = append(m.prs, &sectionModel)
+			s = &sectionModel
+		}
+	} else {
+		sectionConfig := config.IssuesSectionConfig{
+			Title:   msg.Config.Title,
+			Filters: msg.Config.Filters,
+			Limit:   msg.Config.Limit,
+		}
+		m.ctx.Config.IssuesSections = append(m.ctx.Config.IssuesSections, sectionConfig)
+		if len(m.issues) > 0 {
+			sectionModel := issuessection.NewModel(len(m.issues), &m.ctx, sectionConfig, time.Now())
+			m.issues = append(m.issues, &sectionModel)
+			s = &sectionModel
+		}
+	}
+
+	m.tabs.UpdateSectionsConfigs(&m.ctx)
+	if s == nil || m.ctx.View != msg.View {
+		return nil
+	}
+	m.setCurrSectionId(s.GetId())
+	return tea.Batch(append(s.FetchNextPageSectionRows(), m.onViewedRowChanged())...)
+}
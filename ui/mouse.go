@@ -0,0 +1,134 @@
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/dlvhdr/gh-dash/v4/config"
+	"github.com/dlvhdr/gh-dash/v4/ui/components/section"
+)
+
+// mouseWheelDelta is the number of lines the sidebar scrolls by for each
+// turn of the mouse wheel.
+const mouseWheelDelta = 3
+
+// updateMouse handles clicks on rows, tabs and the view switcher, and the
+// mouse wheel over the rows and the sidebar.
+func (m *Model) updateMouse(msg tea.MouseMsg) tea.Cmd {
+	if m.ctx.Config == nil || m.diffView.IsOpen() || msg.Action != tea.MouseActionPress {
+		return nil
+	}
+
+	mainTop := m.getMainContentTop()
+	mainBottom := mainTop + m.contentHeight
+	if m.isInPreview(msg.X, msg.Y-mainTop) {
+		switch msg.Button {
+		case tea.MouseButtonWheelDown:
+			m.sidebar.ScrollDown(mouseWheelDelta)
+		case tea.MouseButtonWheelUp:
+			m.sidebar.ScrollUp(mouseWheelDelta)
+		}
+		return nil
+	}
+
+	if m.isTextInputFocused() {
+		return nil
+	}
+
+	currSection := m.getCurrSection()
+	switch {
+	case msg.Y < mainTop:
+		if msg.Button != tea.MouseButtonLeft || m.ctx.View == config.RepoView {
+			return nil
+		}
+		if id, ok := m.tabs.SectionAt(m.ctx, msg.X); ok && m.getSectionAt(id) != nil {
+			m.setCurrSectionId(id)
+			return m.onViewedRowChanged()
+		}
+
+	case msg.Y < mainBottom:
+		if currSection == nil || m.isPreviewZoomed || msg.Y-mainTop >= m.ctx.MainContentHeight {
+			return nil
+		}
+		switch msg.Button {
+		case tea.MouseButtonWheelDown:
+			return m.selectRow(currSection, currSection.CurrRow()+1)
+		case tea.MouseButtonWheelUp:
+			currSection.PrevRow()
+			return m.onRowSelected(currSection)
+		case tea.MouseButtonLeft:
+			if row, ok := currSection.RowAt(msg.Y - mainTop); ok {
+				return m.selectRow(currSection, row)
+			}
+		}
+
+	case msg.Y == mainBottom:
+		if msg.Button != tea.MouseButtonLeft || m.ctx.Error != nil || m.footer.ShowConfirmQuit {
+			return nil
+		}
+		if view, ok := m.footer.ViewAt(msg.X); ok && view != m.ctx.View {
+			return m.switchView(view)
+		}
+	}
+
+	return nil
+}
+
+// selectRow selects row of s, fetching the section's next page when it's the
+// last row, like moving down to it does.
+func (m *Model) selectRow(s section.Section, row int) tea.Cmd {
+	var cmds []tea.Cmd
+	prevRow := s.CurrRow()
+	if row == prevRow+1 {
+		row = s.NextRow()
+	} else {
+		row = s.SetCurrRow(row)
+	}
+	if prevRow != row && row == s.NumRows()-1 && m.ctx.View != config.RepoView {
+		cmds = append(cmds, s.FetchNextPageSectionRows()...)
+	}
+	cmds = append(cmds, m.onRowSelected(s))
+	return tea.Batch(cmds...)
+}
+
+func (m *Model) onRowSelected(s section.Section) tea.Cmd {
+	if s.IsVisualSelecting() {
+		s.UpdateVisualSelection(s.GetRows(), s.CurrRow())
+	}
+	return m.onViewedRowChanged()
+}
+
+// isInPreview reports whether the point at x and y, counted from the top of
+// the main content, is in the preview pane.
+func (m *Model) isInPreview(x, y int) bool {
+	switch {
+	case !m.sidebar.IsOpen || y < 0 || y >= m.contentHeight:
+		return false
+	case m.isPreviewZoomed:
+		return true
+	case m.ctx.PreviewPosition == config.PreviewBottom:
+		return y >= m.ctx.MainContentHeight
+	default:
+		return x >= m.ctx.MainContentWidth
+	}
+}
+
+// getMainContentTop returns the line the sections and the sidebar start at,
+// which is right below the tabs.
+func (m *Model) getMainContentTop() int {
+	if m.ctx.View == config.RepoView {
+		return 1
+	}
+	return lipgloss.Height(m.tabs.View(m.ctx))
+}
+
+// isTextInputFocused reports whether keys are going to a text input, in which
+// case the mouse can't change the selected row.
+func (m *Model) isTextInputFocused() bool {
+	if s := m.getCurrSection(); s != nil && (s.IsSearchFocused() || s.IsPromptConfirmationFocused()) {
+		return true
+	}
+	return m.prSidebar.IsTextInputBoxFocused() ||
+		m.issueSidebar.IsTextInputBoxFocused() ||
+		m.issueCreator.IsOpen()
+}
@@ -0,0 +1,88 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/dlvhdr/gh-dash/v4/ui/notes"
+)
+
+// openNotePrompt asks for the private note of the current row, starting
+// from its current note.
+func (m *Model) openNotePrompt() tea.Cmd {
+	return m.openNotesPrompt(notePrompt, func(note notes.Note) string {
+		return note.Text
+	})
+}
+
+// openTagsPrompt asks for the local tags of the current row, starting from
+// its current tags.
+func (m *Model) openTagsPrompt() tea.Cmd {
+	return m.openNotesPrompt(tagsPrompt, func(note notes.Note) string {
+		return strings.Join(note.Tags, " ")
+	})
+}
+
+func (m *Model) openNotesPrompt(kind footerPromptKind, value func(note notes.Note) string) tea.Cmd {
+	item := m.getCurrRowItem()
+	if item == nil {
+		return nil
+	}
+
+	m.promptItem = item
+	cmd := m.openFooterPrompt(kind)
+	note, _ := m.ctx.Notes.Get(item.url)
+	m.footerPrompt.SetValue(value(note))
+	m.footer.SetLeftSection(m.footerPrompt.View())
+	return cmd
+}
+
+// setNote sets the note of the item the note prompt was opened on to text.
+func (m *Model) setNote(text string) tea.Cmd {
+	item := m.promptItem
+	m.promptItem = nil
+	if item == nil {
+		return nil
+	}
+
+	m.ctx.Notes.SetText(item.url, text)
+	notifyText := fmt.Sprintf("Saved the note of %s", item.ref)
+	if strings.TrimSpace(text) == "" {
+		notifyText = fmt.Sprintf("Deleted the note of %s", item.ref)
+	}
+	return tea.Batch(m.notify(notifyText), m.onNotesChanged())
+}
+
+// setTags sets the tags of the item the tags prompt was opened on to the
+// ones input lists.
+func (m *Model) setTags(input string) tea.Cmd {
+	item := m.promptItem
+	m.promptItem = nil
+	if item == nil {
+		return nil
+	}
+
+	tags := notes.ParseTags(input)
+	m.ctx.Notes.SetTags(item.url, tags)
+	notifyText := fmt.Sprintf("Tagged %s with %s", item.ref, notes.Note{Tags: tags}.FormattedTags())
+	if len(tags) == 0 {
+		notifyText = fmt.Sprintf("Removed the tags of %s", item.ref)
+	}
+	return tea.Batch(m.notify(notifyText), m.onNotesChanged())
+}
+
+// onNotesChanged rerenders the rows and the preview of the view, which show
+// the notes.
+func (m *Model) onNotesChanged() tea.Cmd {
+	var cmds []tea.Cmd
+	for _, s := range m.getCurrentViewSections() {
+		if s == nil {
+			continue
+		}
+		cmds = append(cmds, m.updateSection(s.GetId(), s.GetType(), notes.ChangedMsg{}))
+	}
+	cmds = append(cmds, m.syncSidebar())
+	return tea.Batch(cmds...)
+}
@@ -0,0 +1,123 @@
+// Package notes keeps private notes and tags on PRs and issues. They're only
+// saved locally and never sent to GitHub.
+package notes
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/log"
+
+	"github.com/dlvhdr/gh-dash/v4/config"
+)
+
+const stateFileName = "notes.yml"
+
+type Note struct {
+	Text      string    `yaml:"text,omitempty"`
+	Tags      []string  `yaml:"tags,omitempty"`
+	UpdatedAt time.Time `yaml:"updatedAt"`
+}
+
+func (n Note) IsEmpty() bool {
+	return n.Text == "" && len(n.Tags) == 0
+}
+
+// FormattedTags returns the tags as they're shown and filtered by, like
+// "#blocked #test-locally".
+func (n Note) FormattedTags() string {
+	tags := make([]string, 0, len(n.Tags))
+	for _, tag := range n.Tags {
+		tags = append(tags, "#"+tag)
+	}
+	return strings.Join(tags, " ")
+}
+
+// Store is the notes of the items, keyed by their URLs. It's saved whenever
+// it changes. A nil Store has no notes.
+type Store struct {
+	mu    sync.Mutex
+	notes map[string]Note
+}
+
+// ChangedMsg is sent to the sections when a note changed, so they show it.
+type ChangedMsg struct{}
+
+// NewStore returns the notes saved by previous sessions.
+func NewStore() *Store {
+	s := &Store{}
+	if err := config.ReadState(stateFileName, &s.notes); err != nil {
+		log.Error("failed to read notes", "err", err)
+	}
+	if s.notes == nil {
+		s.notes = map[string]Note{}
+	}
+	return s
+}
+
+func (s *Store) save() {
+	if err := config.WriteState(stateFileName, s.notes); err != nil {
+		log.Error("failed to save notes", "err", err)
+	}
+}
+
+// Get returns the note of the item at url, if it has one.
+func (s *Store) Get(url string) (Note, bool) {
+	if s == nil {
+		return Note{}, false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	note, ok := s.notes[url]
+	return note, ok
+}
+
+// SetText sets the text of the note of the item at url. The note is deleted
+// when it has neither text nor tags.
+func (s *Store) SetText(url string, text string) {
+	s.update(url, func(note *Note) {
+		note.Text = strings.TrimSpace(text)
+	})
+}
+
+// SetTags sets the tags of the item at url.
+func (s *Store) SetTags(url string, tags []string) {
+	s.update(url, func(note *Note) {
+		note.Tags = tags
+	})
+}
+
+func (s *Store) update(url string, f func(note *Note)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	defer s.save()
+
+	note := s.notes[url]
+	f(&note)
+	if note.IsEmpty() {
+		delete(s.notes, url)
+		return
+	}
+	note.UpdatedAt = time.Now()
+	s.notes[url] = note
+}
+
+// ParseTags parses tags separated by spaces or commas, with or without a
+// leading #. Duplicates are dropped, ignoring case.
+func ParseTags(input string) []string {
+	var tags []string
+	seen := map[string]bool{}
+	fields := strings.FieldsFunc(input, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t'
+	})
+	for _, field := range fields {
+		tag := strings.TrimLeft(field, "#")
+		if tag == "" || seen[strings.ToLower(tag)] {
+			continue
+		}
+		seen[strings.ToLower(tag)] = true
+		tags = append(tags, tag)
+	}
+	return tags
+}
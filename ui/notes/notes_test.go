@@ -0,0 +1,67 @@
+package notes_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dlvhdr/gh-dash/v4/ui/notes"
+)
+
+const prUrl = "https://github.com/dlvhdr/gh-dash/pull/1"
+
+func TestStore(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+
+	s := notes.NewStore()
+	s.SetText(prUrl, " waiting on infra ")
+	s.SetTags(prUrl, []string{"blocked", "infra"})
+
+	s = notes.NewStore()
+	note, ok := s.Get(prUrl)
+	require.True(t, ok, "the notes are saved")
+	require.Equal(t, "waiting on infra", note.Text)
+	require.Equal(t, "#blocked #infra", note.FormattedTags())
+
+	s.SetText(prUrl, "")
+	_, ok = s.Get(prUrl)
+	require.True(t, ok, "a note with tags is kept")
+
+	s.SetTags(prUrl, nil)
+	_, ok = s.Get(prUrl)
+	require.False(t, ok, "an empty note is deleted")
+
+	var nilStore *notes.Store
+	_, ok = nilStore.Get(prUrl)
+	require.False(t, ok)
+}
+
+func TestParseTags(t *testing.T) {
+	testCases := map[string]struct {
+		input string
+		want  []string
+	}{
+		"empty": {
+			input: "  ",
+			want:  nil,
+		},
+		"spaces and commas": {
+			input: "blocked, test-locally  infra",
+			want:  []string{"blocked", "test-locally", "infra"},
+		},
+		"hashes": {
+			input: "#blocked ##infra #",
+			want:  []string{"blocked", "infra"},
+		},
+		"duplicates": {
+			input: "Blocked blocked",
+			want:  []string{"Blocked"},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.want, notes.ParseTags(tc.input))
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/dlvhdr/gh-dash/v4/config"
+	"github.com/dlvhdr/gh-dash/v4/ui/components/palette"
+	"github.com/dlvhdr/gh-dash/v4/ui/keys"
+)
+
+// rowlessKeys are the keys of a view that don't act on the current row, so
+// the palette lists them when there's none.
+func rowlessKeys() []key.Binding {
+	return []key.Binding{
+		keys.IssueKeys.New,
+		keys.IssueKeys.ViewPRs,
+		keys.PRKeys.ViewIssues,
+		keys.BranchKeys.New,
+		keys.BranchKeys.ViewPRs,
+	}
+}
+
+// rowKeys are the universal keys that act on the current row.
+func rowKeys() []key.Binding {
+	k := keys.Keys
+	return []key.Binding{
+		k.OpenGithub,
+		k.CopyNumber,
+		k.CopyUrl,
+		k.ToggleSelection,
+		k.Pin,
+		k.Snooze,
+		k.Mute,
+		k.Note,
+		k.Tags,
+	}
+}
+
+// getPaletteActions returns the actions that can run in the current view,
+// given whether there's a current row and selected rows.
+func (m *Model) getPaletteActions() []palette.Action {
+	hasRow := m.getCurrRowData() != nil
+	numSelected := 0
+	if currSection := m.getCurrSection(); currSection != nil {
+		numSelected = currSection.NumSelected()
+	}
+
+	var actions []palette.Action
+	addBinding := func(binding key.Binding, group string, needsRow bool) {
+		if !binding.Enabled() || len(binding.Keys()) == 0 || (needsRow && !hasRow) {
+			return
+		}
+		action := palette.Action{
+			Key:     binding.Keys()[0],
+			KeyHelp: binding.Help().Key,
+			Desc:    binding.Help().Desc,
+			Group:   group,
+		}
+		if numSelected > 0 && m.getBulkAction(keys.KeyMsg(action.Key)) != "" {
+			action.Desc = fmt.Sprintf("%s (%d selected)", action.Desc, numSelected)
+		}
+		actions = append(actions, action)
+	}
+	containsBinding := func(bindings []key.Binding, binding key.Binding) bool {
+		for _, b := range bindings {
+			if b.Help() == binding.Help() {
+				return true
+			}
+		}
+		return false
+	}
+
+	var viewGroup string
+	var viewBindings []key.Binding
+	var customBindings []config.Keybinding
+	switch m.ctx.View {
+	case config.PRsView:
+		viewGroup, viewBindings, customBindings = "PRs", keys.PRFullHelp(), m.ctx.Config.Keybindings.Prs
+	case config.IssuesView:
+		viewGroup, viewBindings, customBindings = "Issues", keys.IssueFullHelp(), m.ctx.Config.Keybindings.Issues
+	case config.RepoView:
+		viewGroup, viewBindings, customBindings = "Branches", keys.BranchFullHelp(), m.ctx.Config.Keybindings.Branches
+	}
+	for _, binding := range viewBindings {
+		addBinding(binding, viewGroup, !containsBinding(rowlessKeys(), binding))
+	}
+
+	for _, binding := range append(m.keys.NavigationKeys(), m.keys.AppKeys()...) {
+		if binding.Help() == m.keys.CommandPalette.Help() {
+			continue
+		}
+		addBinding(binding, "Universal", containsBinding(rowKeys(), binding))
+	}
+
+	for _, kb := range m.ctx.Config.Keybindings.Universal {
+		if kb.Command != "" {
+			actions = append(actions, palette.Action{
+				Key: kb.Key, KeyHelp: kb.Key, Desc: "custom command", Group: "Custom", Command: kb.Command,
+			})
+		}
+	}
+	if hasRow {
+		for _, kb := range customBindings {
+			if kb.Command != "" {
+				actions = append(actions, palette.Action{
+					Key: kb.Key, KeyHelp: kb.Key, Desc: "custom command", Group: "Custom " + viewGroup, Command: kb.Command,
+				})
+			}
+		}
+	}
+
+	for _, binding := range m.keys.QuitAndHelpKeys() {
+		addBinding(binding, "Universal", false)
+	}
+	return actions
+}
+
+// openPalette shows the command palette in the preview pane.
+func (m *Model) openPalette() tea.Cmd {
+	cmd := m.palette.Open(m.getPaletteActions())
+	m.sidebar.IsOpen = true
+	m.syncMainContentSize()
+	syncCmd := m.syncSidebar()
+	m.sidebar.ScrollToTop()
+	return tea.Batch(cmd, syncCmd)
+}
+
+// runPaletteAction runs the action chosen in the palette by pressing its key.
+func (m Model) runPaletteAction(action palette.Action) (tea.Model, tea.Cmd) {
+	syncCmd := m.syncSidebar()
+	model, cmd := m.Update(keys.KeyMsg(action.Key))
+	return model, tea.Batch(syncCmd, cmd)
+}
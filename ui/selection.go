@@ -0,0 +1,66 @@
+package ui
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/dlvhdr/gh-dash/v4/config"
+	"github.com/dlvhdr/gh-dash/v4/ui/components/section"
+	"github.com/dlvhdr/gh-dash/v4/ui/keys"
+)
+
+// toggleSelection toggles the selection of the current row and moves to the
+// next one, so consecutive rows can be selected by repeating the key.
+func (m *Model) toggleSelection(currSection section.Section) tea.Cmd {
+	if currSection == nil || m.ctx.View == config.RepoView {
+		return nil
+	}
+	row := currSection.GetCurrRow()
+	if row == nil {
+		return nil
+	}
+
+	currSection.ToggleSelection(row)
+	currSection.NextRow()
+	return m.onViewedRowChanged()
+}
+
+// getBulkAction returns the confirmation action to run on the selected rows
+// for msg, or an empty string if msg doesn't trigger a bulk action.
+func (m *Model) getBulkAction(msg tea.KeyMsg) string {
+	switch m.ctx.View {
+	case config.PRsView:
+		switch {
+		case key.Matches(msg, keys.PRKeys.Approve):
+			return "approve"
+		case key.Matches(msg, keys.PRKeys.Assign):
+			return "assign"
+		case key.Matches(msg, keys.PRKeys.Unassign):
+			return "unassign"
+		case key.Matches(msg, keys.PRKeys.Labels):
+			return "labels"
+		case key.Matches(msg, keys.PRKeys.Merge):
+			return "merge"
+		case key.Matches(msg, keys.PRKeys.Close):
+			return "close"
+		case key.Matches(msg, keys.PRKeys.Reopen):
+			return "reopen"
+		}
+
+	case config.IssuesView:
+		switch {
+		case key.Matches(msg, keys.IssueKeys.Assign):
+			return "assign"
+		case key.Matches(msg, keys.IssueKeys.Unassign):
+			return "unassign"
+		case key.Matches(msg, keys.IssueKeys.Labels):
+			return "labels"
+		case key.Matches(msg, keys.IssueKeys.Close):
+			return "close"
+		case key.Matches(msg, keys.IssueKeys.Reopen):
+			return "reopen"
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,164 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/dlvhdr/gh-dash/v4/config"
+	"github.com/dlvhdr/gh-dash/v4/ui/components/issuessection"
+	"github.com/dlvhdr/gh-dash/v4/ui/components/prssection"
+	"github.com/dlvhdr/gh-dash/v4/ui/components/section"
+	"github.com/dlvhdr/gh-dash/v4/ui/triage"
+)
+
+// togglePin pins or unpins the current row. The pinned section is added or
+// removed when the first item is pinned or the last one is unpinned.
+func (m *Model) togglePin() tea.Cmd {
+	item := m.getCurrRowItem()
+	if item == nil {
+		return nil
+	}
+
+	prs := m.ctx.View == config.PRsView
+	hadPinned := m.ctx.Triage.HasPinned(prs)
+	pinned := m.ctx.Triage.TogglePin(item.url, item.id)
+	notifyCmd := m.notify(fmt.Sprintf("Unpinned %s", item.ref))
+	if pinned {
+		notifyCmd = m.notify(fmt.Sprintf("Pinned %s", item.ref))
+	}
+
+	if hadPinned != m.ctx.Triage.HasPinned(prs) {
+		return tea.Batch(notifyCmd, m.togglePinnedSection(pinned))
+	}
+
+	pinnedSection := m.getSectionAt(1)
+	if pinnedSection == nil {
+		return notifyCmd
+	}
+	pinnedSection.ResetRows()
+	return tea.Batch(append(pinnedSection.FetchNextPageSectionRows(), notifyCmd)...)
+}
+
+// togglePinnedSection inserts the pinned section after the search section,
+// or removes it, and renumbers the sections after it. Only the pinned section
+// is fetched, and sections that were still loading, since their results are
+// addressed to their old ids. The current section stays selected.
+func (m *Model) togglePinnedSection(added bool) tea.Cmd {
+	sections := slices.Clone(m.getCurrentViewSections())
+	if len(sections) == 0 {
+		return nil
+	}
+
+	var cmds []tea.Cmd
+	renumberFrom := 1
+	if added {
+		var pinned section.Section
+		if m.ctx.View == config.PRsView {
+			s := prssection.NewPinnedModel(&m.ctx, time.Now())
+			pinned = &s
+		} else {
+			s := issuessection.NewPinnedModel(&m.ctx, time.Now())
+			pinned = &s
+		}
+		cmds = append(cmds, pinned.FetchNextPageSectionRows()...)
+		sections = slices.Insert(sections, 1, pinned)
+		renumberFrom = 2
+	} else if len(sections) > 1 {
+		sections = slices.Delete(sections, 1, 2)
+	}
+
+	for i := renumberFrom; i < len(sections); i++ {
+		sections[i].SetId(i)
+		if sections[i].IsLoading() {
+			sections[i].ResetRows()
+			cmds = append(cmds, sections[i].FetchNextPageSectionRows()...)
+		}
+	}
+
+	if m.ctx.View == config.PRsView {
+		m.prs = sections
+	} else {
+		m.issues = sections
+	}
+	m.tabs.UpdateSectionsConfigs(&m.ctx)
+
+	currSectionId := m.currSectionId
+	if added && currSectionId > 0 {
+		currSectionId++
+	} else if !added && currSectionId > 1 {
+		currSectionId--
+	}
+	m.setCurrSectionId(currSectionId)
+	return tea.Batch(append(cmds, m.onViewedRowChanged())...)
+}
+
+// toggleSnooze unsnoozes the current row if it's snoozed, or else asks until
+// when to snooze it.
+func (m *Model) toggleSnooze() tea.Cmd {
+	item := m.getCurrRowItem()
+	if item == nil {
+		return nil
+	}
+
+	if m.ctx.Triage.Unsnooze(item.url) {
+		return tea.Batch(m.notify(fmt.Sprintf("Unsnoozed %s", item.ref)), m.onTriageChanged())
+	}
+
+	m.promptItem = item
+	cmd := m.openFooterPrompt(snoozePrompt)
+	m.footer.SetLeftSection(m.footerPrompt.View())
+	return cmd
+}
+
+// snooze hides the item the snooze prompt was opened on until the time
+// input says, or until it's updated when input is empty.
+func (m *Model) snooze(input string) tea.Cmd {
+	item := m.promptItem
+	m.promptItem = nil
+	if item == nil {
+		return nil
+	}
+
+	until, err := triage.ParseSnoozeUntil(input, time.Now())
+	if err != nil {
+		return m.notifyErr(err.Error())
+	}
+	m.ctx.Triage.Snooze(item.url, until, item.updatedAt)
+
+	text := fmt.Sprintf("Snoozed %s until it's updated", item.ref)
+	if until != nil {
+		text = fmt.Sprintf("Snoozed %s until %s", item.ref, until.Format("Jan 2 15:04"))
+	}
+	return tea.Batch(m.notify(text), m.onTriageChanged())
+}
+
+// toggleMute mutes or unmutes the current row.
+func (m *Model) toggleMute() tea.Cmd {
+	item := m.getCurrRowItem()
+	if item == nil {
+		return nil
+	}
+
+	text := fmt.Sprintf("Unmuted %s", item.ref)
+	if m.ctx.Triage.ToggleMute(item.url) {
+		text = fmt.Sprintf("Muted %s", item.ref)
+	}
+	return tea.Batch(m.notify(text), m.onTriageChanged())
+}
+
+// onTriageChanged lets the sections of the view hide or show the items the
+// triage state change affects.
+func (m *Model) onTriageChanged() tea.Cmd {
+	var cmds []tea.Cmd
+	for _, s := range m.getCurrentViewSections() {
+		if s == nil {
+			continue
+		}
+		cmds = append(cmds, m.updateSection(s.GetId(), s.GetType(), triage.ChangedMsg{}))
+	}
+	cmds = append(cmds, m.onViewedRowChanged())
+	return tea.Batch(cmds...)
+}
@@ -0,0 +1,271 @@
+// Package triage keeps the local triage state of PRs and issues: which are
+// pinned, snoozed or muted. It's never sent to GitHub.
+package triage
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/log"
+
+	"github.com/dlvhdr/gh-dash/v4/config"
+	"github.com/dlvhdr/gh-dash/v4/data"
+)
+
+const stateFileName = "triage.yml"
+
+type Pin struct {
+	// Id is the GraphQL node ID of the item, which pinned items are fetched
+	// by.
+	Id       string    `yaml:"id"`
+	PinnedAt time.Time `yaml:"pinnedAt"`
+}
+
+// Snooze hides an item until Until, or, when Until is nil, until the item is
+// updated after UpdatedAt.
+type Snooze struct {
+	Until     *time.Time `yaml:"until,omitempty"`
+	UpdatedAt time.Time  `yaml:"updatedAt"`
+}
+
+type state struct {
+	Pinned  map[string]Pin    `yaml:"pinned"`
+	Snoozed map[string]Snooze `yaml:"snoozed"`
+	Muted   map[string]bool   `yaml:"muted"`
+}
+
+// Store is the triage state of the items, keyed by their URLs. It's saved
+// whenever it changes. A nil Store has no state.
+type Store struct {
+	mu           sync.Mutex
+	state        state
+	mutedAuthors []string
+}
+
+// ChangedMsg is sent to the sections when the triage state changed, so they
+// hide or show the items it affects.
+type ChangedMsg struct{}
+
+// NewStore returns the triage state saved by previous sessions.
+func NewStore() *Store {
+	s := &Store{}
+	if err := config.ReadState(stateFileName, &s.state); err != nil {
+		log.Error("failed to read triage state", "err", err)
+	}
+	if s.state.Pinned == nil {
+		s.state.Pinned = map[string]Pin{}
+	}
+	if s.state.Snoozed == nil {
+		s.state.Snoozed = map[string]Snooze{}
+	}
+	if s.state.Muted == nil {
+		s.state.Muted = map[string]bool{}
+	}
+	return s
+}
+
+func (s *Store) save() {
+	if err := config.WriteState(stateFileName, s.state); err != nil {
+		log.Error("failed to save triage state", "err", err)
+	}
+}
+
+// SetMutedAuthors mutes every item authored by one of authors.
+func (s *Store) SetMutedAuthors(authors []string) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.mutedAuthors = authors
+}
+
+func (s *Store) IsPinned(url string) bool {
+	if s == nil {
+		return false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.state.Pinned[url]
+	return ok
+}
+
+// TogglePin pins the item at url, whose node ID is id, or unpins it if it's
+// pinned. It returns whether the item is pinned now.
+func (s *Store) TogglePin(url string, id string) bool {
+	if s == nil {
+		return false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	defer s.save()
+
+	if _, ok := s.state.Pinned[url]; ok {
+		delete(s.state.Pinned, url)
+		return false
+	}
+	s.state.Pinned[url] = Pin{Id: id, PinnedAt: time.Now()}
+	return true
+}
+
+// PinnedIds returns the node IDs of the pinned PRs, or of the pinned issues
+// if prs is false, in the order they were pinned.
+func (s *Store) PinnedIds(prs bool) []string {
+	if s == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var pins []Pin
+	for url, pin := range s.state.Pinned {
+		if data.IsPullRequestUrl(url) == prs {
+			pins = append(pins, pin)
+		}
+	}
+	sort.Slice(pins, func(i, j int) bool {
+		return pins[i].PinnedAt.Before(pins[j].PinnedAt)
+	})
+
+	ids := make([]string, 0, len(pins))
+	for _, pin := range pins {
+		ids = append(ids, pin.Id)
+	}
+	return ids
+}
+
+// HasPinned reports whether any PRs, or issues if prs is false, are pinned.
+func (s *Store) HasPinned(prs bool) bool {
+	return len(s.PinnedIds(prs)) > 0
+}
+
+// Snooze hides the item at url until until, or until it's updated after
+// updatedAt if until is nil.
+func (s *Store) Snooze(url string, until *time.Time, updatedAt time.Time) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	defer s.save()
+	s.state.Snoozed[url] = Snooze{Until: until, UpdatedAt: updatedAt}
+}
+
+// Unsnooze shows the item at url again. It returns false if it wasn't
+// snoozed.
+func (s *Store) Unsnooze(url string) bool {
+	if s == nil {
+		return false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.state.Snoozed[url]; !ok {
+		return false
+	}
+	delete(s.state.Snoozed, url)
+	s.save()
+	return true
+}
+
+// GetSnooze returns how the item at url is snoozed, if it is.
+func (s *Store) GetSnooze(url string) (Snooze, bool) {
+	if s == nil {
+		return Snooze{}, false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	snooze, ok := s.state.Snoozed[url]
+	return snooze, ok
+}
+
+// IsSnoozed reports whether the item at url, which was last updated at
+// updatedAt, is still snoozed at now.
+func (s *Store) IsSnoozed(url string, updatedAt time.Time, now time.Time) bool {
+	snooze, ok := s.GetSnooze(url)
+	if !ok {
+		return false
+	}
+	if snooze.Until != nil {
+		return now.Before(*snooze.Until)
+	}
+	return !updatedAt.After(snooze.UpdatedAt)
+}
+
+// ToggleMute mutes the item at url, or unmutes it if it's muted. It returns
+// whether the item is muted now.
+func (s *Store) ToggleMute(url string) bool {
+	if s == nil {
+		return false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	defer s.save()
+
+	if s.state.Muted[url] {
+		delete(s.state.Muted, url)
+		return false
+	}
+	s.state.Muted[url] = true
+	return true
+}
+
+// IsMuted reports whether the item at url was muted, or is by one of the
+// muted authors.
+func (s *Store) IsMuted(url string, author string) bool {
+	if s == nil {
+		return false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.state.Muted[url] || slices.ContainsFunc(s.mutedAuthors, func(muted string) bool {
+		return strings.EqualFold(muted, author)
+	})
+}
+
+// IsHidden reports whether the item at url, by author and last updated at
+// updatedAt, is hidden from the sections because it's snoozed or muted.
+func (s *Store) IsHidden(url string, author string, updatedAt time.Time) bool {
+	return s.IsMuted(url, author) || s.IsSnoozed(url, updatedAt, time.Now())
+}
+
+var durationRegex = regexp.MustCompile(`^(\d+)([hdw])$`)
+
+// ParseSnoozeUntil parses when to snooze an item until: a duration from now
+// in hours, days or weeks like 4h, 3d or 2w, or a date like 2024-12-31. It
+// returns nil for an empty input, which snoozes the item until it's updated.
+func ParseSnoozeUntil(input string, now time.Time) (*time.Time, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return nil, nil
+	}
+
+	if match := durationRegex.FindStringSubmatch(input); match != nil {
+		n, _ := strconv.Atoi(match[1])
+		var until time.Time
+		switch match[2] {
+		case "h":
+			until = now.Add(time.Duration(n) * time.Hour)
+		case "d":
+			until = now.AddDate(0, 0, n)
+		case "w":
+			until = now.AddDate(0, 0, 7*n)
+		}
+		return &until, nil
+	}
+
+	date, err := time.ParseInLocation(time.DateOnly, input, now.Location())
+	if err != nil {
+		return nil, fmt.Errorf("%q isn't a duration like 3d or a date like 2024-12-31", input)
+	}
+	if !date.After(now) {
+		return nil, fmt.Errorf("%s has passed", input)
+	}
+	return &date, nil
+}
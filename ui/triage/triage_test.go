@@ -0,0 +1,105 @@
+package triage_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dlvhdr/gh-dash/v4/ui/triage"
+)
+
+const (
+	prUrl    = "https://github.com/dlvhdr/gh-dash/pull/1"
+	issueUrl = "https://github.com/dlvhdr/gh-dash/issues/2"
+)
+
+func TestStore(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	updatedAt := time.Now().Add(-time.Hour)
+
+	s := triage.NewStore()
+	require.True(t, s.TogglePin(prUrl, "PR_1"))
+	require.True(t, s.TogglePin(issueUrl, "I_2"))
+	s.Snooze(prUrl, nil, updatedAt)
+	require.True(t, s.ToggleMute(issueUrl))
+	s.SetMutedAuthors([]string{"dependabot[bot]"})
+
+	s = triage.NewStore()
+	s.SetMutedAuthors([]string{"dependabot[bot]"})
+	require.True(t, s.IsPinned(prUrl), "the state is saved")
+	require.Equal(t, []string{"PR_1"}, s.PinnedIds(true))
+	require.Equal(t, []string{"I_2"}, s.PinnedIds(false))
+
+	require.True(t, s.IsHidden(prUrl, "octocat", updatedAt))
+	require.False(t, s.IsHidden(prUrl, "octocat", time.Now()), "snoozes end when the item is updated")
+	require.True(t, s.IsHidden(issueUrl, "octocat", updatedAt))
+	require.True(t, s.IsHidden("https://github.com/dlvhdr/gh-dash/pull/3", "Dependabot[bot]", updatedAt))
+
+	require.True(t, s.Unsnooze(prUrl))
+	require.False(t, s.ToggleMute(issueUrl))
+	require.False(t, s.TogglePin(prUrl, "PR_1"))
+	require.False(t, s.HasPinned(true))
+	require.False(t, s.IsHidden(issueUrl, "octocat", updatedAt))
+
+	var nilStore *triage.Store
+	require.False(t, nilStore.IsHidden(prUrl, "octocat", updatedAt))
+	require.False(t, nilStore.TogglePin(prUrl, "PR_1"))
+	nilStore.Snooze(prUrl, nil, updatedAt)
+	require.False(t, nilStore.Unsnooze(prUrl))
+	require.False(t, nilStore.ToggleMute(prUrl))
+}
+
+func TestParseSnoozeUntil(t *testing.T) {
+	now := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+	testCases := map[string]struct {
+		input   string
+		want    *time.Time
+		wantErr bool
+	}{
+		"until updated": {
+			input: " ",
+			want:  nil,
+		},
+		"hours": {
+			input: "4h",
+			want:  ptr(now.Add(4 * time.Hour)),
+		},
+		"days": {
+			input: "3d",
+			want:  ptr(now.AddDate(0, 0, 3)),
+		},
+		"weeks": {
+			input: "2w",
+			want:  ptr(now.AddDate(0, 0, 14)),
+		},
+		"date": {
+			input: "2024-06-01",
+			want:  ptr(time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)),
+		},
+		"past date": {
+			input:   "2024-05-01",
+			wantErr: true,
+		},
+		"invalid": {
+			input:   "soon",
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			until, err := triage.ParseSnoozeUntil(tc.input, now)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.want, until)
+		})
+	}
+}
+
+func ptr(t time.Time) *time.Time {
+	return &t
+}
@@ -21,19 +21,28 @@ import (
 	"github.com/dlvhdr/gh-dash/v4/ui/common"
 	"github.com/dlvhdr/gh-dash/v4/ui/components/branch"
 	"github.com/dlvhdr/gh-dash/v4/ui/components/branchsidebar"
+	"github.com/dlvhdr/gh-dash/v4/ui/components/diffview"
 	"github.com/dlvhdr/gh-dash/v4/ui/components/footer"
+	"github.com/dlvhdr/gh-dash/v4/ui/components/issuecreator"
 	"github.com/dlvhdr/gh-dash/v4/ui/components/issuesidebar"
 	"github.com/dlvhdr/gh-dash/v4/ui/components/issuessection"
+	"github.com/dlvhdr/gh-dash/v4/ui/components/palette"
+	"github.com/dlvhdr/gh-dash/v4/ui/components/prompt"
 	"github.com/dlvhdr/gh-dash/v4/ui/components/prsidebar"
 	"github.com/dlvhdr/gh-dash/v4/ui/components/prssection"
 	"github.com/dlvhdr/gh-dash/v4/ui/components/reposection"
 	"github.com/dlvhdr/gh-dash/v4/ui/components/section"
 	"github.com/dlvhdr/gh-dash/v4/ui/components/sidebar"
 	"github.com/dlvhdr/gh-dash/v4/ui/components/tabs"
+	"github.com/dlvhdr/gh-dash/v4/ui/components/taskhistory"
+	"github.com/dlvhdr/gh-dash/v4/ui/components/tasks"
 	"github.com/dlvhdr/gh-dash/v4/ui/constants"
 	"github.com/dlvhdr/gh-dash/v4/ui/context"
+	"github.com/dlvhdr/gh-dash/v4/ui/jobs"
 	"github.com/dlvhdr/gh-dash/v4/ui/keys"
+	"github.com/dlvhdr/gh-dash/v4/ui/notes"
 	"github.com/dlvhdr/gh-dash/v4/ui/theme"
+	"github.com/dlvhdr/gh-dash/v4/ui/triage"
 )
 
 type Model struct {
@@ -42,6 +51,7 @@ type Model struct {
 	prSidebar     prsidebar.Model
 	issueSidebar  issuesidebar.Model
 	branchSidebar branchsidebar.Model
+	issueCreator  issuecreator.Model
 	currSectionId int
 	footer        footer.Model
 	repo          section.Section
@@ -50,25 +60,50 @@ type Model struct {
 	tabs          tabs.Model
 	ctx           context.ProgramContext
 	taskSpinner   spinner.Model
-	tasks         map[string]context.Task
+	tasks         *taskhistory.Log
+	taskHistory   taskhistory.Model
+	palette       palette.Model
+	diffView      diffview.Model
+	// contentHeight is the height of the screen between the tabs and the
+	// footer, which the sections share with the preview pane.
+	contentHeight   int
+	previewSize     previewSize
+	isPreviewZoomed bool
+	// initialItemRef is the PR or issue to go to once the dashboard loads.
+	initialItemRef   *string
+	footerPrompt     prompt.Model
+	footerPromptKind footerPromptKind
+	// promptItem is the item the footer prompt was opened on.
+	promptItem *rowItem
+	// keySequence holds the keys of a sequence like "g p" until it completes
+	// a binding, and isDispatchingKey is set while the completed binding and
+	// the keyCount typed before it are handled.
+	keySequence      keys.Sequence
+	isDispatchingKey bool
+	keyCount         int
 }
 
-func NewModel(repoPath *string, configPath string) Model {
+func NewModel(repoPath *string, configPath string, itemRef *string) Model {
 	taskSpinner := spinner.Model{Spinner: spinner.Dot}
 	m := Model{
-		keys:        keys.Keys,
-		sidebar:     sidebar.NewModel(),
-		taskSpinner: taskSpinner,
-		tasks:       map[string]context.Task{},
+		keys:           keys.Keys,
+		sidebar:        sidebar.NewModel(),
+		taskSpinner:    taskSpinner,
+		tasks:          taskhistory.NewLog(),
+		initialItemRef: itemRef,
 	}
+	m.keys.SwitchToRepo.SetEnabled(config.IsFeatureEnabled(config.FF_REPO_VIEW) && repoPath != nil)
 
 	m.ctx = context.ProgramContext{
 		RepoPath:   repoPath,
 		ConfigPath: configPath,
+		Jobs:       jobs.NewManager(),
+		Triage:     triage.NewStore(),
+		Notes:      notes.NewStore(),
 		StartTask: func(task context.Task) tea.Cmd {
 			log.Debug("Starting task", "id", task.Id)
 			task.StartTime = time.Now()
-			m.tasks[task.Id] = task
+			m.tasks.Start(task)
 			rTask := m.renderRunningTask()
 			m.footer.SetRightSection(rTask)
 			return m.taskSpinner.Tick
@@ -83,7 +118,12 @@ func NewModel(repoPath *string, configPath string) Model {
 	m.prSidebar = prsidebar.NewModel(m.ctx)
 	m.issueSidebar = issuesidebar.NewModel(m.ctx)
 	m.branchSidebar = branchsidebar.NewModel(m.ctx)
+	m.issueCreator = issuecreator.NewModel(&m.ctx)
+	m.taskHistory = taskhistory.NewModel(&m.ctx, m.tasks)
+	m.palette = palette.NewModel(&m.ctx)
+	m.diffView = diffview.NewModel(&m.ctx)
 	m.tabs = tabs.NewModel(&m.ctx)
+	m.footerPrompt = prompt.NewModel(&m.ctx)
 
 	return m
 }
@@ -163,54 +203,180 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		log.Debug("Key pressed", "key", msg.String())
 		m.ctx.Error = nil
 
+		if m.diffView.IsOpen() {
+			m.diffView, cmd = m.diffView.Update(msg)
+			return m, cmd
+		}
+
+		if m.isFooterPromptOpen() {
+			cmd = m.updateFooterPrompt(msg)
+			m.footer.SetLeftSection(m.footerPrompt.View())
+			return m, cmd
+		}
+
 		if currSection != nil && (currSection.IsSearchFocused() || currSection.IsPromptConfirmationFocused()) {
 			cmd = m.updateSection(currSection.GetId(), currSection.GetType(), msg)
 			return m, cmd
 		}
 
+		if currSection != nil && currSection.IsFilterFocused() {
+			cmd = m.updateSection(currSection.GetId(), currSection.GetType(), msg)
+			return m, tea.Batch(cmd, m.onViewedRowChanged())
+		}
+
 		if m.prSidebar.IsTextInputBoxFocused() {
 			m.prSidebar, cmd = m.prSidebar.Update(msg)
-			m.syncSidebar()
+			cmd = tea.Batch(cmd, m.syncSidebar())
 			return m, cmd
 		}
 
 		if m.issueSidebar.IsTextInputBoxFocused() {
 			m.issueSidebar, cmd = m.issueSidebar.Update(msg)
-			m.syncSidebar()
+			cmd = tea.Batch(cmd, m.syncSidebar())
+			return m, cmd
+		}
+
+		if m.issueCreator.IsOpen() {
+			m.issueCreator, cmd = m.issueCreator.Update(msg)
+			cmd = tea.Batch(cmd, m.syncSidebar())
 			return m, cmd
 		}
 
+		if m.palette.IsOpen() {
+			m.palette, cmd = m.palette.Update(msg)
+			cmd = tea.Batch(cmd, m.syncSidebar())
+			return m, cmd
+		}
+
+		if m.taskHistory.IsOpen() {
+			if key.Matches(msg, m.keys.TaskHistory) {
+				m.taskHistory.Close()
+			} else {
+				m.taskHistory, cmd = m.taskHistory.Update(msg)
+			}
+			cmd = tea.Batch(cmd, m.syncSidebar())
+			return m, cmd
+		}
+
+		if !m.isDispatchingKey {
+			return m.pressKey(msg)
+		}
+
 		switch {
 		case m.isUserDefinedKeybinding(msg):
 			cmd = m.executeKeybinding(msg.String())
 			return m, cmd
 
+		case currSection != nil && currSection.NumSelected() > 0 && m.getBulkAction(msg) != "":
+			currSection.SetPromptConfirmationAction(m.getBulkAction(msg))
+			cmd = currSection.SetIsPromptConfirmationShown(true)
+			return m, cmd
+
+		case currSection != nil && currSection.NumSelected() > 0 && msg.Type == tea.KeyEsc:
+			currSection.ClearSelection()
+
+		case currSection != nil && currSection.IsFiltered() && msg.Type == tea.KeyEsc:
+			currSection.ResetFilter()
+			cmd = m.onViewedRowChanged()
+
+		case key.Matches(msg, m.keys.ToggleSelection):
+			cmd = m.toggleSelection(currSection)
+
+		case key.Matches(msg, m.keys.SelectAll):
+			if currSection != nil {
+				currSection.ToggleSelectAll(currSection.GetRows())
+			}
+
+		case key.Matches(msg, m.keys.VisualSelect):
+			if currSection != nil && currSection.IsVisualSelecting() {
+				currSection.StopVisualSelection()
+			} else if currSection != nil {
+				currSection.StartVisualSelection(currSection.GetRows(), currSection.CurrRow())
+			}
+
+		case key.Matches(msg, m.keys.Pin) && m.ctx.View != config.RepoView:
+			cmd = m.togglePin()
+
+		case key.Matches(msg, m.keys.Snooze) && m.ctx.View != config.RepoView:
+			cmd = m.toggleSnooze()
+			if m.isFooterPromptOpen() {
+				return m, cmd
+			}
+
+		case key.Matches(msg, m.keys.Mute) && m.ctx.View != config.RepoView:
+			cmd = m.toggleMute()
+
+		case key.Matches(msg, m.keys.Note) && m.ctx.View != config.RepoView:
+			cmd = m.openNotePrompt()
+			if m.isFooterPromptOpen() {
+				return m, cmd
+			}
+
+		case key.Matches(msg, m.keys.Tags) && m.ctx.View != config.RepoView:
+			cmd = m.openTagsPrompt()
+			if m.isFooterPromptOpen() {
+				return m, cmd
+			}
+
+		case key.Matches(msg, m.keys.GoTo):
+			cmd = m.openFooterPrompt(goToPrompt)
+			m.footer.SetLeftSection(m.footerPrompt.View())
+			return m, cmd
+
+		case key.Matches(msg, m.keys.CommandPalette):
+			cmd = m.openPalette()
+			return m, cmd
+
+		case key.Matches(msg, m.keys.TaskHistory):
+			m.sidebar.IsOpen = true
+			m.taskHistory.Open()
+			m.syncMainContentSize()
+			cmd = m.syncSidebar()
+			m.sidebar.ScrollToTop()
+			return m, cmd
+
 		case key.Matches(msg, m.keys.PrevSection):
-			prevSection := m.getSectionAt(m.getPrevSectionId())
-			if prevSection != nil {
-				m.setCurrSectionId(prevSection.GetId())
-				m.onViewedRowChanged()
+			for i := 0; i < m.repeatCount(); i++ {
+				prevSection := m.getSectionAt(m.getPrevSectionId())
+				if prevSection != nil {
+					m.setCurrSectionId(prevSection.GetId())
+				}
 			}
+			cmd = m.onViewedRowChanged()
 
 		case key.Matches(msg, m.keys.NextSection):
-			nextSectionId := m.getNextSectionId()
-			nextSection := m.getSectionAt(nextSectionId)
-			if nextSection != nil {
-				m.setCurrSectionId(nextSection.GetId())
-				m.onViewedRowChanged()
+			for i := 0; i < m.repeatCount(); i++ {
+				nextSection := m.getSectionAt(m.getNextSectionId())
+				if nextSection != nil {
+					m.setCurrSectionId(nextSection.GetId())
+				}
 			}
+			cmd = m.onViewedRowChanged()
 
 		case key.Matches(msg, m.keys.Down):
 			prevRow := currSection.CurrRow()
-			nextRow := currSection.NextRow()
+			nextRow := prevRow
+			for i := 0; i < m.repeatCount(); i++ {
+				nextRow = currSection.NextRow()
+			}
 			if prevRow != nextRow && nextRow == currSection.NumRows()-1 && m.ctx.View != config.RepoView {
 				cmds = append(cmds, currSection.FetchNextPageSectionRows()...)
 			}
 			cmd = m.onViewedRowChanged()
 
 		case key.Matches(msg, m.keys.Up):
-			currSection.PrevRow()
-			m.onViewedRowChanged()
+			for i := 0; i < m.repeatCount(); i++ {
+				currSection.PrevRow()
+			}
+			cmd = m.onViewedRowChanged()
+
+		case (key.Matches(msg, m.keys.FirstLine) || key.Matches(msg, m.keys.LastLine)) && m.keyCount > 0:
+			// A count goes to that row, like in vim.
+			currSection.FirstItem()
+			for i := 1; i < m.keyCount; i++ {
+				currSection.NextRow()
+			}
+			cmd = m.onViewedRowChanged()
 
 		case key.Matches(msg, m.keys.FirstLine):
 			currSection.FirstItem()
@@ -223,9 +389,50 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			currSection.LastItem()
 			cmd = m.onViewedRowChanged()
 
+		case key.Matches(msg, m.keys.SwitchToPrs) && m.ctx.View != config.PRsView:
+			cmd = m.switchView(config.PRsView)
+
+		case key.Matches(msg, m.keys.SwitchToIssues) && m.ctx.View != config.IssuesView:
+			cmd = m.switchView(config.IssuesView)
+
+		case key.Matches(msg, m.keys.SwitchToRepo) && m.ctx.View != config.RepoView:
+			cmd = m.switchView(config.RepoView)
+
 		case key.Matches(msg, m.keys.TogglePreview):
 			m.sidebar.IsOpen = !m.sidebar.IsOpen
-			m.syncMainContentWidth()
+			m.isPreviewZoomed = false
+			m.syncMainContentSize()
+			cmd = m.syncSidebar()
+
+		case key.Matches(msg, m.keys.GrowPreview):
+			m.resizePreview(1)
+			cmd = m.syncSidebar()
+
+		case key.Matches(msg, m.keys.ShrinkPreview):
+			m.resizePreview(-1)
+			cmd = m.syncSidebar()
+
+		case key.Matches(msg, m.keys.ZoomPreview):
+			m.toggleZoomPreview()
+			cmd = m.syncSidebar()
+
+		case key.Matches(msg, m.keys.ActivityFilter):
+			switch m.ctx.View {
+			case config.PRsView:
+				m.prSidebar.CycleActivityFilter()
+			case config.IssuesView:
+				m.issueSidebar.CycleActivityFilter()
+			}
+			cmd = m.syncSidebar()
+
+		case key.Matches(msg, m.keys.ToggleBots):
+			switch m.ctx.View {
+			case config.PRsView:
+				m.prSidebar.ToggleBotActivity()
+			case config.IssuesView:
+				m.issueSidebar.ToggleBotActivity()
+			}
+			cmd = m.syncSidebar()
 
 		case key.Matches(msg, m.keys.Refresh):
 			currSection.ResetFilters()
@@ -243,12 +450,26 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, cmd
 			}
 
+		case key.Matches(msg, m.keys.Filter):
+			if currSection != nil && m.ctx.View != config.RepoView {
+				cmd = currSection.SetIsFiltering(true)
+				return m, cmd
+			}
+
+		case key.Matches(msg, m.keys.SaveSection):
+			if currSection != nil && m.ctx.View != config.RepoView {
+				currSection.SetPromptConfirmationAction("save_section")
+				cmd = currSection.SetIsPromptConfirmationShown(true)
+				return m, cmd
+			}
+
 		case key.Matches(msg, m.keys.Help):
 			if !m.footer.ShowAll {
-				m.ctx.MainContentHeight = m.ctx.MainContentHeight + common.FooterHeight - common.ExpandedHelpHeight
+				m.contentHeight = m.contentHeight + common.FooterHeight - common.ExpandedHelpHeight
 			} else {
-				m.ctx.MainContentHeight = m.ctx.MainContentHeight + common.ExpandedHelpHeight - common.FooterHeight
+				m.contentHeight = m.contentHeight + common.ExpandedHelpHeight - common.FooterHeight
 			}
+			m.syncMainContentSize()
 
 		case key.Matches(msg, m.keys.CopyNumber):
 			row := m.getCurrRowData()
@@ -283,11 +504,11 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, cmd
 
 		case key.Matches(msg, m.keys.Quit):
-			if m.ctx.Config.ConfirmQuit {
+			if m.ctx.Config.ConfirmQuit && !m.footer.ShowConfirmQuit {
 				m.footer, cmd = m.footer.Update(msg)
 				return m, cmd
 			}
-			cmd = tea.Quit
+			return m, m.quit()
 
 		case m.ctx.View == config.RepoView:
 			switch {
@@ -317,18 +538,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, cmd
 
 			case key.Matches(msg, keys.BranchKeys.ViewPRs):
-				m.ctx.View = m.switchSelectedView()
-				m.syncMainContentWidth()
-				m.setCurrSectionId(m.getCurrentViewDefaultSection())
-				m.tabs.UpdateSectionsConfigs(&m.ctx)
-
-				currSections := m.getCurrentViewSections()
-				if len(currSections) == 0 {
-					newSections, fetchSectionsCmds := m.fetchAllViewSections()
-					m.setCurrentViewSections(newSections)
-					cmd = fetchSectionsCmds
-				}
-				m.onViewedRowChanged()
+				cmd = m.switchView(m.switchSelectedView())
 
 			}
 		case m.ctx.View == config.PRsView:
@@ -339,32 +549,32 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			case key.Matches(msg, keys.PRKeys.Approve):
 				m.sidebar.IsOpen = true
 				cmd = m.prSidebar.SetIsApproving(true)
-				m.syncMainContentWidth()
-				m.syncSidebar()
+				m.syncMainContentSize()
+				cmd = tea.Batch(cmd, m.syncSidebar())
 				m.sidebar.ScrollToBottom()
 				return m, cmd
 
 			case key.Matches(msg, keys.PRKeys.Assign):
 				m.sidebar.IsOpen = true
 				cmd = m.prSidebar.SetIsAssigning(true)
-				m.syncMainContentWidth()
-				m.syncSidebar()
+				m.syncMainContentSize()
+				cmd = tea.Batch(cmd, m.syncSidebar())
 				m.sidebar.ScrollToBottom()
 				return m, cmd
 
 			case key.Matches(msg, keys.PRKeys.Unassign):
 				m.sidebar.IsOpen = true
 				cmd = m.prSidebar.SetIsUnassigning(true)
-				m.syncMainContentWidth()
-				m.syncSidebar()
+				m.syncMainContentSize()
+				cmd = tea.Batch(cmd, m.syncSidebar())
 				m.sidebar.ScrollToBottom()
 				return m, cmd
 
 			case key.Matches(msg, keys.PRKeys.Comment):
 				m.sidebar.IsOpen = true
 				cmd = m.prSidebar.SetIsCommenting(true)
-				m.syncMainContentWidth()
-				m.syncSidebar()
+				m.syncMainContentSize()
+				cmd = tea.Batch(cmd, m.syncSidebar())
 				m.sidebar.ScrollToBottom()
 				return m, cmd
 
@@ -376,10 +586,15 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, cmd
 
 			case key.Matches(msg, keys.PRKeys.Ready):
-				if currSection != nil {
+				if currSection == nil || currSection.GetCurrRow() == nil {
+					return m, nil
+				}
+				if pr, ok := currSection.GetCurrRow().(*data.PullRequestData); ok && !pr.IsDraft {
+					currSection.SetPromptConfirmationAction("draft")
+				} else {
 					currSection.SetPromptConfirmationAction("ready")
-					cmd = currSection.SetIsPromptConfirmationShown(true)
 				}
+				cmd = currSection.SetIsPromptConfirmationShown(true)
 				return m, cmd
 
 			case key.Matches(msg, keys.PRKeys.Reopen):
@@ -389,11 +604,58 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 				return m, cmd
 
+			case key.Matches(msg, keys.PRKeys.RequestReview):
+				if currSection == nil || currSection.GetCurrRow() == nil {
+					return m, nil
+				}
+				m.sidebar.IsOpen = true
+				cmd = m.prSidebar.SetIsRequestingReview(true)
+				m.syncMainContentSize()
+				cmd = tea.Batch(cmd, m.syncSidebar())
+				m.sidebar.ScrollToBottom()
+				return m, cmd
+
+			case key.Matches(msg, keys.PRKeys.TaskList):
+				if currSection == nil || currSection.GetCurrRow() == nil {
+					return m, nil
+				}
+				m.sidebar.IsOpen = true
+				cmd = m.prSidebar.SetIsEditingTasks(true)
+				m.syncMainContentSize()
+				cmd = tea.Batch(cmd, m.syncSidebar())
+				return m, cmd
+
+			case key.Matches(msg, keys.PRKeys.Labels):
+				if currSection == nil || currSection.GetCurrRow() == nil {
+					return m, nil
+				}
+				m.sidebar.IsOpen = true
+				cmd = m.prSidebar.SetIsLabeling(true)
+				m.syncMainContentSize()
+				cmd = tea.Batch(cmd, m.syncSidebar())
+				m.sidebar.ScrollToBottom()
+				return m, cmd
+
+			case key.Matches(msg, keys.PRKeys.ViewedFiles):
+				if currSection == nil || currSection.GetCurrRow() == nil {
+					return m, nil
+				}
+				m.sidebar.IsOpen = true
+				cmd = m.prSidebar.SetIsViewingFiles(true)
+				m.syncMainContentSize()
+				cmd = tea.Batch(cmd, m.syncSidebar())
+				m.sidebar.ScrollToBottom()
+				return m, cmd
+
 			case key.Matches(msg, keys.PRKeys.Merge):
-				if currSection != nil {
-					currSection.SetPromptConfirmationAction("merge")
-					cmd = currSection.SetIsPromptConfirmationShown(true)
+				if currSection == nil || currSection.GetCurrRow() == nil {
+					return m, nil
 				}
+				m.sidebar.IsOpen = true
+				cmd = m.prSidebar.SetIsMerging(true)
+				m.syncMainContentSize()
+				cmd = tea.Batch(cmd, m.syncSidebar())
+				m.sidebar.ScrollToBottom()
 				return m, cmd
 
 			case key.Matches(msg, keys.PRKeys.Update):
@@ -404,18 +666,16 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, cmd
 
 			case key.Matches(msg, keys.PRKeys.ViewIssues):
-				m.ctx.View = m.switchSelectedView()
-				m.syncMainContentWidth()
-				m.setCurrSectionId(m.getCurrentViewDefaultSection())
-				m.tabs.UpdateSectionsConfigs(&m.ctx)
-
-				currSections := m.getCurrentViewSections()
-				if len(currSections) == 0 {
-					newSections, fetchSectionsCmds := m.fetchAllViewSections()
-					m.setCurrentViewSections(newSections)
-					cmd = fetchSectionsCmds
-				}
-				m.onViewedRowChanged()
+				cmd = m.switchView(m.switchSelectedView())
+
+			case key.Matches(msg, keys.PRKeys.JumpToLinked):
+				cmd = m.jumpToLinkedItem()
+
+			case key.Matches(msg, keys.PRKeys.NextTab):
+				cmd = m.switchPrSidebarTab(1)
+
+			case key.Matches(msg, keys.PRKeys.PrevTab):
+				cmd = m.switchPrSidebarTab(-1)
 			}
 		case m.ctx.View == config.IssuesView:
 			switch {
@@ -425,24 +685,53 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			case key.Matches(msg, keys.IssueKeys.Assign):
 				m.sidebar.IsOpen = true
 				cmd = m.issueSidebar.SetIsAssigning(true)
-				m.syncMainContentWidth()
-				m.syncSidebar()
+				m.syncMainContentSize()
+				cmd = tea.Batch(cmd, m.syncSidebar())
 				m.sidebar.ScrollToBottom()
 				return m, cmd
 
 			case key.Matches(msg, keys.IssueKeys.Unassign):
 				m.sidebar.IsOpen = true
 				cmd = m.issueSidebar.SetIsUnassigning(true)
-				m.syncMainContentWidth()
-				m.syncSidebar()
+				m.syncMainContentSize()
+				cmd = tea.Batch(cmd, m.syncSidebar())
 				m.sidebar.ScrollToBottom()
 				return m, cmd
 
 			case key.Matches(msg, keys.IssueKeys.Comment):
 				m.sidebar.IsOpen = true
 				cmd = m.issueSidebar.SetIsCommenting(true)
-				m.syncMainContentWidth()
-				m.syncSidebar()
+				m.syncMainContentSize()
+				cmd = tea.Batch(cmd, m.syncSidebar())
+				m.sidebar.ScrollToBottom()
+				return m, cmd
+
+			case key.Matches(msg, keys.IssueKeys.New):
+				m.sidebar.IsOpen = true
+				cmd = m.issueCreator.Open(m.getDefaultNewIssueRepo(), m.getRepoSuggestions())
+				m.syncMainContentSize()
+				cmd = tea.Batch(cmd, m.syncSidebar())
+				m.sidebar.ScrollToTop()
+				return m, cmd
+
+			case key.Matches(msg, keys.IssueKeys.TaskList):
+				if currSection == nil || currSection.GetCurrRow() == nil {
+					return m, nil
+				}
+				m.sidebar.IsOpen = true
+				cmd = m.issueSidebar.SetIsEditingTasks(true)
+				m.syncMainContentSize()
+				cmd = tea.Batch(cmd, m.syncSidebar())
+				return m, cmd
+
+			case key.Matches(msg, keys.IssueKeys.Labels):
+				if currSection == nil || currSection.GetCurrRow() == nil {
+					return m, nil
+				}
+				m.sidebar.IsOpen = true
+				cmd = m.issueSidebar.SetIsLabeling(true)
+				m.syncMainContentSize()
+				cmd = tea.Batch(cmd, m.syncSidebar())
 				m.sidebar.ScrollToBottom()
 				return m, cmd
 
@@ -461,35 +750,36 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, cmd
 
 			case key.Matches(msg, keys.IssueKeys.ViewPRs):
-				m.ctx.View = m.switchSelectedView()
-				m.syncMainContentWidth()
-				m.setCurrSectionId(m.getCurrentViewDefaultSection())
-				m.tabs.UpdateSectionsConfigs(&m.ctx)
-
-				currSections := m.getCurrentViewSections()
-				if len(currSections) == 0 {
-					newSections, fetchSectionsCmds := m.fetchAllViewSections()
-					m.setCurrentViewSections(newSections)
-					cmd = fetchSectionsCmds
-				}
-				m.onViewedRowChanged()
+				cmd = m.switchView(m.switchSelectedView())
+
+			case key.Matches(msg, keys.IssueKeys.JumpToLinked):
+				cmd = m.jumpToLinkedItem()
 			}
 
 		}
 
+		if currSection != nil && currSection.IsVisualSelecting() {
+			currSection.UpdateVisualSelection(currSection.GetRows(), currSection.CurrRow())
+		}
+
 	case initMsg:
 		m.ctx.Config = &msg.Config
 		m.ctx.RepoUrl = msg.RepoUrl
 		m.ctx.Theme = theme.ParseTheme(m.ctx.Config)
 		m.ctx.Styles = context.InitStyles(m.ctx.Theme)
 		m.ctx.View = m.ctx.Config.Defaults.View
+		m.ctx.Triage.SetMutedAuthors(m.ctx.Config.Defaults.MutedAuthors)
 		m.currSectionId = m.getCurrentViewDefaultSection()
 		m.sidebar.IsOpen = msg.Config.Defaults.Preview.Open
+		m.previewSize = loadPreviewSize(msg.Config.Defaults.Preview)
 		m.tabs.UpdateSectionsConfigs(&m.ctx)
-		m.syncMainContentWidth()
+		m.syncMainContentSize()
 		newSections, fetchSectionsCmds := m.fetchAllViewSections()
 		m.setCurrentViewSections(newSections)
 		cmds = append(cmds, fetchSectionsCmds, fetchUser, m.doRefreshAtInterval())
+		if m.initialItemRef != nil {
+			cmds = append(cmds, m.goTo(*m.initialItemRef))
+		}
 
 	case intervalRefresh:
 		newSections, fetchSectionsCmds := m.fetchAllViewSections()
@@ -499,50 +789,74 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case userFetchedMsg:
 		m.ctx.User = msg.user
 
+	case diffview.OpenMsg:
+		cmd = m.diffView.Open(msg.Pr)
+
+	case constants.TaskProgressMsg:
+		if m.tasks.SetStartText(msg.TaskId, msg.StartText) {
+			m.footer.SetRightSection(m.renderRunningTask())
+			cmds = append(cmds, m.updateSection(msg.SectionId, msg.SectionType, msg.Msg), msg.Next)
+			cmds = append(cmds, m.syncSidebar())
+		}
+
 	case constants.TaskFinishedMsg:
-		task, ok := m.tasks[msg.TaskId]
+		task, ok := m.tasks.Finish(msg)
 		if ok {
 			log.Debug("Task finished", "id", task.Id)
 			if msg.Err != nil {
-				log.Error("Task finished with error", "id", task.Id, "err", msg.Err)
-				task.State = context.TaskError
-				task.Error = msg.Err
-			} else {
-				task.State = context.TaskFinished
+				log.Error("Task finished with error", "id", task.Id, "err", msg.Err, "stderr", msg.Stderr)
 			}
-			now := time.Now()
-			task.FinishedTime = &now
-			m.tasks[msg.TaskId] = task
 			clear := tea.Tick(2*time.Second, func(t time.Time) tea.Msg {
 				return constants.ClearTaskMsg{TaskId: msg.TaskId}
 			})
 			cmds = append(cmds, clear)
 
-			scmd := m.updateSection(msg.SectionId, msg.SectionType, msg.Msg)
-			cmds = append(cmds, scmd)
+			if updateMsg, ok := msg.Msg.(tasks.UpdatePRMsg); ok && updateMsg.IsDraft != nil {
+				m.movePrBetweenDraftSections(updateMsg)
+			} else {
+				scmd := m.updateSection(msg.SectionId, msg.SectionType, msg.Msg)
+				cmds = append(cmds, scmd)
+			}
+
+			if _, ok := msg.Msg.(tasks.IssueCreatedMsg); ok && msg.Err == nil {
+				cmds = append(cmds, m.refreshIssueSections()...)
+			}
+
+			if savedMsg, ok := msg.Msg.(tasks.SectionSavedMsg); ok && msg.Err == nil {
+				cmds = append(cmds, m.addSavedSection(savedMsg))
+			}
 
 			syncCmd := m.syncSidebar()
 			cmds = append(cmds, syncCmd)
 		}
 
 	case spinner.TickMsg:
-		if len(m.tasks) > 0 {
+		if m.tasks.NumRunning() > 0 {
 			taskSpinner, internalTickCmd := m.taskSpinner.Update(msg)
 			m.taskSpinner = taskSpinner
 			rTask := m.renderRunningTask()
 			m.footer.SetRightSection(rTask)
 			cmd = internalTickCmd
+			if m.taskHistory.IsOpen() {
+				cmds = append(cmds, m.syncSidebar())
+			}
 		}
 
+	case keySequenceTimeoutMsg:
+		return m.dispatchKeyPresses(m.keySequence.Timeout(msg.id, m.sequenceBindings()))
+
+	case palette.ActionChosenMsg:
+		return m.runPaletteAction(msg.Action)
+
 	case constants.ClearTaskMsg:
-		m.footer.SetRightSection("")
-		delete(m.tasks, msg.TaskId)
+		m.tasks.Clear(msg.TaskId)
+		m.footer.SetRightSection(m.renderRunningTask())
 
 	case section.SectionMsg:
 		cmd = m.updateRelevantSection(msg)
 
 		if msg.Id == m.currSectionId {
-			m.onViewedRowChanged()
+			cmds = append(cmds, m.onViewedRowChanged())
 		}
 
 	case execProcessFinishedMsg, tea.FocusMsg:
@@ -550,6 +864,20 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			cmds = append(cmds, currSection.FetchNextPageSectionRows()...)
 		}
 
+	case tea.MouseMsg:
+		cmd = m.updateMouse(msg)
+
+	case prsidebar.DetailsFetchedMsg:
+		m.prSidebar, cmd = m.prSidebar.Update(msg)
+		cmds = append(cmds, m.syncSidebar())
+
+	case itemFetchedMsg:
+		cmd = m.onItemFetched(msg)
+
+	case issuesidebar.TimelineFetchedMsg:
+		m.issueSidebar, cmd = m.issueSidebar.Update(msg)
+		cmds = append(cmds, m.syncSidebar())
+
 	case tea.WindowSizeMsg:
 		m.onWindowSizeChanged(msg)
 
@@ -567,12 +895,38 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	if m.prSidebar.IsTextInputBoxFocused() {
 		m.prSidebar, prSidebarCmd = m.prSidebar.Update(msg)
-		m.syncSidebar()
+		cmds = append(cmds, m.syncSidebar())
 	}
 
 	if m.issueSidebar.IsTextInputBoxFocused() {
 		m.issueSidebar, issueSidebarCmd = m.issueSidebar.Update(msg)
-		m.syncSidebar()
+		cmds = append(cmds, m.syncSidebar())
+	}
+
+	if m.issueCreator.IsOpen() {
+		var issueCreatorCmd tea.Cmd
+		m.issueCreator, issueCreatorCmd = m.issueCreator.Update(msg)
+		cmds = append(cmds, issueCreatorCmd)
+		cmds = append(cmds, m.syncSidebar())
+	}
+
+	if m.palette.IsOpen() {
+		var paletteCmd tea.Cmd
+		m.palette, paletteCmd = m.palette.Update(msg)
+		cmds = append(cmds, paletteCmd)
+		cmds = append(cmds, m.syncSidebar())
+	}
+
+	if m.isFooterPromptOpen() {
+		var footerPromptCmd tea.Cmd
+		m.footerPrompt, footerPromptCmd = m.footerPrompt.Update(msg)
+		cmds = append(cmds, footerPromptCmd)
+	}
+
+	if m.diffView.IsOpen() {
+		var diffViewCmd tea.Cmd
+		m.diffView, diffViewCmd = m.diffView.Update(msg)
+		cmds = append(cmds, diffViewCmd)
 	}
 
 	m.footer, footerCmd = m.footer.Update(msg)
@@ -585,6 +939,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.footer.SetLeftSection(currSection.GetPagerContent())
 		}
 	}
+	if m.isFooterPromptOpen() {
+		m.footer.SetLeftSection(m.footerPrompt.View())
+	}
 
 	m.tabs.UpdateSectionCounts(m.getCurrentViewSections())
 
@@ -609,20 +966,21 @@ func (m Model) View() string {
 	}
 
 	s := strings.Builder{}
-	if m.ctx.View != config.RepoView {
-		s.WriteString(m.tabs.View(m.ctx))
-	}
-	s.WriteString("\n")
-	content := "No sections defined"
-	currSection := m.getCurrSection()
-	if currSection != nil {
-		content = lipgloss.JoinHorizontal(
-			lipgloss.Top,
-			m.getCurrSection().View(),
-			m.sidebar.View(),
-		)
+	if m.diffView.IsOpen() {
+		s.WriteString(m.diffView.View())
+	} else {
+		if m.ctx.View != config.RepoView {
+			s.WriteString(m.tabs.View(m.ctx))
+		}
+		s.WriteString("\n")
+		content := "No sections defined"
+		currSection := m.getCurrSection()
+		if currSection != nil {
+			content = m.renderMainContent(currSection.View())
+		}
+		content = m.renderWhichKey(content)
+		s.WriteString(content)
 	}
-	s.WriteString(content)
 	s.WriteString("\n")
 	if m.ctx.Error != nil {
 		s.WriteString(
@@ -663,11 +1021,12 @@ func (m *Model) onWindowSizeChanged(msg tea.WindowSizeMsg) {
 	m.ctx.ScreenWidth = msg.Width
 	m.ctx.ScreenHeight = msg.Height
 	if m.footer.ShowAll {
-		m.ctx.MainContentHeight = msg.Height - common.TabsHeight - common.ExpandedHelpHeight
+		m.contentHeight = msg.Height - common.TabsHeight - common.ExpandedHelpHeight
 	} else {
-		m.ctx.MainContentHeight = msg.Height - common.TabsHeight - common.FooterHeight
+		m.contentHeight = msg.Height - common.TabsHeight - common.FooterHeight
 	}
-	m.syncMainContentWidth()
+	m.diffView.SetSize(msg.Width, msg.Height-common.FooterHeight)
+	m.syncMainContentSize()
 }
 
 func (m *Model) syncProgramContext() {
@@ -677,6 +1036,10 @@ func (m *Model) syncProgramContext() {
 	m.footer.UpdateProgramContext(&m.ctx)
 	m.sidebar.UpdateProgramContext(&m.ctx)
 	m.prSidebar.UpdateProgramContext(&m.ctx)
+	m.issueCreator.UpdateProgramContext(&m.ctx)
+	m.taskHistory.UpdateProgramContext(&m.ctx)
+	m.palette.UpdateProgramContext(&m.ctx)
+	m.diffView.UpdateProgramContext(&m.ctx)
 	m.issueSidebar.UpdateProgramContext(&m.ctx)
 	m.branchSidebar.UpdateProgramContext(&m.ctx)
 }
@@ -698,8 +1061,59 @@ func (m *Model) updateSection(id int, sType string, msg tea.Msg) (cmd tea.Cmd) {
 	return cmd
 }
 
+// quit stops the running jobs, like watching checks, and quits.
+func (m *Model) quit() tea.Cmd {
+	m.ctx.Jobs.CancelAll()
+	return tea.Quit
+}
+
+// getPrFiles returns the files of the PR at prUrl the current section keeps,
+// or nil if they weren't fetched yet.
+func (m *Model) getPrFiles(prUrl string) *data.PullRequestFiles {
+	prs, ok := m.getCurrSection().(*prssection.Model)
+	if !ok {
+		return nil
+	}
+	return prs.GetFiles(prUrl)
+}
+
+// movePrBetweenDraftSections applies a PR's draft change to every PR section.
+// Sections whose draft: filter no longer matches the PR drop it, and sections
+// whose filters only differ by their draft: filter from a section that had the
+// PR pick it up, so the PR moves between them without refetching.
+func (m *Model) movePrBetweenDraftSections(msg tasks.UpdatePRMsg) {
+	var pr *data.PullRequestData
+	filters := map[string]bool{}
+	for _, s := range m.prs {
+		prs, ok := s.(*prssection.Model)
+		if !ok {
+			continue
+		}
+		if curr := prs.GetPr(msg.PrUrl); curr != nil {
+			pr = curr
+			filters[prssection.WithoutDraftFilter(prs.GetFilters())] = true
+		}
+	}
+	if pr == nil {
+		return
+	}
+	pr.IsDraft = *msg.IsDraft
+
+	for i, s := range m.prs {
+		prs, ok := s.(*prssection.Model)
+		if !ok {
+			continue
+		}
+		if prs.GetPr(msg.PrUrl) != nil {
+			m.prs[i], _ = s.Update(msg)
+		} else if filters[prssection.WithoutDraftFilter(prs.GetFilters())] {
+			prs.AddPr(*pr)
+		}
+	}
+}
+
 func (m *Model) updateRelevantSection(msg section.SectionMsg) (cmd tea.Cmd) {
-	return m.updateSection(msg.Id, msg.Type, msg)
+	return m.updateSection(msg.Id, msg.Type, msg.InternalMsg)
 }
 
 func (m *Model) updateCurrentSection(msg tea.Msg) (cmd tea.Cmd) {
@@ -710,19 +1124,29 @@ func (m *Model) updateCurrentSection(msg tea.Msg) (cmd tea.Cmd) {
 	return m.updateSection(section.GetId(), section.GetType(), msg)
 }
 
-func (m *Model) syncMainContentWidth() {
-	sideBarOffset := 0
-	if m.sidebar.IsOpen {
-		sideBarOffset = m.ctx.Config.Defaults.Preview.Width
-	}
-	m.ctx.MainContentWidth = m.ctx.ScreenWidth - sideBarOffset
-}
-
 func (m *Model) syncSidebar() tea.Cmd {
 	currRowData := m.getCurrRowData()
 	width := m.sidebar.GetSidebarContentWidth()
 	var cmd tea.Cmd
 
+	if m.issueCreator.IsOpen() {
+		m.issueCreator.SetWidth(width)
+		m.sidebar.SetContent(m.issueCreator.View())
+		return nil
+	}
+
+	if m.palette.IsOpen() {
+		m.palette.SetSize(width, m.ctx.PreviewHeight-m.ctx.Styles.Sidebar.PagerHeight)
+		m.sidebar.SetContent(m.palette.View())
+		return nil
+	}
+
+	if m.taskHistory.IsOpen() {
+		m.taskHistory.SetSize(width, m.ctx.PreviewHeight-m.ctx.Styles.Sidebar.PagerHeight)
+		m.sidebar.SetContent(m.taskHistory.View())
+		return nil
+	}
+
 	if currRowData == nil {
 		m.sidebar.SetContent("")
 		return nil
@@ -734,12 +1158,15 @@ func (m *Model) syncSidebar() tea.Cmd {
 		m.sidebar.SetContent(m.branchSidebar.View())
 	case *data.PullRequestData:
 		m.prSidebar.SetSectionId(m.currSectionId)
-		m.prSidebar.SetRow(row)
+		m.prSidebar.SetRow(row, m.getPrFiles(row.Url))
+		if m.sidebar.IsOpen {
+			cmd = m.prSidebar.FetchTab()
+		}
 		m.prSidebar.SetWidth(width)
 		m.sidebar.SetContent(m.prSidebar.View())
 	case *data.IssueData:
 		m.issueSidebar.SetSectionId(m.currSectionId)
-		m.issueSidebar.SetRow(row)
+		cmd = m.issueSidebar.SetRow(row)
 		m.issueSidebar.SetWidth(width)
 		m.sidebar.SetContent(m.issueSidebar.View())
 	}
@@ -747,6 +1174,21 @@ func (m *Model) syncSidebar() tea.Cmd {
 	return cmd
 }
 
+// switchPrSidebarTab shows another tab of the PR sidebar, scrolled to where
+// it was when it was last shown.
+func (m *Model) switchPrSidebarTab(delta int) tea.Cmd {
+	if m.getCurrRowData() == nil {
+		return nil
+	}
+
+	m.sidebar.IsOpen = true
+	m.syncMainContentSize()
+	fetchCmd := m.prSidebar.SwitchTab(delta, m.sidebar.ScrollOffset())
+	syncCmd := m.syncSidebar()
+	m.sidebar.SetScrollOffset(m.prSidebar.ScrollOffset())
+	return tea.Batch(fetchCmd, syncCmd)
+}
+
 func (m *Model) fetchAllViewSections() ([]section.Section, tea.Cmd) {
 	if m.ctx.View == config.RepoView {
 		var cmd tea.Cmd
@@ -809,6 +1251,23 @@ func (m *Model) setCurrentViewSections(newSections []section.Section) {
 	}
 }
 
+// switchView shows view, fetching its sections the first time it's shown.
+func (m *Model) switchView(view config.ViewType) tea.Cmd {
+	var cmd tea.Cmd
+	m.ctx.View = view
+	m.syncMainContentSize()
+	m.setCurrSectionId(m.getCurrentViewDefaultSection())
+	m.tabs.UpdateSectionsConfigs(&m.ctx)
+
+	currSections := m.getCurrentViewSections()
+	if len(currSections) == 0 {
+		newSections, fetchSectionsCmds := m.fetchAllViewSections()
+		m.setCurrentViewSections(newSections)
+		cmd = fetchSectionsCmds
+	}
+	return tea.Batch(cmd, m.onViewedRowChanged())
+}
+
 func (m *Model) switchSelectedView() config.ViewType {
 	repoFF := config.IsFeatureEnabled(config.FF_REPO_VIEW)
 
@@ -866,9 +1325,9 @@ func (m *Model) isUserDefinedKeybinding(msg tea.KeyMsg) bool {
 }
 
 func (m *Model) renderRunningTask() string {
-	tasks := make([]context.Task, 0, len(m.tasks))
-	for _, value := range m.tasks {
-		tasks = append(tasks, value)
+	tasks := m.tasks.Visible()
+	if len(tasks) == 0 {
+		return ""
 	}
 	sort.Slice(tasks, func(i, j int) bool {
 		if tasks[i].FinishedTime != nil && tasks[j].FinishedTime == nil {
@@ -908,12 +1367,7 @@ func (m *Model) renderRunningTask() string {
 			Render(fmt.Sprintf("%s %s", constants.SuccessIcon, task.FinishedText))
 	}
 
-	var numProcessing int
-	for _, task := range m.tasks {
-		if task.State == context.TaskStart {
-			numProcessing += 1
-		}
-	}
+	numProcessing := m.tasks.NumRunning()
 
 	stats := ""
 	if numProcessing > 1 {
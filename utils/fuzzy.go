@@ -0,0 +1,93 @@
+package utils
+
+import (
+	"sort"
+	"unicode"
+)
+
+const (
+	fuzzyMatchBonus       = 1
+	fuzzyConsecutiveBonus = 5
+	fuzzyWordStartBonus   = 8
+	fuzzyFirstCharBonus   = 10
+)
+
+// FuzzyMatch is a single result of FuzzyFind. MatchedIndexes holds the
+// positions (in runes) of the characters of Str that matched the pattern.
+type FuzzyMatch struct {
+	Str            string
+	Index          int
+	Score          int
+	MatchedIndexes []int
+}
+
+// FuzzyScore reports whether every character of pattern appears in str in
+// order, ignoring case, and returns a score that favors consecutive matches
+// and matches at the start of words.
+func FuzzyScore(pattern, str string) (int, []int, bool) {
+	p := []rune(pattern)
+	if len(p) == 0 {
+		return 0, nil, true
+	}
+
+	s := []rune(str)
+	matched := make([]int, 0, len(p))
+	score := 0
+	pi := 0
+	for si := 0; si < len(s) && pi < len(p); si++ {
+		if unicode.ToLower(s[si]) != unicode.ToLower(p[pi]) {
+			continue
+		}
+
+		score += fuzzyMatchBonus
+		if si == 0 {
+			score += fuzzyFirstCharBonus
+		} else if isWordSeparator(s[si-1]) {
+			score += fuzzyWordStartBonus
+		}
+		if len(matched) > 0 && matched[len(matched)-1] == si-1 {
+			score += fuzzyConsecutiveBonus
+		}
+
+		matched = append(matched, si)
+		pi++
+	}
+
+	if pi < len(p) {
+		return 0, nil, false
+	}
+
+	// Prefer shorter strings when everything else is equal
+	score -= len(s) - len(p)
+	return score, matched, true
+}
+
+// FuzzyFind returns the items of data that match pattern, best match first.
+// Items with the same score keep their original order.
+func FuzzyFind(pattern string, data []string) []FuzzyMatch {
+	matches := make([]FuzzyMatch, 0, len(data))
+	for i, str := range data {
+		score, matched, ok := FuzzyScore(pattern, str)
+		if !ok {
+			continue
+		}
+		matches = append(matches, FuzzyMatch{
+			Str:            str,
+			Index:          i,
+			Score:          score,
+			MatchedIndexes: matched,
+		})
+	}
+
+	if pattern != "" {
+		sort.SliceStable(matches, func(i, j int) bool {
+			return matches[i].Score > matches[j].Score
+		})
+	}
+
+	return matches
+}
+
+func isWordSeparator(r rune) bool {
+	return unicode.IsSpace(r) || r == '-' || r == '_' || r == '/' || r == ':' || r == '.'
+}
@@ -0,0 +1,95 @@
+package utils_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dlvhdr/gh-dash/v4/utils"
+)
+
+func TestFuzzyScore(t *testing.T) {
+	testCases := map[string]struct {
+		pattern     string
+		str         string
+		wantOk      bool
+		wantIndexes []int
+	}{
+		"empty pattern matches anything": {
+			pattern: "",
+			str:     "bug",
+			wantOk:  true,
+		},
+		"exact match": {
+			pattern:     "bug",
+			str:         "bug",
+			wantOk:      true,
+			wantIndexes: []int{0, 1, 2},
+		},
+		"case insensitive": {
+			pattern:     "BUG",
+			str:         "Bug",
+			wantOk:      true,
+			wantIndexes: []int{0, 1, 2},
+		},
+		"subsequence": {
+			pattern:     "gfi",
+			str:         "good first issue",
+			wantOk:      true,
+			wantIndexes: []int{0, 5, 6},
+		},
+		"out of order": {
+			pattern: "gub",
+			str:     "bug",
+			wantOk:  false,
+		},
+		"pattern longer than string": {
+			pattern: "bugs",
+			str:     "bug",
+			wantOk:  false,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			_, indexes, ok := utils.FuzzyScore(tc.pattern, tc.str)
+			require.Equal(t, tc.wantOk, ok)
+			require.Equal(t, tc.wantIndexes, indexes)
+		})
+	}
+}
+
+func TestFuzzyFind(t *testing.T) {
+	testCases := map[string]struct {
+		pattern string
+		data    []string
+		want    []string
+	}{
+		"empty pattern keeps order": {
+			pattern: "",
+			data:    []string{"enhancement", "bug", "docs"},
+			want:    []string{"enhancement", "bug", "docs"},
+		},
+		"filters non matching": {
+			pattern: "do",
+			data:    []string{"enhancement", "bug", "docs"},
+			want:    []string{"docs"},
+		},
+		"prefers word starts and consecutive matches": {
+			pattern: "fi",
+			data:    []string{"wontfix-it", "good first issue", "fix"},
+			want:    []string{"fix", "good first issue", "wontfix-it"},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			matches := utils.FuzzyFind(tc.pattern, tc.data)
+			got := make([]string, 0, len(matches))
+			for _, m := range matches {
+				got = append(got, m.Str)
+			}
+			require.Equal(t, tc.want, got)
+		})
+	}
+}